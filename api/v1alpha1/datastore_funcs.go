@@ -6,28 +6,81 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// GetContent is the resolver for the container of the Secret.
-// The bare content has priority over the external reference.
+// ContentFileRoot is the directory the file paths of the content references must be rooted at, such as the mount path
+// of a CSI secret store volume: when empty, the file paths are not resolved. The content is copied to the Tenant Control Planes
+// Secrets, thus the other files of the Kamaji Pod, such as its ServiceAccount token, must not be readable.
+var ContentFileRoot string
+
+// CheckContentFilePath ensures the given file path is absolute, clean, and rooted at the given directory:
+// an empty directory disables the file paths.
+func CheckContentFilePath(root, path string) error {
+	if len(root) == 0 {
+		return fmt.Errorf("the file paths are disabled, since no root directory is allowed")
+	}
+
+	if !filepath.IsAbs(path) || filepath.Clean(path) != path {
+		return fmt.Errorf("the file path %s must be an absolute and clean path", path)
+	}
+
+	if rel, err := filepath.Rel(root, path); err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("the file path %s must be rooted at %s", path, root)
+	}
+
+	return nil
+}
+
+// GetContent is the resolver for the container of the Secret, or of the file on the Kamaji Pod filesystem.
+// The bare content has priority over the external references.
 func (in *ContentRef) GetContent(ctx context.Context, client client.Client) ([]byte, error) {
 	if content := in.Content; len(content) > 0 {
 		return content, nil
 	}
 
+	if filePath := in.FilePath; len(filePath) > 0 {
+		if err := CheckContentFilePath(ContentFileRoot, filePath); err != nil {
+			return nil, err
+		}
+		// The symbolic links are resolved, since they could point outside the root directory.
+		resolved, err := filepath.EvalSymlinks(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read file %s: %w", filePath, err)
+		}
+
+		root, err := filepath.EvalSymlinks(ContentFileRoot)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve the root directory %s: %w", ContentFileRoot, err)
+		}
+
+		if err = CheckContentFilePath(root, resolved); err != nil {
+			return nil, fmt.Errorf("the file path %s resolves outside the root directory: %w", filePath, err)
+		}
+
+		content, err := os.ReadFile(resolved)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read file %s: %w", filePath, err)
+		}
+
+		return content, nil
+	}
+
 	secretRef := in.SecretRef
 
 	if secretRef == nil {
-		return nil, fmt.Errorf("no bare content and no external Secret reference")
+		return nil, fmt.Errorf("no bare content, no file path, and no external Secret reference")
 	}
 
 	secret, namespacedName := &corev1.Secret{}, types.NamespacedName{Name: secretRef.Name, Namespace: secretRef.Namespace}
 	if err := client.Get(ctx, namespacedName, secret); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("cannot retrieve secret %s, for key %s: %w", namespacedName.String(), secretRef.KeyPath, err)
 	}
 
 	v, ok := secret.Data[string(secretRef.KeyPath)]
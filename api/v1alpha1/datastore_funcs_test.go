@@ -0,0 +1,69 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestContentRefFilePath(t *testing.T) {
+	root, outside := t.TempDir(), t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(root, "ca.crt"), []byte("content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(outside, "token"), []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Symlink(filepath.Join(outside, "token"), filepath.Join(root, "token")); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(previous string) {
+		ContentFileRoot = previous
+	}(ContentFileRoot)
+
+	for _, tc := range []struct {
+		name    string
+		root    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{name: "happy path", root: root, path: filepath.Join(root, "ca.crt"), want: "content"},
+		{name: "missing file", root: root, path: filepath.Join(root, "missing.crt"), wantErr: true},
+		{name: "non absolute path", root: root, path: "ca.crt", wantErr: true},
+		{name: "non clean path", root: root, path: root + "/nested/../ca.crt", wantErr: true},
+		{name: "outside the root", root: root, path: filepath.Join(outside, "token"), wantErr: true},
+		{name: "the root itself", root: root, path: root, wantErr: true},
+		{name: "symbolic link escaping the root", root: root, path: filepath.Join(root, "token"), wantErr: true},
+		{name: "disabled", root: "", path: filepath.Join(root, "ca.crt"), wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ContentFileRoot = tc.root
+
+			content, err := (&ContentRef{FilePath: tc.path}).GetContent(context.Background(), nil)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got the content %q", content)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(content) != tc.want {
+				t.Fatalf("expected the content %q, got %q", tc.want, content)
+			}
+		})
+	}
+}
@@ -43,11 +43,24 @@ type TLSConfig struct {
 	CertificateAuthority CertKeyPair `json:"certificateAuthority"`
 	// Specifies the SSL/TLS key and private key pair used to connect to the data store.
 	ClientCertificate ClientCertificate `json:"clientCertificate"`
+	// Overrides the server name used by Kamaji to verify the data store server certificate, such as when fronted by a load balancer
+	// whose certificate does not include the endpoints host: when empty, the endpoint host is used.
+	// The Tenant Control Planes are not affected, since Kine, and the kube-apiserver etcd client, do not support overriding it.
+	ServerName string `json:"serverName,omitempty"`
 }
 
 type ClientCertificate struct {
 	Certificate ContentRef `json:"certificate"`
 	PrivateKey  ContentRef `json:"privateKey"`
+	// Next is the client certificate replacing the current one upon a rotation:
+	// when valid, it's presented to the data store in place of the current one, which must be still accepted
+	// until the rotation is completed by promoting the next certificate as the current one.
+	Next *ClientCertificateKeyPair `json:"next,omitempty"`
+}
+
+type ClientCertificateKeyPair struct {
+	Certificate ContentRef `json:"certificate"`
+	PrivateKey  ContentRef `json:"privateKey"`
 }
 
 type CertKeyPair struct {
@@ -63,9 +76,13 @@ type BasicAuth struct {
 
 type ContentRef struct {
 	// Bare content of the file, base64 encoded.
-	// It has precedence over the SecretReference value.
+	// It has precedence over the SecretReference, and the FilePath, values.
 	Content   []byte           `json:"content,omitempty"`
 	SecretRef *SecretReference `json:"secretReference,omitempty"`
+	// Absolute path of the file on the Kamaji Pod filesystem storing the content, such as a volume mounted by
+	// a CSI secret store driver: it's mutually exclusive with the SecretReference value, and supported by DataStores only.
+	// It must be rooted at the directory allowed by the --datastore-file-root flag, disabling the file paths when empty.
+	FilePath string `json:"filePath,omitempty"`
 }
 
 // +kubebuilder:validation:MinLength=1
@@ -82,12 +99,38 @@ type SecretReference struct {
 type DataStoreStatus struct {
 	// List of the Tenant Control Planes, namespaced named, using this data store.
 	UsedBy []string `json:"usedBy,omitempty"`
+	// Conditions contains the latest observations of the data store state.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// LastValidationTime is the last time the data store content, TLS configuration, and connection have been successfully validated:
+	// since each status update triggers the reconciliation again, it's refreshed at most once per health check interval,
+	// and no more than once per minute, thus lagging behind the last successful validation up to that interval.
+	// It's left unchanged upon failures.
+	LastValidationTime *metav1.Time `json:"lastValidationTime,omitempty"`
 }
 
+const (
+	// DataStoreTLSValidCondition reports if the client certificates chain up to the data store Certificate Authority,
+	// with the client authentication usages: it's reported only when the strict TLS validation is enabled.
+	DataStoreTLSValidCondition = "TLSValid"
+	// DataStoreReadyCondition reports if the Certificate Authority certificate, and the client certificate and private key,
+	// of the data store are retrieved, and well-formed.
+	DataStoreReadyCondition = "Ready"
+	// DataStoreConnectionHealthyCondition reports if the data store endpoints are reachable by Kamaji,
+	// using the DataStore credentials: it's checked periodically according to the health check interval.
+	DataStoreConnectionHealthyCondition = "ConnectionHealthy"
+	// DataStoreNextClientCertificateValidCondition reports if the next client certificate is retrieved, well-formed,
+	// and matching its private key: it's reported only when the next client certificate is specified.
+	DataStoreNextClientCertificateValidCondition = "NextClientCertificateValid"
+)
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster
 //+kubebuilder:printcolumn:name="Driver",type="string",JSONPath=".spec.driver",description="Kamaji data store driver"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Data store readiness"
+//+kubebuilder:printcolumn:name="Last Validation",type="date",JSONPath=".status.lastValidationTime",description="Last successful validation, refreshed at most once per health check interval"
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
 
 // DataStore is the Schema for the datastores API.
@@ -59,6 +59,16 @@ func (d *DatastoreUsedSecret) ExtractValue() client.IndexerFunc {
 			res = append(res, d.namespacedName(*ds.Spec.TLSConfig.ClientCertificate.PrivateKey.SecretRef))
 		}
 
+		if next := ds.Spec.TLSConfig.ClientCertificate.Next; next != nil {
+			if next.Certificate.SecretRef != nil {
+				res = append(res, d.namespacedName(*next.Certificate.SecretRef))
+			}
+
+			if next.PrivateKey.SecretRef != nil {
+				res = append(res, d.namespacedName(*next.PrivateKey.SecretRef))
+			}
+		}
+
 		return res
 	}
 }
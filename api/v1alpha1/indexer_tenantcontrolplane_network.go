@@ -0,0 +1,44 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/clastix/kamaji/internal/constants"
+)
+
+const (
+	TenantControlPlaneNetworkKey = "metadata.labels.network"
+)
+
+// TenantControlPlaneNetwork indexes the Tenant Control Planes by the shared network label,
+// allowing to retrieve the CIDRs assigned to the tenants of the same network.
+type TenantControlPlaneNetwork struct{}
+
+func (t *TenantControlPlaneNetwork) Object() client.Object {
+	return &TenantControlPlane{}
+}
+
+func (t *TenantControlPlaneNetwork) Field() string {
+	return TenantControlPlaneNetworkKey
+}
+
+func (t *TenantControlPlaneNetwork) ExtractValue() client.IndexerFunc {
+	return func(object client.Object) []string {
+		network, ok := object.GetLabels()[constants.NetworkLabel]
+		if !ok || len(network) == 0 {
+			return nil
+		}
+
+		return []string{network}
+	}
+}
+
+func (t *TenantControlPlaneNetwork) SetupWithManager(ctx context.Context, mgr controllerruntime.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, t.Object(), t.Field(), t.ExtractValue())
+}
@@ -0,0 +1,39 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	TenantControlPlaneSpecDataStoreKey = "spec.dataStore"
+)
+
+// TenantControlPlaneSpecDataStore indexes the Tenant Control Planes by the requested DataStore,
+// which could differ from the used one, such as when it doesn't exist yet.
+type TenantControlPlaneSpecDataStore struct{}
+
+func (t *TenantControlPlaneSpecDataStore) Object() client.Object {
+	return &TenantControlPlane{}
+}
+
+func (t *TenantControlPlaneSpecDataStore) Field() string {
+	return TenantControlPlaneSpecDataStoreKey
+}
+
+func (t *TenantControlPlaneSpecDataStore) ExtractValue() client.IndexerFunc {
+	return func(object client.Object) []string {
+		tcp := object.(*TenantControlPlane) //nolint:forcetypeassert
+
+		return []string{tcp.Spec.DataStore}
+	}
+}
+
+func (t *TenantControlPlaneSpecDataStore) SetupWithManager(ctx context.Context, mgr controllerruntime.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, t.Object(), t.Field(), t.ExtractValue())
+}
@@ -14,9 +14,19 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/clastix/kamaji/internal/constants"
 	kamajierrors "github.com/clastix/kamaji/internal/errors"
 )
 
+// CertificatesKeyAlgorithm returns the algorithm of the private keys generated for the Tenant Control Plane PKI.
+func (in *TenantControlPlane) CertificatesKeyAlgorithm() KeyAlgorithm {
+	if certificates := in.Spec.ControlPlane.Certificates; certificates != nil && len(certificates.KeyAlgorithm) > 0 {
+		return certificates.KeyAlgorithm
+	}
+
+	return KeyAlgorithmRSA2048
+}
+
 // AssignedControlPlaneAddress returns the announced address and port of a Tenant Control Plane.
 // In case of non-well formed values, or missing announcement, an error is returned.
 func (in *TenantControlPlane) AssignedControlPlaneAddress() (string, int32, error) {
@@ -70,3 +80,63 @@ func (in *TenantControlPlane) DeclaredControlPlaneAddress(ctx context.Context, c
 
 	return "", kamajierrors.MissingValidIPError{}
 }
+
+// ControllerManagerVersion returns the desired Kubernetes version of the kube-controller-manager component,
+// taking in consideration the optional override.
+func (in *TenantControlPlane) ControllerManagerVersion() string {
+	if v := in.Spec.Kubernetes.ComponentVersions.ControllerManager; len(v) > 0 {
+		return v
+	}
+
+	return in.Spec.Kubernetes.Version
+}
+
+// SchedulerVersion returns the desired Kubernetes version of the kube-scheduler component,
+// taking in consideration the optional override.
+func (in *TenantControlPlane) SchedulerVersion() string {
+	if v := in.Spec.Kubernetes.ComponentVersions.Scheduler; len(v) > 0 {
+		return v
+	}
+
+	return in.Spec.Kubernetes.Version
+}
+
+// KineImage returns the Kine container image of the Tenant Control Plane,
+// taking in consideration the optional override of the given default one.
+func (in *TenantControlPlane) KineImage(defaultImage string) string {
+	if opts := in.Spec.DataStoreOptions; opts != nil && opts.Kine != nil && len(opts.Kine.Image) > 0 {
+		return opts.Kine.Image
+	}
+
+	return defaultImage
+}
+
+// IsQuarantined returns true if the Tenant Control Plane has been quarantined using the related annotation.
+func (in *TenantControlPlane) IsQuarantined() bool {
+	return in.GetAnnotations()[constants.QuarantineAnnotation] == "true"
+}
+
+// IsSuspended returns true if the Tenant Control Plane has been suspended by scaling its control plane Pods to zero.
+func (in *TenantControlPlane) IsSuspended() bool {
+	replicas := in.Spec.ControlPlane.Deployment.Replicas
+
+	return replicas != nil && *replicas == 0
+}
+
+// IsGarbageCollectorEnabled returns false if the garbage collector of the Tenant Control Plane has been disabled.
+func (in *TenantControlPlane) IsGarbageCollectorEnabled() bool {
+	if cm := in.Spec.ControlPlane.ControllerManager; cm != nil && cm.EnableGarbageCollector != nil {
+		return *cm.EnableGarbageCollector
+	}
+
+	return true
+}
+
+// IsServiceEnforced returns false if the drifts of the Tenant Control Plane Service must be only reported.
+func (in *TenantControlPlane) IsServiceEnforced() bool {
+	if enforce := in.Spec.ControlPlane.Service.Enforce; enforce != nil {
+		return *enforce
+	}
+
+	return true
+}
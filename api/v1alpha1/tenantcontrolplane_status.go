@@ -42,17 +42,40 @@ type PublicKeyPrivateKeyPairStatus struct {
 	SecretName string      `json:"secretName,omitempty"`
 	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
 	Checksum   string      `json:"checksum,omitempty"`
+	// Rotation tracks the last signing key rotation.
+	Rotation *KeyRotationStatus `json:"rotation,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Overlapping;Completed
+type KeyRotationPhase string
+
+const (
+	// KeyRotationPhaseOverlapping means the previous public key is still used to verify the issued tokens.
+	KeyRotationPhaseOverlapping KeyRotationPhase = "Overlapping"
+	// KeyRotationPhaseCompleted means the previous public key has been removed from the verification set.
+	KeyRotationPhaseCompleted KeyRotationPhase = "Completed"
+)
+
+// KeyRotationStatus defines the status of a signing key rotation.
+type KeyRotationStatus struct {
+	// The rotateSigningKey value which triggered the rotation.
+	Trigger string           `json:"trigger,omitempty"`
+	Phase   KeyRotationPhase `json:"phase,omitempty"`
+	// The time the new signing key has been generated, starting the overlap.
+	StartTime metav1.Time `json:"startTime,omitempty"`
 }
 
 // CertificatesStatus defines the observed state of ETCD TLSConfig.
 type CertificatesStatus struct {
-	CA                     CertificatePrivateKeyPairStatus `json:"ca,omitempty"`
-	APIServer              CertificatePrivateKeyPairStatus `json:"apiServer,omitempty"`
-	APIServerKubeletClient CertificatePrivateKeyPairStatus `json:"apiServerKubeletClient,omitempty"`
-	FrontProxyCA           CertificatePrivateKeyPairStatus `json:"frontProxyCA,omitempty"`
-	FrontProxyClient       CertificatePrivateKeyPairStatus `json:"frontProxyClient,omitempty"`
-	SA                     PublicKeyPrivateKeyPairStatus   `json:"sa,omitempty"`
-	ETCD                   *ETCDCertificatesStatus         `json:"etcd,omitempty"`
+	CA                     CertificatePrivateKeyPairStatus  `json:"ca,omitempty"`
+	APIServer              CertificatePrivateKeyPairStatus  `json:"apiServer,omitempty"`
+	APIServerKubeletClient CertificatePrivateKeyPairStatus  `json:"apiServerKubeletClient,omitempty"`
+	FrontProxyCA           CertificatePrivateKeyPairStatus  `json:"frontProxyCA,omitempty"`
+	FrontProxyClient       CertificatePrivateKeyPairStatus  `json:"frontProxyClient,omitempty"`
+	SA                     PublicKeyPrivateKeyPairStatus    `json:"sa,omitempty"`
+	ETCD                   *ETCDCertificatesStatus          `json:"etcd,omitempty"`
+	APIServerSNI           *CertificatePrivateKeyPairStatus `json:"apiServerSNI,omitempty"`
+	ClusterCABundle        *CertificatePrivateKeyPairStatus `json:"clusterCABundle,omitempty"`
 }
 
 type DataStoreCertificateStatus struct {
@@ -73,6 +96,35 @@ type DataStoreSetupStatus struct {
 	Checksum   string      `json:"checksum,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=Exporting;Importing;Validating;Completed;Failed
+type DataStoreMigrationPhase string
+
+const (
+	DataStoreMigrationExporting  DataStoreMigrationPhase = "Exporting"
+	DataStoreMigrationImporting  DataStoreMigrationPhase = "Importing"
+	DataStoreMigrationValidating DataStoreMigrationPhase = "Validating"
+	DataStoreMigrationCompleted  DataStoreMigrationPhase = "Completed"
+	// DataStoreMigrationFailed is reported upon any failure: the migration is retried from scratch,
+	// replacing the objects partially imported to the target DataStore.
+	DataStoreMigrationFailed DataStoreMigrationPhase = "Failed"
+)
+
+// DataStoreMigrationStatus contains the progress of a migration between DataStores backed by different drivers.
+type DataStoreMigrationStatus struct {
+	// The DataStore the data is migrated from.
+	Source string `json:"source,omitempty"`
+	// The DataStore the data is migrated to.
+	Target string                  `json:"target,omitempty"`
+	Phase  DataStoreMigrationPhase `json:"phase,omitempty"`
+	// The amount of Kubernetes objects migrated to the target DataStore.
+	ObjectCount int `json:"objectCount,omitempty"`
+	// Checksum of the migrated Kubernetes objects, used to validate the data integrity.
+	Checksum string `json:"checksum,omitempty"`
+	// Human-readable message reporting migration failures.
+	Message    string      `json:"message,omitempty"`
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+}
+
 // StorageStatus defines the observed state of StorageStatus.
 type StorageStatus struct {
 	Driver        string                     `json:"driver,omitempty"`
@@ -80,6 +132,8 @@ type StorageStatus struct {
 	Config        DataStoreConfigStatus      `json:"config,omitempty"`
 	Setup         DataStoreSetupStatus       `json:"setup,omitempty"`
 	Certificate   DataStoreCertificateStatus `json:"certificate,omitempty"`
+	// Migration contains the progress of the latest migration between DataStores with different drivers.
+	Migration *DataStoreMigrationStatus `json:"migration,omitempty"`
 }
 
 // KubeconfigStatus contains information about the generated kubeconfig.
@@ -145,11 +199,89 @@ type AddonStatus struct {
 	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
 }
 
+// MonitoringStatus contains information about the resources required to scrape the kube-apiserver metrics.
+type MonitoringStatus struct {
+	// The Service exposing the kube-apiserver metrics.
+	MetricsService KubernetesServiceStatus `json:"metricsService,omitempty"`
+	// The Secret in the Tenant Control Plane namespace containing the bearer token and the CA required to scrape the metrics.
+	TokenSecretName string      `json:"tokenSecretName,omitempty"`
+	LastUpdate      metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// AddonObjectReference references an object applied to the Tenant Cluster by an Addon.
+type AddonObjectReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// CNIStatus defines the observed state of the CNI Addon.
+type CNIStatus struct {
+	AddonStatus `json:",inline"`
+	// Checksum of the applied manifests.
+	Checksum string `json:"checksum,omitempty"`
+	// Objects applied to the Tenant Cluster, used to prune the ones no more desired.
+	Objects []AddonObjectReference `json:"objects,omitempty"`
+	// Ready is true when all the CNI DaemonSets are scheduled, updated, and available.
+	Ready bool `json:"ready"`
+}
+
+// DefaultsStatus defines the observed state of the policy objects seeded in the Tenant Cluster.
+type DefaultsStatus struct {
+	AddonStatus `json:",inline"`
+	// Checksum of the applied manifests.
+	Checksum string `json:"checksum,omitempty"`
+	// Objects applied to the Tenant Cluster, used to prune the ones no more desired.
+	Objects []AddonObjectReference `json:"objects,omitempty"`
+}
+
+// NetworkPoliciesStatus defines the observed state of the NetworkPolicies seeded in the Tenant Cluster.
+type NetworkPoliciesStatus struct {
+	AddonStatus `json:",inline"`
+	// Checksum of the applied manifests.
+	Checksum string `json:"checksum,omitempty"`
+	// Objects applied to the Tenant Cluster, used to prune the ones no more desired.
+	Objects []AddonObjectReference `json:"objects,omitempty"`
+}
+
+// WebhooksStatus defines the observed state of the webhook configurations seeded in the Tenant Cluster.
+type WebhooksStatus struct {
+	AddonStatus `json:",inline"`
+	// Checksum of the applied manifests, and of the injected CA bundle.
+	Checksum string `json:"checksum,omitempty"`
+	// Objects applied to the Tenant Cluster, used to prune the ones no more desired.
+	Objects []AddonObjectReference `json:"objects,omitempty"`
+}
+
+// APFBootstrapStatus defines the observed state of the API Priority and Fairness objects seeded in the Tenant Cluster.
+type APFBootstrapStatus struct {
+	// Checksum of the applied manifests.
+	Checksum string `json:"checksum,omitempty"`
+	// Objects applied to the Tenant Cluster, used to prune the ones no more desired.
+	Objects    []AddonObjectReference `json:"objects,omitempty"`
+	LastUpdate metav1.Time            `json:"lastUpdate,omitempty"`
+}
+
 // AddonsStatus defines the observed state of the different Addons.
 type AddonsStatus struct {
-	CoreDNS      AddonStatus        `json:"coreDNS,omitempty"`
-	KubeProxy    AddonStatus        `json:"kubeProxy,omitempty"`
-	Konnectivity KonnectivityStatus `json:"konnectivity,omitempty"`
+	CNI             CNIStatus             `json:"cni,omitempty"`
+	CoreDNS         AddonStatus           `json:"coreDNS,omitempty"`
+	Defaults        DefaultsStatus        `json:"defaults,omitempty"`
+	KubeProxy       AddonStatus           `json:"kubeProxy,omitempty"`
+	Konnectivity    KonnectivityStatus    `json:"konnectivity,omitempty"`
+	NetworkPolicies NetworkPoliciesStatus `json:"networkPolicies,omitempty"`
+	Webhooks        WebhooksStatus        `json:"webhooks,omitempty"`
+}
+
+// AutomationStatus contains information about the automation ServiceAccount and its issued token.
+type AutomationStatus struct {
+	ServiceAccount ExternalKubernetesObjectStatus `json:"sa,omitempty"`
+	// The name of the Secret in the Tenant Control Plane Namespace containing the issued token.
+	SecretName string `json:"secretName,omitempty"`
+	// Expiration time of the issued token.
+	ExpirationTimestamp metav1.Time `json:"expirationTimestamp,omitempty"`
+	LastUpdate          metav1.Time `json:"lastUpdate,omitempty"`
 }
 
 // TenantControlPlaneStatus defines the observed state of TenantControlPlane.
@@ -171,6 +303,78 @@ type TenantControlPlaneStatus struct {
 	ControlPlaneEndpoint string `json:"controlPlaneEndpoint,omitempty"`
 	// Addons contains the status of the different Addons
 	Addons AddonsStatus `json:"addons,omitempty"`
+	// Automation contains the status of the automation ServiceAccount and its token
+	Automation *AutomationStatus `json:"automation,omitempty"`
+	// Monitoring contains the status of the metrics Service and its scraping token
+	Monitoring *MonitoringStatus `json:"monitoring,omitempty"`
+	// APFBootstrap contains the status of the API Priority and Fairness objects seeded in the Tenant Cluster
+	APFBootstrap *APFBootstrapStatus `json:"apfBootstrap,omitempty"`
+	// NodeCount is the number of nodes registered in the Tenant Control Plane.
+	NodeCount int32 `json:"nodeCount,omitempty"`
+	// ConfigurationSnapshot contains the snapshot of the effective configuration of the Tenant Control Plane,
+	// updated only upon changes: it's reported only when enabled in the operator.
+	ConfigurationSnapshot *ConfigurationSnapshotStatus `json:"configurationSnapshot,omitempty"`
+	// Conditions contains the latest observations of the Tenant Control Plane state.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+const (
+	// VersionSkewViolationCondition reports if the Control Plane components versions
+	// are not complying with the Kubernetes version skew policy.
+	VersionSkewViolationCondition = "VersionSkewViolation"
+	// LargeTenantCondition reports if the Tenant Control Plane node count crossed the LargeTenant threshold.
+	LargeTenantCondition = "LargeTenant"
+	// InClusterReachabilityCondition reports if the API Server is reachable from the Tenant Cluster network
+	// through the kubernetes Service, as verified by the in-cluster probe.
+	InClusterReachabilityCondition = "InClusterReachability"
+	// WaitingForLoadBalancerCondition reports if the Tenant Control Plane is waiting for the LoadBalancer Service address.
+	WaitingForLoadBalancerCondition = "WaitingForLoadBalancer"
+	// DataStoreReachableCondition reports if the DataStore is reachable by Kamaji:
+	// upon the Tenant Control Plane creation the failures are reported as Initializing during the grace period.
+	DataStoreReachableCondition = "DataStoreReachable"
+	// DataStoreNotFoundCondition reports if the DataStore of the Tenant Control Plane does not exist:
+	// the reconciliation is resumed once the DataStore is created.
+	DataStoreNotFoundCondition = "DataStoreNotFound"
+	// QuarantinedCondition reports if the Tenant Control Plane has been quarantined by the operator,
+	// using the kamaji.clastix.io/quarantine annotation.
+	QuarantinedCondition = "Quarantined"
+	// SuspendedCondition reports if the Tenant Control Plane has been suspended by scaling its replicas to zero.
+	SuspendedCondition = "Suspended"
+	// KineImageCompatibleCondition reports if the Kine image supports the driver of the DataStore,
+	// according to the Kine capability matrix known by Kamaji.
+	KineImageCompatibleCondition = "KineImageCompatible"
+	// ServiceDriftCondition reports if the Kamaji-managed fields of the Tenant Control Plane Service drifted
+	// from the desired state, and if the drift has been reverted.
+	ServiceDriftCondition = "ServiceDrift"
+	// DataStoreSchemaMismatchCondition reports if the kine table of the Tenant Control Plane has been created
+	// by a Kine release incompatible with the one used by Kamaji.
+	DataStoreSchemaMismatchCondition = "DatastoreSchemaMismatch"
+)
+
+// ConfigurationSnapshotStatus defines the snapshot of the effective configuration of a Tenant Control Plane,
+// the sensitive values, such as the rendered arguments, are reported as checksums.
+type ConfigurationSnapshotStatus struct {
+	// Checksum of the whole effective configuration.
+	Checksum string `json:"checksum,omitempty"`
+	// LastChange is the time the effective configuration has been changed.
+	LastChange metav1.Time `json:"lastChange,omitempty"`
+	// The configuration of the Tenant Control Plane containers.
+	// +listType=map
+	// +listMapKey=name
+	Components []ComponentConfigurationSnapshot `json:"components,omitempty"`
+	// The SHA-256 fingerprints of the Tenant Control Plane certificates, by name.
+	CertificateFingerprints map[string]string `json:"certificateFingerprints,omitempty"`
+}
+
+// ComponentConfigurationSnapshot defines the snapshot of the effective configuration of a Tenant Control Plane container.
+type ComponentConfigurationSnapshot struct {
+	Name string `json:"name"`
+	// The container image, along with its version.
+	Image string `json:"image,omitempty"`
+	// Checksum of the rendered arguments.
+	ArgsChecksum string `json:"argsChecksum,omitempty"`
 }
 
 // KubernetesStatus defines the status of the resources deployed in the management cluster,
@@ -181,9 +385,37 @@ type KubernetesStatus struct {
 	Deployment KubernetesDeploymentStatus `json:"deployment,omitempty"`
 	Service    KubernetesServiceStatus    `json:"service,omitempty"`
 	Ingress    *KubernetesIngressStatus   `json:"ingress,omitempty"`
+	// Drain contains the information regarding the Tenant Control Plane Pods draining their connections.
+	Drain *KubernetesDrainStatus `json:"drain,omitempty"`
+	// ControllerManagerDeployment is the status of the controller-manager Deployment, when isolated.
+	ControllerManagerDeployment *KubernetesDeploymentStatus `json:"controllerManagerDeployment,omitempty"`
+	// SchedulerDeployment is the status of the scheduler Deployment, when isolated.
+	SchedulerDeployment *KubernetesDeploymentStatus `json:"schedulerDeployment,omitempty"`
+	// ReadReplicasDeployment is the status of the kube-apiserver read replicas Deployment, when enabled.
+	ReadReplicasDeployment *KubernetesDeploymentStatus `json:"readReplicasDeployment,omitempty"`
+	// ReadReplicasService is the status of the Service exposing the kube-apiserver read replicas, when enabled.
+	ReadReplicasService *KubernetesServiceStatus `json:"readReplicasService,omitempty"`
+}
+
+// KubernetesDrainStatus defines the status of the Tenant Control Plane Pods draining their connections upon termination.
+type KubernetesDrainStatus struct {
+	// The Tenant Control Plane Pods currently terminating, and draining their in-flight connections.
+	DrainingPods []DrainingPod `json:"drainingPods,omitempty"`
+	// The duration of the last completed draining.
+	LastDrainDuration *metav1.Duration `json:"lastDrainDuration,omitempty"`
+	// Last time when a draining has been completed.
+	LastDrainCompletion *metav1.Time `json:"lastDrainCompletion,omitempty"`
+}
+
+// DrainingPod defines a Tenant Control Plane Pod draining its connections.
+type DrainingPod struct {
+	// The name of the terminating Pod.
+	Name string `json:"name"`
+	// The time the Pod entered the termination.
+	Since metav1.Time `json:"since"`
 }
 
-// +kubebuilder:validation:Enum=Provisioning;CertificateAuthorityRotating;Upgrading;Migrating;Ready;NotReady
+// +kubebuilder:validation:Enum=Provisioning;CertificateAuthorityRotating;Upgrading;Migrating;Ready;NotReady;Suspended
 type KubernetesVersionStatus string
 
 var (
@@ -193,6 +425,7 @@ var (
 	VersionMigrating    KubernetesVersionStatus = "Migrating"
 	VersionReady        KubernetesVersionStatus = "Ready"
 	VersionNotReady     KubernetesVersionStatus = "NotReady"
+	VersionSuspended    KubernetesVersionStatus = "Suspended"
 )
 
 type KubernetesVersion struct {
@@ -214,6 +447,20 @@ type KubernetesDeploymentStatus struct {
 	Namespace string `json:"namespace"`
 	// Last time when deployment was updated
 	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+	// LastChangeReason summarizes the changes of the last reconciliation rolling out the Tenant Control Plane Pods.
+	LastChangeReason *DeploymentChangeReason `json:"lastChangeReason,omitempty"`
+}
+
+// DeploymentChangeReason summarizes the Deployment Pod template changes triggering a rollout.
+type DeploymentChangeReason struct {
+	// The causes of the rollout, such as CertificateRotation, KubeconfigRotation, FlagsChange, VersionChange,
+	// ResourcesChange, DataStoreChange, or SpecChange for any other Pod template change.
+	Reasons []string `json:"reasons"`
+	// The changed command-line arguments in the <container>: <flag> <old value> -> <new value> form,
+	// the values of the sensitive flags are redacted.
+	ChangedArgs []string `json:"changedArgs,omitempty"`
+	// Last time when a Pod template change has been applied.
+	LastUpdate metav1.Time `json:"lastUpdate"`
 }
 
 // KubernetesServiceStatus defines the status for the Tenant Control Plane Service in the management cluster.
@@ -31,6 +31,14 @@ type NetworkProfileSpec struct {
 	PodCIDR string `json:"podCidr,omitempty"`
 	// +kubebuilder:default={"10.96.0.10"}
 	DNSServiceIPs []string `json:"dnsServiceIPs,omitempty"`
+	// Port range reserved for the NodePort Services of the Tenant cluster, mapped to the --service-node-port-range flag.
+	// The range is inclusive, and expressed in the <first>-<last> form (e.g.: 30000-32767).
+	// +kubebuilder:validation:Pattern=`^[0-9]{1,5}-[0-9]{1,5}$`
+	ServiceNodePortRange string `json:"serviceNodePortRange,omitempty"`
+	// Declares the worker nodes are not directly reachable by the API Server, such as the ones behind a NAT:
+	// the Konnectivity addon is required to route the API Server to nodes traffic, such as logs, exec, and port-forward.
+	// Disabling the Konnectivity addon is warned, or rejected according to the Kamaji configuration.
+	KonnectivityRequired bool `json:"konnectivityRequired,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=Hostname;InternalIP;ExternalIP;InternalDNS;ExternalDNS
@@ -65,6 +73,19 @@ type KubernetesSpec struct {
 	// Full reference available here: https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers
 	// +kubebuilder:default=CertificateApproval;CertificateSigning;CertificateSubjectRestriction;DefaultIngressClass;DefaultStorageClass;DefaultTolerationSeconds;LimitRanger;MutatingAdmissionWebhook;NamespaceLifecycle;PersistentVolumeClaimResize;Priority;ResourceQuota;RuntimeClass;ServiceAccount;StorageObjectInUseProtection;TaintNodesByCondition;ValidatingAdmissionWebhook
 	AdmissionControllers AdmissionControllers `json:"admissionControllers,omitempty"`
+	// ComponentVersions allows to override the Kubernetes version of the single Control Plane components,
+	// defaulting to the Kubernetes version of the Tenant Control Plane.
+	// The overrides must comply with the Kubernetes version skew policy: the components cannot be newer
+	// than the API Server, and cannot be older than one minor version.
+	ComponentVersions ComponentVersions `json:"componentVersions,omitempty"`
+}
+
+// ComponentVersions defines the Kubernetes version overrides of the Control Plane components.
+type ComponentVersions struct {
+	// Kubernetes version of the kube-controller-manager component.
+	ControllerManager string `json:"controllerManager,omitempty"`
+	// Kubernetes version of the kube-scheduler component.
+	Scheduler string `json:"scheduler,omitempty"`
 }
 
 // AdditionalMetadata defines which additional metadata, such as labels and annotations, must be attached to the created resource.
@@ -82,6 +103,267 @@ type ControlPlane struct {
 	Service ServiceSpec `json:"service"`
 	// Defining the options for an Optional Ingress which will expose API Server of the Tenant Control Plane
 	Ingress *IngressSpec `json:"ingress,omitempty"`
+	// Defining the options for a least-privilege ServiceAccount created in the Tenant Control Plane,
+	// along with its issued token, meant to be used by provisioning pipelines in place of the admin kubeconfig.
+	Automation *AutomationSpec `json:"automation,omitempty"`
+	// Defining the options for the kube-apiserver component of the Tenant Control Plane.
+	APIServer *APIServerSpec `json:"apiServer,omitempty"`
+	// Defining the options to monitor the Tenant Control Plane components.
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+	// Defining the options for the certificates generated for the Tenant Control Plane.
+	Certificates *CertificatesSpec `json:"certificates,omitempty"`
+	// Defining the options for the kube-controller-manager component of the Tenant Control Plane.
+	ControllerManager *ControllerManagerSpec `json:"controllerManager,omitempty"`
+	// Defining the options for the additional kube-apiserver replicas meant to serve the read-heavy workloads,
+	// exposed through a dedicated Service named after the Tenant Control Plane with the -read suffix.
+	ReadReplicas *ReadReplicasSpec `json:"readReplicas,omitempty"`
+}
+
+// ReadReplicasSpec defines the options for the additional kube-apiserver replicas serving the read-heavy workloads.
+// They're sharing the same DataStore of the Tenant Control Plane, thus they're serving the same data, and are not
+// enforcing any read-only access since the kube-apiserver doesn't support it: the clients are expected to send
+// their mutating requests to the Tenant Control Plane endpoint, and the RBAC must be used to restrict them.
+type ReadReplicasSpec struct {
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// Number of the kube-apiserver read replicas.
+	Replicas *int32 `json:"replicas,omitempty"`
+	// +kubebuilder:default=ClusterIP
+	// The type of the Service exposing the read replicas: the Node port, or the LoadBalancer address, are assigned
+	// by the management cluster, and must be added to the spec.networkProfile.certSANs to be served by the
+	// kube-apiserver certificate.
+	ServiceType ServiceType `json:"serviceType,omitempty"`
+}
+
+// ControllerManagerSpec defines the options for the kube-controller-manager component.
+type ControllerManagerSpec struct {
+	// +kubebuilder:default=true
+	// Enables the garbage collector, mapped to the --enable-garbage-collector flag of both the kube-controller-manager
+	// and the kube-apiserver. WARNING: when disabled, the dependent objects are not deleted along with their owners,
+	// and the objects with the foreground deletion finalizer are never removed, leaking resources in the Tenant Cluster.
+	// It's meant for debugging purposes only: a Warning event is emitted upon roll out. Upon change, the control plane Pods are rolled out.
+	EnableGarbageCollector *bool `json:"enableGarbageCollector,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=rsa-2048;rsa-4096;ecdsa-p256;ecdsa-p384
+type KeyAlgorithm string
+
+const (
+	KeyAlgorithmRSA2048   KeyAlgorithm = "rsa-2048"
+	KeyAlgorithmRSA4096   KeyAlgorithm = "rsa-4096"
+	KeyAlgorithmECDSAP256 KeyAlgorithm = "ecdsa-p256"
+	KeyAlgorithmECDSAP384 KeyAlgorithm = "ecdsa-p384"
+)
+
+// CertificatesSpec defines the options for the certificates generated for the Tenant Control Plane.
+type CertificatesSpec struct {
+	// +kubebuilder:default="rsa-2048"
+	// The algorithm of the private keys generated for the Tenant Control Plane PKI.
+	// Upon change, the certificates and kubeconfig files are regenerated, rolling out the control plane Pods:
+	// the Certificate Authorities and the Service Account signing key retain their algorithm until rotated,
+	// to avoid invalidating the worker nodes trust and the issued Service Account tokens.
+	KeyAlgorithm KeyAlgorithm `json:"keyAlgorithm,omitempty"`
+	// Additional PEM encoded Certificate Authorities, such as a corporate root, appended to the Tenant Control Plane CA
+	// when distributed to the in-cluster clients, through the kube-root-ca.crt ConfigMaps, and to the kubeconfig files.
+	// Upon change, the bundle is redistributed, rolling out the kube-controller-manager Pods and regenerating the kubeconfig files.
+	ClusterCABundleRef *ContentRef `json:"clusterCABundleRef,omitempty"`
+}
+
+// MonitoringSpec defines the options to monitor the Tenant Control Plane components.
+type MonitoringSpec struct {
+	// Exposes the kube-apiserver metrics with a dedicated Service, isolating the scraping traffic from the API one.
+	// A read-only ServiceAccount is created in the Tenant Control Plane, and its bearer token is stored in the
+	// Tenant Control Plane namespace, ready to be referenced by a Prometheus ServiceMonitor.
+	MetricsService *MetricsServiceSpec `json:"metricsService,omitempty"`
+}
+
+// MetricsServiceSpec defines the options for the Service exposing the kube-apiserver metrics.
+type MetricsServiceSpec struct {
+	// AdditionalMetadata defines which additional metadata, such as labels and annotations, must be attached to the created resource.
+	AdditionalMetadata AdditionalMetadata `json:"additionalMetadata,omitempty"`
+}
+
+// APIServerSpec defines the options for the kube-apiserver component.
+type APIServerSpec struct {
+	// Duration the API Server waits before timing out a request, mapped to the --request-timeout flag.
+	// Long-running requests, such as WATCH, are not affected by this setting.
+	RequestTimeout *metav1.Duration `json:"requestTimeout,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// Minimum number of seconds a handler must keep a long-running request open before timing it out,
+	// mapped to the --min-request-timeout flag.
+	MinRequestTimeout *int32 `json:"minRequestTimeout,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// Maximum number of non-mutating requests in flight at a given time, mapped to the --max-requests-inflight flag.
+	// When not specified, the defaults of the DataStore tier configured in the operator are applied upon creation.
+	MaxRequestsInflight *int32 `json:"maxRequestsInflight,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// Maximum number of mutating requests in flight at a given time, mapped to the --max-mutating-requests-inflight flag.
+	// When not specified, the defaults of the DataStore tier configured in the operator are applied upon creation.
+	MaxMutatingRequestsInflight *int32 `json:"maxMutatingRequestsInflight,omitempty"`
+	// Defining the API Priority and Fairness options.
+	APF *APFSpec `json:"apf,omitempty"`
+	// Explicit ordered list of the enabled Admission Controllers, mapped to the --enable-admission-plugins flag:
+	// when specified, it takes precedence over the spec.kubernetes.admissionControllers list.
+	// The ServiceAccount and NamespaceLifecycle Admission Controllers are required by Kamaji and cannot be omitted.
+	AdmissionControllersOrder AdmissionControllers `json:"admissionControllersOrder,omitempty"`
+	// List of additional serving certificates selected by the client SNI, mapped to the --tls-sni-cert-key flag.
+	// Each certificate must be valid for all of its hostnames.
+	SNICerts []SNICertificate `json:"sniCerts,omitempty"`
+	// Defining the tuning options of the kube-apiserver storage layer, and its caches.
+	Tuning *APIServerTuningSpec `json:"tuning,omitempty"`
+	// Allows the privileged containers in the Tenant Cluster, mapped to the --allow-privileged flag.
+	// When not specified, privileged containers are allowed as for the kubeadm clusters, since required by
+	// system components such as kube-proxy, and most of the CNIs.
+	AllowPrivileged *bool `json:"allowPrivileged,omitempty"`
+	// Configures the Service Account tokens signing key.
+	ServiceAccount *ServiceAccountSpec `json:"serviceAccount,omitempty"`
+	// The reconciler publishing the API Server addresses as endpoints of the kubernetes Service in the Tenant Cluster,
+	// mapped to the --endpoint-reconciler-type flag: when set to master-count, the --apiserver-count flag is aligned
+	// to the Tenant Control Plane replicas, when set to none, the endpoints must be managed externally.
+	// When not specified, the kube-apiserver default is used. Upon change, the control plane Pods are rolled out.
+	EndpointReconcilerType EndpointReconcilerType `json:"endpointReconcilerType,omitempty"`
+	// Configures the OpenID Connect authentication of the Tenant Cluster users, mapped to the --oidc-* flags.
+	// Upon change, the control plane Pods are rolled out.
+	OIDC *OIDCSpec `json:"oidc,omitempty"`
+	// List of the origins allowed by the kube-apiserver CORS policy, mapped to the --cors-allowed-origins flag,
+	// required by the web applications calling the Tenant Cluster API directly.
+	// Each origin is a regular expression, which should be anchored to prevent the partial matches, such as //example\.com(:|$).
+	// Upon change, the control plane Pods are rolled out.
+	CORSAllowedOrigins []string `json:"corsAllowedOrigins,omitempty"`
+	// Defining the graceful shutdown options of the kube-apiserver, allowing the zero-downtime rollouts.
+	GracefulShutdown *APIServerGracefulShutdownSpec `json:"gracefulShutdown,omitempty"`
+}
+
+// APIServerGracefulShutdownSpec defines the graceful shutdown options of the kube-apiserver component.
+type APIServerGracefulShutdownSpec struct {
+	// The grace period the watch requests are given to be gracefully terminated upon the kube-apiserver shutdown,
+	// mapped to the --shutdown-watch-termination-grace-period flag, available starting from Kubernetes v1.27.0.
+	// When not specified, the kube-apiserver default is used. Upon change, the control plane Pods are rolled out.
+	WatchTerminationGracePeriod *metav1.Duration `json:"watchTerminationGracePeriod,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=RS256;RS384;RS512;ES256;ES384;ES512;PS256;PS384;PS512
+type OIDCSigningAlgorithm string
+
+// OIDCSpec defines the OpenID Connect authentication options of the kube-apiserver.
+type OIDCSpec struct {
+	// +kubebuilder:validation:MinLength=1
+	// The URL of the OpenID issuer, mapped to the --oidc-issuer-url flag: only the HTTPS scheme is accepted.
+	IssuerURL string `json:"issuerURL"`
+	// +kubebuilder:validation:MinLength=1
+	// The client ID the ID Tokens must be issued for, mapped to the --oidc-client-id flag.
+	// Multiple audiences require the structured authentication configuration, which is not generally available
+	// in the supported Kubernetes versions.
+	ClientID string `json:"clientID"`
+	// The claim used as the user name, mapped to the --oidc-username-claim flag.
+	// When not specified, the kube-apiserver default is used.
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+	// The prefix prepended to the user names, mapped to the --oidc-username-prefix flag.
+	UsernamePrefix string `json:"usernamePrefix,omitempty"`
+	// The claim used as the user groups, mapped to the --oidc-groups-claim flag.
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+	// The prefix prepended to the user groups, mapped to the --oidc-groups-prefix flag.
+	GroupsPrefix string `json:"groupsPrefix,omitempty"`
+	// The signing algorithms accepted for the ID Tokens, mapped to the --oidc-signing-algs flag.
+	// When not specified, the kube-apiserver default is used, accepting RS256 only.
+	SigningAlgs []OIDCSigningAlgorithm `json:"signingAlgs,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=lease;master-count;none
+type EndpointReconcilerType string
+
+const (
+	EndpointReconcilerTypeLease       EndpointReconcilerType = "lease"
+	EndpointReconcilerTypeMasterCount EndpointReconcilerType = "master-count"
+	EndpointReconcilerTypeNone        EndpointReconcilerType = "none"
+)
+
+type ServiceAccountSpec struct {
+	// Triggers the rotation of the Service Account signing key when changed, such as with a timestamp:
+	// the previous public key is kept to verify the already issued tokens for the rotation overlap.
+	RotateSigningKey string `json:"rotateSigningKey,omitempty"`
+	// +kubebuilder:default="24h"
+	// The time the previous public key is kept to verify the Service Account tokens upon a signing key rotation,
+	// it should be greater than the maximum expiration of the tokens issued by the Tenant Cluster.
+	RotationOverlap *metav1.Duration `json:"rotationOverlap,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=application/json;application/yaml;application/vnd.kubernetes.protobuf
+type StorageMediaType string
+
+const (
+	StorageMediaTypeJSON     StorageMediaType = "application/json"
+	StorageMediaTypeYAML     StorageMediaType = "application/yaml"
+	StorageMediaTypeProtobuf StorageMediaType = "application/vnd.kubernetes.protobuf"
+)
+
+// APIServerTuningSpec defines the tuning options of the kube-apiserver storage layer, and its caches.
+type APIServerTuningSpec struct {
+	// The media type used to store the objects in the DataStore, mapped to the --storage-media-type flag.
+	// When not specified, it's defaulted according to the DataStore driver: application/json for the Kine drivers,
+	// application/vnd.kubernetes.protobuf for etcd. Upon change, the control plane Pods are rolled out.
+	StorageMediaType StorageMediaType `json:"storageMediaType,omitempty"`
+	// Enables the watch cache of the kube-apiserver, mapped to the --watch-cache flag.
+	// Disabling it lowers the memory footprint of the control plane Pods, at the cost of serving the list and watch
+	// requests from the DataStore, increasing its load, and the requests latency.
+	// When not specified, the kube-apiserver default is used, enabling it. Upon change, the control plane Pods are rolled out.
+	WatchCache *bool `json:"watchCache,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// The default size of the watch cache of the resources, mapped to the --default-watch-cache-size flag:
+	// zero disables the watch cache for the resources with no size specified in watchCacheSizes.
+	// When not specified, the kube-apiserver default is used. Upon change, the control plane Pods are rolled out.
+	DefaultWatchCacheSize *int32 `json:"defaultWatchCacheSize,omitempty"`
+	// The watch cache size of the given resources, mapped to the --watch-cache-sizes flag:
+	// the keys are the lowercase plural resource names, qualified by the API group for the non-core ones
+	// (e.g.: pods, deployments.apps), and the values are non-negative sizes, zero disabling the watch cache.
+	// Upon change, the control plane Pods are rolled out.
+	WatchCacheSizes map[string]int32 `json:"watchCacheSizes,omitempty"`
+}
+
+// SNICertificate defines a serving certificate of the kube-apiserver component along with the hostnames it serves.
+type SNICertificate struct {
+	// +kubebuilder:validation:MinItems=1
+	// Hostnames served with the given certificate, wildcard domains are allowed.
+	Hostnames   []string   `json:"hostnames"`
+	Certificate ContentRef `json:"certificate"`
+	PrivateKey  ContentRef `json:"privateKey"`
+}
+
+// APFSpec defines the API Priority and Fairness options of the kube-apiserver component.
+type APFSpec struct {
+	// +kubebuilder:default=true
+	// Toggles the API Priority and Fairness request handling, mapped to the --enable-priority-and-fairness flag.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Seeds the Tenant Cluster with the provided FlowSchemas and PriorityLevelConfigurations once the API Server is ready.
+	// Objects are applied using Server-Side Apply, and reconciled back upon any Tenant Control Plane reconciliation.
+	Bootstrap *APFBootstrapSpec `json:"bootstrap,omitempty"`
+}
+
+// APFBootstrapSpec defines the API Priority and Fairness objects seeded in the Tenant Cluster,
+// the allowed kinds are FlowSchema, and PriorityLevelConfiguration.
+type APFBootstrapSpec struct {
+	// Inline multi-document YAML manifests of the API Priority and Fairness objects.
+	// Mutually exclusive with ConfigMapRef.
+	Manifests string `json:"manifests,omitempty"`
+	// ConfigMap in the Tenant Control Plane namespace containing the API Priority and Fairness objects manifests,
+	// each key must contain multi-document YAML manifests, applied in the keys order.
+	// Mutually exclusive with Manifests.
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+}
+
+// AutomationSpec defines the ServiceAccount created in the Tenant Control Plane for automation purposes.
+type AutomationSpec struct {
+	// +kubebuilder:default="kamaji-automation"
+	// Name of the ServiceAccount created in the kube-system Namespace of the Tenant Control Plane.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// +kubebuilder:validation:MinItems=1
+	// List of the ClusterRole names bound to the ServiceAccount,
+	// the ClusterRoles must exist in the Tenant Control Plane.
+	ClusterRoles []string `json:"clusterRoles"`
+	// +kubebuilder:default="24h"
+	// Requested validity of the issued token, the token is rotated once two-thirds of its lifetime elapsed.
+	// The value cannot be lower than 10 minutes.
+	TokenTTL metav1.Duration `json:"tokenTTL,omitempty"`
 }
 
 // IngressSpec defines the options for the ingress which will expose API Server of the Tenant Control Plane.
@@ -108,6 +390,8 @@ type DeploymentSpec struct {
 	// +kubebuilder:default={registry:"registry.k8s.io",apiServerImage:"kube-apiserver",controllerManagerImage:"kube-controller-manager",schedulerImage:"kube-scheduler"}
 	RegistrySettings RegistrySettings `json:"registrySettings,omitempty"`
 	// +kubebuilder:default=2
+	// Number of the control plane Pods: zero suspends the Tenant Control Plane, scaling down all its Pods,
+	// while retaining the certificates, the DataStore data, and the configuration, until it's scaled up again.
 	Replicas *int32 `json:"replicas,omitempty"`
 	// NodeSelector is a selector which must be true for the pod to fit on a node.
 	// Selector which must match a node's labels for the pod to be scheduled on that node.
@@ -150,6 +434,24 @@ type DeploymentSpec struct {
 	// AdditionalVolumeMounts allows to mount an additional volume into each component of the Control Plane
 	// (kube-apiserver, controller-manager, and scheduler).
 	AdditionalVolumeMounts *AdditionalVolumeMounts `json:"additionalVolumeMounts,omitempty"`
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	// ImagePullPolicy is applied to all the containers generated by Kamaji for the Tenant Control Plane.
+	// When not specified, IfNotPresent is used for the pinned images, Always for the untagged, or latest, ones.
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// ComponentIsolation runs the controller-manager, and the scheduler, in dedicated Deployments rather than as
+	// containers of the Tenant Control Plane Pods, allowing to scale and roll them out independently of the API Server.
+	// The isolated components reach the API Server through the Tenant Control Plane Service.
+	ComponentIsolation bool `json:"componentIsolation,omitempty"`
+	// IsolatedComponentsReplicas defines the replicas of the isolated components Deployments,
+	// defaulting to the Tenant Control Plane ones: used only if the component isolation is enabled.
+	IsolatedComponentsReplicas *IsolatedComponentsReplicas `json:"isolatedComponentsReplicas,omitempty"`
+}
+
+type IsolatedComponentsReplicas struct {
+	// +kubebuilder:validation:Minimum=0
+	ControllerManager *int32 `json:"controllerManager,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	Scheduler *int32 `json:"scheduler,omitempty"`
 }
 
 // AdditionalVolumeMounts allows mounting additional volumes to the Control Plane components.
@@ -169,9 +471,16 @@ type ControlPlaneExtraArgs struct {
 }
 
 type ServiceSpec struct {
+	// AdditionalMetadata annotations are applied to the generated Service, such as the cloud providers ones tuning the LoadBalancer:
+	// annotations removed from the specification are removed from the Service too, leaving the ones set by third parties untouched.
 	AdditionalMetadata AdditionalMetadata `json:"additionalMetadata,omitempty"`
 	// ServiceType allows specifying how to expose the Tenant Control Plane.
 	ServiceType ServiceType `json:"serviceType"`
+	// +kubebuilder:default=true
+	// Enforces the Kamaji-managed fields of the Service, such as the selector, the port, and the type, reverting any drift
+	// with the desired state. When disabled, the drifts are preserved, and only reported with the ServiceDrift condition,
+	// along with a Warning event.
+	Enforce *bool `json:"enforce,omitempty"`
 }
 
 // AddonSpec defines the spec for every addon.
@@ -213,6 +522,11 @@ type KonnectivityAgentSpec struct {
 	// +kubebuilder:default=v0.0.32
 	Version   string    `json:"version,omitempty"`
 	ExtraArgs ExtraArgs `json:"extraArgs,omitempty"`
+	// Additional tolerations of the agent DaemonSet Pods, appended to the CriticalAddonsOnly one:
+	// use them to schedule the agent on tainted nodes, such as the control-plane ones.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Additional node selector of the agent DaemonSet Pods, merged with the kubernetes.io/os=linux one.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 }
 
 // KonnectivitySpec defines the spec for Konnectivity.
@@ -223,16 +537,149 @@ type KonnectivitySpec struct {
 	KonnectivityAgentSpec KonnectivityAgentSpec `json:"agent,omitempty"`
 }
 
+// CNISpec defines the CNI manifests applied to the Tenant Cluster.
+type CNISpec struct {
+	// Inline multi-document YAML manifests of the CNI.
+	// Mutually exclusive with ConfigMapRef.
+	Manifests string `json:"manifests,omitempty"`
+	// ConfigMap in the Tenant Control Plane namespace containing the CNI manifests,
+	// each key must contain multi-document YAML manifests, applied in the keys order.
+	// Mutually exclusive with Manifests.
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+	// Declares the CNI replaces the kube-proxy functionalities, such as Cilium with kube-proxy replacement:
+	// when enabled, the kube-proxy addon must be disabled.
+	ReplacesKubeProxy bool `json:"replacesKubeProxy,omitempty"`
+}
+
+// DefaultsSpec defines the policy objects seeded in the Tenant Cluster,
+// the allowed kinds are PriorityClass, LimitRange, and ResourceQuota.
+type DefaultsSpec struct {
+	// Inline multi-document YAML manifests of the policy objects.
+	// Mutually exclusive with ConfigMapRef.
+	Manifests string `json:"manifests,omitempty"`
+	// ConfigMap in the Tenant Control Plane namespace containing the policy objects manifests,
+	// each key must contain multi-document YAML manifests, applied in the keys order.
+	// Mutually exclusive with Manifests.
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+}
+
+// WebhooksSpec defines the webhook configurations seeded in the Tenant Cluster,
+// the allowed kinds are ValidatingWebhookConfiguration, and MutatingWebhookConfiguration.
+type WebhooksSpec struct {
+	// Inline multi-document YAML manifests of the webhook configurations.
+	// Mutually exclusive with ConfigMapRef.
+	Manifests string `json:"manifests,omitempty"`
+	// ConfigMap in the Tenant Control Plane namespace containing the webhook configurations manifests,
+	// each key must contain multi-document YAML manifests, applied in the keys order.
+	// Mutually exclusive with Manifests.
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+}
+
+// NetworkPoliciesSpec defines the NetworkPolicies seeded in the Tenant Cluster, such as a default-deny baseline,
+// the allowed kind is NetworkPolicy: objects with no namespace are applied to the default one.
+type NetworkPoliciesSpec struct {
+	// Inline multi-document YAML manifests of the NetworkPolicies.
+	// Mutually exclusive with ConfigMapRef.
+	Manifests string `json:"manifests,omitempty"`
+	// ConfigMap in the Tenant Control Plane namespace containing the NetworkPolicies manifests,
+	// each key must contain multi-document YAML manifests, applied in the keys order.
+	// Mutually exclusive with Manifests.
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+}
+
+// InClusterReachabilitySpec defines the probe verifying the API Server is reachable from the Tenant Cluster network.
+type InClusterReachabilitySpec struct {
+	// +kubebuilder:default="curlimages/curl:8.5.0"
+	// Container image of the probe Job, it must provide the sh and curl binaries.
+	Image string `json:"image,omitempty"`
+	// +kubebuilder:default="5m"
+	// Interval between two consecutive probes.
+	Interval metav1.Duration `json:"interval,omitempty"`
+}
+
 // AddonsSpec defines the enabled addons and their features.
 type AddonsSpec struct {
+	// Enables the CNI addon in the Tenant Cluster, applying the provided manifests.
+	// Objects are applied using Server-Side Apply, and periodically reconciled to revert any drift.
+	CNI *CNISpec `json:"cni,omitempty"`
 	// Enables the DNS addon in the Tenant Cluster.
 	// The registry and the tag are configurable, the image is hard-coded to `coredns`.
 	CoreDNS *AddonSpec `json:"coreDNS,omitempty"`
+	// Seeds the Tenant Cluster with the provided policy objects, such as PriorityClasses, LimitRanges, and ResourceQuotas.
+	// Objects are applied using Server-Side Apply, and reconciled back upon any drift.
+	Defaults *DefaultsSpec `json:"defaults,omitempty"`
+	// Enables the periodic verification of the API Server reachability from the Tenant Cluster network:
+	// a Job in the kube-system Namespace queries the kubernetes Service, reporting the outcome with the InClusterReachability condition.
+	InClusterReachability *InClusterReachabilitySpec `json:"inClusterReachability,omitempty"`
 	// Enables the Konnectivity addon in the Tenant Cluster, required if the worker nodes are in a different network.
 	Konnectivity *KonnectivitySpec `json:"konnectivity,omitempty"`
 	// Enables the kube-proxy addon in the Tenant Cluster.
 	// The registry and the tag are configurable, the image is hard-coded to `kube-proxy`.
 	KubeProxy *AddonSpec `json:"kubeProxy,omitempty"`
+	// Seeds the Tenant Cluster with the provided NetworkPolicies, such as a security baseline.
+	// Objects are applied using Server-Side Apply, and reconciled back upon any drift: they're deleted once disabled.
+	NetworkPolicies *NetworkPoliciesSpec `json:"networkPolicies,omitempty"`
+	// Seeds the Tenant Cluster with the provided ValidatingWebhookConfigurations and MutatingWebhookConfigurations.
+	// The webhooks with no CA bundle are injected with the Tenant Control Plane CA, refreshed upon its rotation:
+	// objects are applied using Server-Side Apply, and reconciled back upon any drift.
+	Webhooks *WebhooksSpec `json:"webhooks,omitempty"`
+}
+
+// DataStoreOptions defines the driver specific options of the Tenant Control Plane DataStore.
+type DataStoreOptions struct {
+	// Options applied when the Tenant Control Plane is backed by an etcd DataStore.
+	Etcd *EtcdDataStoreOptions `json:"etcd,omitempty"`
+	// Options applied when the Tenant Control Plane is backed by a Kine DataStore, such as MySQL, or PostgreSQL.
+	Kine *KineDataStoreOptions `json:"kine,omitempty"`
+}
+
+// EtcdDataStoreOptions defines the options for the Tenant Control Plane backed by an etcd DataStore.
+type EtcdDataStoreOptions struct {
+	// Interval of the compaction requests issued by the API Server, mapped to the --etcd-compaction-interval flag.
+	// When not specified, the API Server compaction is disabled since it's expected to be performed by etcd itself:
+	// when enabling it, the etcd auto-compaction should be disabled to avoid double compaction.
+	APIServerCompactionInterval *metav1.Duration `json:"apiServerCompactionInterval,omitempty"`
+}
+
+// KineDataStoreOptions defines the options for the Tenant Control Plane backed by a Kine DataStore.
+type KineDataStoreOptions struct {
+	// Defining the size of the Kine connection pool to the SQL backend.
+	// Upon change, the control plane Pods are rolled out.
+	ConnectionPool *KineConnectionPool `json:"connectionPool,omitempty"`
+	// Defining the Kine container image, overriding the one of the Kamaji configuration: it allows pinning
+	// a Kine release during a staged upgrade. Upon change, the control plane Pods are rolled out.
+	Image string `json:"image,omitempty"`
+	// Defining the probes of the Kine container, checking it's serving the storage endpoint used by the API Server:
+	// the control plane Pods are not marked as ready until Kine is, since Kine starts serving once the SQL backend
+	// has been set up. Upon change, the control plane Pods are rolled out.
+	Probes *KineProbes `json:"probes,omitempty"`
+}
+
+// KineProbes defines the timings of the liveness, and readiness probes of the Kine container.
+// When a value is not specified, the default is used.
+type KineProbes struct {
+	// +kubebuilder:validation:Minimum=1
+	// How often, in seconds, the probes are performed, defaulting to 10 seconds.
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// Number of seconds after which the probes time out, defaulting to 1 second.
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// Consecutive failures after which the Kine container is restarted, or marked as not ready, defaulting to 3.
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// KineConnectionPool defines the Kine connection pool to the SQL backend.
+// When a value is not specified, the Kine default is used.
+type KineConnectionPool struct {
+	// +kubebuilder:validation:Minimum=1
+	// Maximum number of idle connections retained by Kine, mapped to the --datastore-max-idle-connections flag.
+	MaxIdleConnections *int32 `json:"maxIdleConnections,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// Maximum number of open connections to the SQL backend, mapped to the --datastore-max-open-connections flag.
+	MaxOpenConnections *int32 `json:"maxOpenConnections,omitempty"`
+	// Maximum amount of time a connection may be reused, mapped to the --datastore-connection-max-lifetime flag.
+	ConnectionMaxLifetime *metav1.Duration `json:"connectionMaxLifetime,omitempty"`
 }
 
 // TenantControlPlaneSpec defines the desired state of TenantControlPlane.
@@ -240,14 +687,27 @@ type TenantControlPlaneSpec struct {
 	// DataStore allows to specify a DataStore that should be used to store the Kubernetes data for the given Tenant Control Plane.
 	// This parameter is optional and acts as an override over the default one which is used by the Kamaji Operator.
 	// Migration from a different DataStore to another one is not yet supported and the reconciliation will be blocked.
-	DataStore    string       `json:"dataStore,omitempty"`
-	ControlPlane ControlPlane `json:"controlPlane"`
+	DataStore string `json:"dataStore,omitempty"`
+	// DataStoreOptions contains the driver specific options of the DataStore used by the Tenant Control Plane.
+	DataStoreOptions *DataStoreOptions `json:"dataStoreOptions,omitempty"`
+	ControlPlane     ControlPlane      `json:"controlPlane"`
 	// Kubernetes specification for tenant control plane
 	Kubernetes KubernetesSpec `json:"kubernetes"`
 	// NetworkProfile specifies how the network is
 	NetworkProfile NetworkProfileSpec `json:"networkProfile,omitempty"`
 	// Addons contain which addons are enabled
 	Addons AddonsSpec `json:"addons,omitempty"`
+	// NodeCountThresholds allows to be notified when the Tenant Control Plane is growing large,
+	// such as for capacity planning, or to migrate it to a dedicated DataStore.
+	NodeCountThresholds *NodeCountThresholds `json:"nodeCountThresholds,omitempty"`
+}
+
+// NodeCountThresholds defines the Tenant Control Plane node count thresholds.
+type NodeCountThresholds struct {
+	// +kubebuilder:validation:Minimum=1
+	// Number of nodes from which the Tenant Control Plane is considered large:
+	// once crossed, the LargeTenant condition is set, and an event is emitted.
+	LargeTenant int32 `json:"largeTenant"`
 }
 
 // +kubebuilder:object:root=true
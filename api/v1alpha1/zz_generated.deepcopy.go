@@ -9,10 +9,77 @@
 package v1alpha1
 
 import (
-	"k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APFBootstrapSpec) DeepCopyInto(out *APFBootstrapSpec) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APFBootstrapSpec.
+func (in *APFBootstrapSpec) DeepCopy() *APFBootstrapSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APFBootstrapSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APFBootstrapStatus) DeepCopyInto(out *APFBootstrapStatus) {
+	*out = *in
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]AddonObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APFBootstrapStatus.
+func (in *APFBootstrapStatus) DeepCopy() *APFBootstrapStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APFBootstrapStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APFSpec) DeepCopyInto(out *APFSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Bootstrap != nil {
+		in, out := &in.Bootstrap, &out.Bootstrap
+		*out = new(APFBootstrapSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APFSpec.
+func (in *APFSpec) DeepCopy() *APFSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APFSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIServerCertificatesStatus) DeepCopyInto(out *APIServerCertificatesStatus) {
 	*out = *in
@@ -29,6 +96,140 @@ func (in *APIServerCertificatesStatus) DeepCopy() *APIServerCertificatesStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerGracefulShutdownSpec) DeepCopyInto(out *APIServerGracefulShutdownSpec) {
+	*out = *in
+	if in.WatchTerminationGracePeriod != nil {
+		in, out := &in.WatchTerminationGracePeriod, &out.WatchTerminationGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerGracefulShutdownSpec.
+func (in *APIServerGracefulShutdownSpec) DeepCopy() *APIServerGracefulShutdownSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerGracefulShutdownSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerSpec) DeepCopyInto(out *APIServerSpec) {
+	*out = *in
+	if in.RequestTimeout != nil {
+		in, out := &in.RequestTimeout, &out.RequestTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinRequestTimeout != nil {
+		in, out := &in.MinRequestTimeout, &out.MinRequestTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRequestsInflight != nil {
+		in, out := &in.MaxRequestsInflight, &out.MaxRequestsInflight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxMutatingRequestsInflight != nil {
+		in, out := &in.MaxMutatingRequestsInflight, &out.MaxMutatingRequestsInflight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.APF != nil {
+		in, out := &in.APF, &out.APF
+		*out = new(APFSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdmissionControllersOrder != nil {
+		in, out := &in.AdmissionControllersOrder, &out.AdmissionControllersOrder
+		*out = make(AdmissionControllers, len(*in))
+		copy(*out, *in)
+	}
+	if in.SNICerts != nil {
+		in, out := &in.SNICerts, &out.SNICerts
+		*out = make([]SNICertificate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tuning != nil {
+		in, out := &in.Tuning, &out.Tuning
+		*out = new(APIServerTuningSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowPrivileged != nil {
+		in, out := &in.AllowPrivileged, &out.AllowPrivileged
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDCSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CORSAllowedOrigins != nil {
+		in, out := &in.CORSAllowedOrigins, &out.CORSAllowedOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GracefulShutdown != nil {
+		in, out := &in.GracefulShutdown, &out.GracefulShutdown
+		*out = new(APIServerGracefulShutdownSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerSpec.
+func (in *APIServerSpec) DeepCopy() *APIServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerTuningSpec) DeepCopyInto(out *APIServerTuningSpec) {
+	*out = *in
+	if in.WatchCache != nil {
+		in, out := &in.WatchCache, &out.WatchCache
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DefaultWatchCacheSize != nil {
+		in, out := &in.DefaultWatchCacheSize, &out.DefaultWatchCacheSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WatchCacheSizes != nil {
+		in, out := &in.WatchCacheSizes, &out.WatchCacheSizes
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerTuningSpec.
+func (in *APIServerTuningSpec) DeepCopy() *APIServerTuningSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerTuningSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdditionalMetadata) DeepCopyInto(out *AdditionalMetadata) {
 	*out = *in
@@ -63,21 +264,21 @@ func (in *AdditionalVolumeMounts) DeepCopyInto(out *AdditionalVolumeMounts) {
 	*out = *in
 	if in.APIServer != nil {
 		in, out := &in.APIServer, &out.APIServer
-		*out = make([]v1.VolumeMount, len(*in))
+		*out = make([]corev1.VolumeMount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.ControllerManager != nil {
 		in, out := &in.ControllerManager, &out.ControllerManager
-		*out = make([]v1.VolumeMount, len(*in))
+		*out = make([]corev1.VolumeMount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.Scheduler != nil {
 		in, out := &in.Scheduler, &out.Scheduler
-		*out = make([]v1.VolumeMount, len(*in))
+		*out = make([]corev1.VolumeMount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -94,6 +295,21 @@ func (in *AdditionalVolumeMounts) DeepCopy() *AdditionalVolumeMounts {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonObjectReference) DeepCopyInto(out *AddonObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonObjectReference.
+func (in *AddonObjectReference) DeepCopy() *AddonObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonSpec) DeepCopyInto(out *AddonSpec) {
 	*out = *in
@@ -129,11 +345,26 @@ func (in *AddonStatus) DeepCopy() *AddonStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonsSpec) DeepCopyInto(out *AddonsSpec) {
 	*out = *in
+	if in.CNI != nil {
+		in, out := &in.CNI, &out.CNI
+		*out = new(CNISpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CoreDNS != nil {
 		in, out := &in.CoreDNS, &out.CoreDNS
 		*out = new(AddonSpec)
 		**out = **in
 	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(DefaultsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InClusterReachability != nil {
+		in, out := &in.InClusterReachability, &out.InClusterReachability
+		*out = new(InClusterReachabilitySpec)
+		**out = **in
+	}
 	if in.Konnectivity != nil {
 		in, out := &in.Konnectivity, &out.Konnectivity
 		*out = new(KonnectivitySpec)
@@ -144,6 +375,16 @@ func (in *AddonsSpec) DeepCopyInto(out *AddonsSpec) {
 		*out = new(AddonSpec)
 		**out = **in
 	}
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = new(NetworkPoliciesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = new(WebhooksSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonsSpec.
@@ -159,9 +400,13 @@ func (in *AddonsSpec) DeepCopy() *AddonsSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonsStatus) DeepCopyInto(out *AddonsStatus) {
 	*out = *in
+	in.CNI.DeepCopyInto(&out.CNI)
 	in.CoreDNS.DeepCopyInto(&out.CoreDNS)
+	in.Defaults.DeepCopyInto(&out.Defaults)
 	in.KubeProxy.DeepCopyInto(&out.KubeProxy)
 	in.Konnectivity.DeepCopyInto(&out.Konnectivity)
+	in.NetworkPolicies.DeepCopyInto(&out.NetworkPolicies)
+	in.Webhooks.DeepCopyInto(&out.Webhooks)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonsStatus.
@@ -193,6 +438,45 @@ func (in AdmissionControllers) DeepCopy() AdmissionControllers {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutomationSpec) DeepCopyInto(out *AutomationSpec) {
+	*out = *in
+	if in.ClusterRoles != nil {
+		in, out := &in.ClusterRoles, &out.ClusterRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.TokenTTL = in.TokenTTL
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutomationSpec.
+func (in *AutomationSpec) DeepCopy() *AutomationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutomationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutomationStatus) DeepCopyInto(out *AutomationStatus) {
+	*out = *in
+	in.ServiceAccount.DeepCopyInto(&out.ServiceAccount)
+	in.ExpirationTimestamp.DeepCopyInto(&out.ExpirationTimestamp)
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutomationStatus.
+func (in *AutomationStatus) DeepCopy() *AutomationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutomationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
@@ -210,6 +494,47 @@ func (in *BasicAuth) DeepCopy() *BasicAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CNISpec) DeepCopyInto(out *CNISpec) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNISpec.
+func (in *CNISpec) DeepCopy() *CNISpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CNISpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CNIStatus) DeepCopyInto(out *CNIStatus) {
+	*out = *in
+	in.AddonStatus.DeepCopyInto(&out.AddonStatus)
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]AddonObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNIStatus.
+func (in *CNIStatus) DeepCopy() *CNIStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CNIStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertKeyPair) DeepCopyInto(out *CertKeyPair) {
 	*out = *in
@@ -247,6 +572,26 @@ func (in *CertificatePrivateKeyPairStatus) DeepCopy() *CertificatePrivateKeyPair
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatesSpec) DeepCopyInto(out *CertificatesSpec) {
+	*out = *in
+	if in.ClusterCABundleRef != nil {
+		in, out := &in.ClusterCABundleRef, &out.ClusterCABundleRef
+		*out = new(ContentRef)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesSpec.
+func (in *CertificatesSpec) DeepCopy() *CertificatesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificatesStatus) DeepCopyInto(out *CertificatesStatus) {
 	*out = *in
@@ -261,6 +606,16 @@ func (in *CertificatesStatus) DeepCopyInto(out *CertificatesStatus) {
 		*out = new(ETCDCertificatesStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.APIServerSNI != nil {
+		in, out := &in.APIServerSNI, &out.APIServerSNI
+		*out = new(CertificatePrivateKeyPairStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterCABundle != nil {
+		in, out := &in.ClusterCABundle, &out.ClusterCABundle
+		*out = new(CertificatePrivateKeyPairStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesStatus.
@@ -278,6 +633,11 @@ func (in *ClientCertificate) DeepCopyInto(out *ClientCertificate) {
 	*out = *in
 	in.Certificate.DeepCopyInto(&out.Certificate)
 	in.PrivateKey.DeepCopyInto(&out.PrivateKey)
+	if in.Next != nil {
+		in, out := &in.Next, &out.Next
+		*out = new(ClientCertificateKeyPair)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertificate.
@@ -290,6 +650,81 @@ func (in *ClientCertificate) DeepCopy() *ClientCertificate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCertificateKeyPair) DeepCopyInto(out *ClientCertificateKeyPair) {
+	*out = *in
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	in.PrivateKey.DeepCopyInto(&out.PrivateKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertificateKeyPair.
+func (in *ClientCertificateKeyPair) DeepCopy() *ClientCertificateKeyPair {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCertificateKeyPair)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentConfigurationSnapshot) DeepCopyInto(out *ComponentConfigurationSnapshot) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentConfigurationSnapshot.
+func (in *ComponentConfigurationSnapshot) DeepCopy() *ComponentConfigurationSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentConfigurationSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentVersions) DeepCopyInto(out *ComponentVersions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentVersions.
+func (in *ComponentVersions) DeepCopy() *ComponentVersions {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentVersions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigurationSnapshotStatus) DeepCopyInto(out *ConfigurationSnapshotStatus) {
+	*out = *in
+	in.LastChange.DeepCopyInto(&out.LastChange)
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentConfigurationSnapshot, len(*in))
+		copy(*out, *in)
+	}
+	if in.CertificateFingerprints != nil {
+		in, out := &in.CertificateFingerprints, &out.CertificateFingerprints
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationSnapshotStatus.
+func (in *ConfigurationSnapshotStatus) DeepCopy() *ConfigurationSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigurationSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContentRef) DeepCopyInto(out *ContentRef) {
 	*out = *in
@@ -325,6 +760,36 @@ func (in *ControlPlane) DeepCopyInto(out *ControlPlane) {
 		*out = new(IngressSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Automation != nil {
+		in, out := &in.Automation, &out.Automation
+		*out = new(AutomationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIServer != nil {
+		in, out := &in.APIServer, &out.APIServer
+		*out = new(APIServerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = new(CertificatesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = new(ControllerManagerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadReplicas != nil {
+		in, out := &in.ReadReplicas, &out.ReadReplicas
+		*out = new(ReadReplicasSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlane.
@@ -342,22 +807,22 @@ func (in *ControlPlaneComponentsResources) DeepCopyInto(out *ControlPlaneCompone
 	*out = *in
 	if in.APIServer != nil {
 		in, out := &in.APIServer, &out.APIServer
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ControllerManager != nil {
 		in, out := &in.ControllerManager, &out.ControllerManager
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Scheduler != nil {
 		in, out := &in.Scheduler, &out.Scheduler
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Kine != nil {
 		in, out := &in.Kine, &out.Kine
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -407,6 +872,26 @@ func (in *ControlPlaneExtraArgs) DeepCopy() *ControlPlaneExtraArgs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerManagerSpec) DeepCopyInto(out *ControllerManagerSpec) {
+	*out = *in
+	if in.EnableGarbageCollector != nil {
+		in, out := &in.EnableGarbageCollector, &out.EnableGarbageCollector
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerManagerSpec.
+func (in *ControllerManagerSpec) DeepCopy() *ControllerManagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerManagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataStore) DeepCopyInto(out *DataStore) {
 	*out = *in
@@ -497,6 +982,47 @@ func (in *DataStoreList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreMigrationStatus) DeepCopyInto(out *DataStoreMigrationStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreMigrationStatus.
+func (in *DataStoreMigrationStatus) DeepCopy() *DataStoreMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreOptions) DeepCopyInto(out *DataStoreOptions) {
+	*out = *in
+	if in.Etcd != nil {
+		in, out := &in.Etcd, &out.Etcd
+		*out = new(EtcdDataStoreOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kine != nil {
+		in, out := &in.Kine, &out.Kine
+		*out = new(KineDataStoreOptions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreOptions.
+func (in *DataStoreOptions) DeepCopy() *DataStoreOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataStoreSetupStatus) DeepCopyInto(out *DataStoreSetupStatus) {
 	*out = *in
@@ -547,6 +1073,17 @@ func (in *DataStoreStatus) DeepCopyInto(out *DataStoreStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastValidationTime != nil {
+		in, out := &in.LastValidationTime, &out.LastValidationTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreStatus.
@@ -574,10 +1111,82 @@ func (in *DatastoreUsedSecret) DeepCopy() *DatastoreUsedSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultsSpec) DeepCopyInto(out *DefaultsSpec) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultsSpec.
+func (in *DefaultsSpec) DeepCopy() *DefaultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultsStatus) DeepCopyInto(out *DefaultsStatus) {
+	*out = *in
+	in.AddonStatus.DeepCopyInto(&out.AddonStatus)
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]AddonObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultsStatus.
+func (in *DefaultsStatus) DeepCopy() *DefaultsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentChangeReason) DeepCopyInto(out *DeploymentChangeReason) {
+	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChangedArgs != nil {
+		in, out := &in.ChangedArgs, &out.ChangedArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentChangeReason.
+func (in *DeploymentChangeReason) DeepCopy() *DeploymentChangeReason {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentChangeReason)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentSpec) DeepCopyInto(out *DeploymentSpec) {
 	*out = *in
 	out.RegistrySettings = in.RegistrySettings
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = make(map[string]string, len(*in))
@@ -588,19 +1197,19 @@ func (in *DeploymentSpec) DeepCopyInto(out *DeploymentSpec) {
 	in.Strategy.DeepCopyInto(&out.Strategy)
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.TopologySpreadConstraints != nil {
 		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
-		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -618,21 +1227,21 @@ func (in *DeploymentSpec) DeepCopyInto(out *DeploymentSpec) {
 	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
 	if in.AdditionalInitContainers != nil {
 		in, out := &in.AdditionalInitContainers, &out.AdditionalInitContainers
-		*out = make([]v1.Container, len(*in))
+		*out = make([]corev1.Container, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.AdditionalContainers != nil {
 		in, out := &in.AdditionalContainers, &out.AdditionalContainers
-		*out = make([]v1.Container, len(*in))
+		*out = make([]corev1.Container, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.AdditionalVolumes != nil {
 		in, out := &in.AdditionalVolumes, &out.AdditionalVolumes
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -642,6 +1251,11 @@ func (in *DeploymentSpec) DeepCopyInto(out *DeploymentSpec) {
 		*out = new(AdditionalVolumeMounts)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IsolatedComponentsReplicas != nil {
+		in, out := &in.IsolatedComponentsReplicas, &out.IsolatedComponentsReplicas
+		*out = new(IsolatedComponentsReplicas)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentSpec.
@@ -654,6 +1268,22 @@ func (in *DeploymentSpec) DeepCopy() *DeploymentSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainingPod) DeepCopyInto(out *DrainingPod) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainingPod.
+func (in *DrainingPod) DeepCopy() *DrainingPod {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainingPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ETCDCertificateStatus) DeepCopyInto(out *ETCDCertificateStatus) {
 	*out = *in
@@ -706,6 +1336,26 @@ func (in Endpoints) DeepCopy() Endpoints {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdDataStoreOptions) DeepCopyInto(out *EtcdDataStoreOptions) {
+	*out = *in
+	if in.APIServerCompactionInterval != nil {
+		in, out := &in.APIServerCompactionInterval, &out.APIServerCompactionInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdDataStoreOptions.
+func (in *EtcdDataStoreOptions) DeepCopy() *EtcdDataStoreOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdDataStoreOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalKubernetesObjectStatus) DeepCopyInto(out *ExternalKubernetesObjectStatus) {
 	*out = *in
@@ -731,43 +1381,185 @@ func (in ExtraArgs) DeepCopyInto(out *ExtraArgs) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraArgs.
-func (in ExtraArgs) DeepCopy() ExtraArgs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraArgs.
+func (in ExtraArgs) DeepCopy() ExtraArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraArgs)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageOverrideTrait) DeepCopyInto(out *ImageOverrideTrait) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageOverrideTrait.
+func (in *ImageOverrideTrait) DeepCopy() *ImageOverrideTrait {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageOverrideTrait)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InClusterReachabilitySpec) DeepCopyInto(out *InClusterReachabilitySpec) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InClusterReachabilitySpec.
+func (in *InClusterReachabilitySpec) DeepCopy() *InClusterReachabilitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InClusterReachabilitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
+	*out = *in
+	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
+func (in *IngressSpec) DeepCopy() *IngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IsolatedComponentsReplicas) DeepCopyInto(out *IsolatedComponentsReplicas) {
+	*out = *in
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IsolatedComponentsReplicas.
+func (in *IsolatedComponentsReplicas) DeepCopy() *IsolatedComponentsReplicas {
+	if in == nil {
+		return nil
+	}
+	out := new(IsolatedComponentsReplicas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyRotationStatus) DeepCopyInto(out *KeyRotationStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyRotationStatus.
+func (in *KeyRotationStatus) DeepCopy() *KeyRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KineConnectionPool) DeepCopyInto(out *KineConnectionPool) {
+	*out = *in
+	if in.MaxIdleConnections != nil {
+		in, out := &in.MaxIdleConnections, &out.MaxIdleConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxOpenConnections != nil {
+		in, out := &in.MaxOpenConnections, &out.MaxOpenConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConnectionMaxLifetime != nil {
+		in, out := &in.ConnectionMaxLifetime, &out.ConnectionMaxLifetime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KineConnectionPool.
+func (in *KineConnectionPool) DeepCopy() *KineConnectionPool {
 	if in == nil {
 		return nil
 	}
-	out := new(ExtraArgs)
+	out := new(KineConnectionPool)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageOverrideTrait) DeepCopyInto(out *ImageOverrideTrait) {
+func (in *KineDataStoreOptions) DeepCopyInto(out *KineDataStoreOptions) {
 	*out = *in
+	if in.ConnectionPool != nil {
+		in, out := &in.ConnectionPool, &out.ConnectionPool
+		*out = new(KineConnectionPool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(KineProbes)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageOverrideTrait.
-func (in *ImageOverrideTrait) DeepCopy() *ImageOverrideTrait {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KineDataStoreOptions.
+func (in *KineDataStoreOptions) DeepCopy() *KineDataStoreOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageOverrideTrait)
+	out := new(KineDataStoreOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
+func (in *KineProbes) DeepCopyInto(out *KineProbes) {
 	*out = *in
-	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
-func (in *IngressSpec) DeepCopy() *IngressSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KineProbes.
+func (in *KineProbes) DeepCopy() *KineProbes {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressSpec)
+	out := new(KineProbes)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -780,6 +1572,20 @@ func (in *KonnectivityAgentSpec) DeepCopyInto(out *KonnectivityAgentSpec) {
 		*out = make(ExtraArgs, len(*in))
 		copy(*out, *in)
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityAgentSpec.
@@ -812,7 +1618,7 @@ func (in *KonnectivityServerSpec) DeepCopyInto(out *KonnectivityServerSpec) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ExtraArgs != nil {
@@ -978,6 +1784,11 @@ func (in *KubernetesDeploymentStatus) DeepCopyInto(out *KubernetesDeploymentStat
 	*out = *in
 	in.DeploymentStatus.DeepCopyInto(&out.DeploymentStatus)
 	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.LastChangeReason != nil {
+		in, out := &in.LastChangeReason, &out.LastChangeReason
+		*out = new(DeploymentChangeReason)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesDeploymentStatus.
@@ -990,6 +1801,37 @@ func (in *KubernetesDeploymentStatus) DeepCopy() *KubernetesDeploymentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesDrainStatus) DeepCopyInto(out *KubernetesDrainStatus) {
+	*out = *in
+	if in.DrainingPods != nil {
+		in, out := &in.DrainingPods, &out.DrainingPods
+		*out = make([]DrainingPod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastDrainDuration != nil {
+		in, out := &in.LastDrainDuration, &out.LastDrainDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LastDrainCompletion != nil {
+		in, out := &in.LastDrainCompletion, &out.LastDrainCompletion
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesDrainStatus.
+func (in *KubernetesDrainStatus) DeepCopy() *KubernetesDrainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesDrainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesIngressStatus) DeepCopyInto(out *KubernetesIngressStatus) {
 	*out = *in
@@ -1031,6 +1873,7 @@ func (in *KubernetesSpec) DeepCopyInto(out *KubernetesSpec) {
 		*out = make(AdmissionControllers, len(*in))
 		copy(*out, *in)
 	}
+	out.ComponentVersions = in.ComponentVersions
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesSpec.
@@ -1054,6 +1897,31 @@ func (in *KubernetesStatus) DeepCopyInto(out *KubernetesStatus) {
 		*out = new(KubernetesIngressStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(KubernetesDrainStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControllerManagerDeployment != nil {
+		in, out := &in.ControllerManagerDeployment, &out.ControllerManagerDeployment
+		*out = new(KubernetesDeploymentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SchedulerDeployment != nil {
+		in, out := &in.SchedulerDeployment, &out.SchedulerDeployment
+		*out = new(KubernetesDeploymentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadReplicasDeployment != nil {
+		in, out := &in.ReadReplicasDeployment, &out.ReadReplicasDeployment
+		*out = new(KubernetesDeploymentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadReplicasService != nil {
+		in, out := &in.ReadReplicasService, &out.ReadReplicasService
+		*out = new(KubernetesServiceStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesStatus.
@@ -1086,6 +1954,100 @@ func (in *KubernetesVersion) DeepCopy() *KubernetesVersion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsServiceSpec) DeepCopyInto(out *MetricsServiceSpec) {
+	*out = *in
+	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsServiceSpec.
+func (in *MetricsServiceSpec) DeepCopy() *MetricsServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
+	*out = *in
+	if in.MetricsService != nil {
+		in, out := &in.MetricsService, &out.MetricsService
+		*out = new(MetricsServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
+func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringStatus) DeepCopyInto(out *MonitoringStatus) {
+	*out = *in
+	in.MetricsService.DeepCopyInto(&out.MetricsService)
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringStatus.
+func (in *MonitoringStatus) DeepCopy() *MonitoringStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPoliciesSpec) DeepCopyInto(out *NetworkPoliciesSpec) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPoliciesSpec.
+func (in *NetworkPoliciesSpec) DeepCopy() *NetworkPoliciesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPoliciesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPoliciesStatus) DeepCopyInto(out *NetworkPoliciesStatus) {
+	*out = *in
+	in.AddonStatus.DeepCopyInto(&out.AddonStatus)
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]AddonObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPoliciesStatus.
+func (in *NetworkPoliciesStatus) DeepCopy() *NetworkPoliciesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPoliciesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkProfileSpec) DeepCopyInto(out *NetworkProfileSpec) {
 	*out = *in
@@ -1111,10 +2073,50 @@ func (in *NetworkProfileSpec) DeepCopy() *NetworkProfileSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeCountThresholds) DeepCopyInto(out *NodeCountThresholds) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeCountThresholds.
+func (in *NodeCountThresholds) DeepCopy() *NodeCountThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeCountThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCSpec) DeepCopyInto(out *OIDCSpec) {
+	*out = *in
+	if in.SigningAlgs != nil {
+		in, out := &in.SigningAlgs, &out.SigningAlgs
+		*out = make([]OIDCSigningAlgorithm, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCSpec.
+func (in *OIDCSpec) DeepCopy() *OIDCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublicKeyPrivateKeyPairStatus) DeepCopyInto(out *PublicKeyPrivateKeyPairStatus) {
 	*out = *in
 	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(KeyRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicKeyPrivateKeyPairStatus.
@@ -1127,6 +2129,26 @@ func (in *PublicKeyPrivateKeyPairStatus) DeepCopy() *PublicKeyPrivateKeyPairStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadReplicasSpec) DeepCopyInto(out *ReadReplicasSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadReplicasSpec.
+func (in *ReadReplicasSpec) DeepCopy() *ReadReplicasSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadReplicasSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistrySettings) DeepCopyInto(out *RegistrySettings) {
 	*out = *in
@@ -1142,6 +2164,28 @@ func (in *RegistrySettings) DeepCopy() *RegistrySettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNICertificate) DeepCopyInto(out *SNICertificate) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	in.PrivateKey.DeepCopyInto(&out.PrivateKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNICertificate.
+func (in *SNICertificate) DeepCopy() *SNICertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(SNICertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
@@ -1158,10 +2202,35 @@ func (in *SecretReference) DeepCopy() *SecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountSpec) DeepCopyInto(out *ServiceAccountSpec) {
+	*out = *in
+	if in.RotationOverlap != nil {
+		in, out := &in.RotationOverlap, &out.RotationOverlap
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountSpec.
+func (in *ServiceAccountSpec) DeepCopy() *ServiceAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 	*out = *in
 	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+	if in.Enforce != nil {
+		in, out := &in.Enforce, &out.Enforce
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
@@ -1180,6 +2249,11 @@ func (in *StorageStatus) DeepCopyInto(out *StorageStatus) {
 	out.Config = in.Config
 	in.Setup.DeepCopyInto(&out.Setup)
 	in.Certificate.DeepCopyInto(&out.Certificate)
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(DataStoreMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageStatus.
@@ -1268,13 +2342,38 @@ func (in *TenantControlPlaneList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantControlPlaneNetwork) DeepCopyInto(out *TenantControlPlaneNetwork) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneNetwork.
+func (in *TenantControlPlaneNetwork) DeepCopy() *TenantControlPlaneNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantControlPlaneNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantControlPlaneSpec) DeepCopyInto(out *TenantControlPlaneSpec) {
 	*out = *in
+	if in.DataStoreOptions != nil {
+		in, out := &in.DataStoreOptions, &out.DataStoreOptions
+		*out = new(DataStoreOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	in.ControlPlane.DeepCopyInto(&out.ControlPlane)
 	in.Kubernetes.DeepCopyInto(&out.Kubernetes)
 	in.NetworkProfile.DeepCopyInto(&out.NetworkProfile)
 	in.Addons.DeepCopyInto(&out.Addons)
+	if in.NodeCountThresholds != nil {
+		in, out := &in.NodeCountThresholds, &out.NodeCountThresholds
+		*out = new(NodeCountThresholds)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneSpec.
@@ -1297,6 +2396,33 @@ func (in *TenantControlPlaneStatus) DeepCopyInto(out *TenantControlPlaneStatus)
 	in.KubeadmConfig.DeepCopyInto(&out.KubeadmConfig)
 	in.KubeadmPhase.DeepCopyInto(&out.KubeadmPhase)
 	in.Addons.DeepCopyInto(&out.Addons)
+	if in.Automation != nil {
+		in, out := &in.Automation, &out.Automation
+		*out = new(AutomationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APFBootstrap != nil {
+		in, out := &in.APFBootstrap, &out.APFBootstrap
+		*out = new(APFBootstrapStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigurationSnapshot != nil {
+		in, out := &in.ConfigurationSnapshot, &out.ConfigurationSnapshot
+		*out = new(ConfigurationSnapshotStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneStatus.
@@ -1323,3 +2449,44 @@ func (in *TenantControlPlaneStatusDataStore) DeepCopy() *TenantControlPlaneStatu
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhooksSpec) DeepCopyInto(out *WebhooksSpec) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhooksSpec.
+func (in *WebhooksSpec) DeepCopy() *WebhooksSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhooksSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhooksStatus) DeepCopyInto(out *WebhooksStatus) {
+	*out = *in
+	in.AddonStatus.DeepCopyInto(&out.AddonStatus)
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]AddonObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhooksStatus.
+func (in *WebhooksStatus) DeepCopy() *WebhooksStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhooksStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -4,22 +4,37 @@
 package manager
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
+	"path/filepath"
 	goRuntime "runtime"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/time/rate"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 
@@ -29,7 +44,10 @@ import (
 	"github.com/clastix/kamaji/controllers/soot"
 	"github.com/clastix/kamaji/internal"
 	"github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/constants"
+	kamajidatastore "github.com/clastix/kamaji/internal/datastore"
 	datastoreutils "github.com/clastix/kamaji/internal/datastore/utils"
+	"github.com/clastix/kamaji/internal/tracing"
 	"github.com/clastix/kamaji/internal/webhook"
 	"github.com/clastix/kamaji/internal/webhook/handlers"
 	"github.com/clastix/kamaji/internal/webhook/routes"
@@ -41,10 +59,33 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 	var (
 		metricsBindAddress         string
 		healthProbeBindAddress     string
+		enablePprof                bool
+		pprofBindAddress           string
+		webhookBindHost            string
+		webhookBindPort            int
 		leaderElect                bool
+		leaderElectLeaseID         string
+		leaderElectNamespace       string
+		leaderElectLeaseDuration   time.Duration
+		leaderElectRenewDeadline   time.Duration
+		leaderElectRetryPeriod     time.Duration
 		tmpDirectory               string
+		createTmpDirectory         bool
+		retainTmpOnError           bool
+		configurationSnapshot      bool
 		kineImage                  string
+		kinePullPolicy             string
+		kineCPURequest             string
+		kineMemoryRequest          string
+		kineCPULimit               string
+		kineMemoryLimit            string
+		kineResources              corev1.ResourceRequirements
 		controllerReconcileTimeout time.Duration
+		finalizerTimeout           time.Duration
+		lbRequeueInterval          time.Duration
+		lbRequeueMaxInterval       time.Duration
+		lbPendingTimeout           time.Duration
+		enableDrainMetrics         bool
 		cacheResyncPeriod          time.Duration
 		datastore                  string
 		managerNamespace           string
@@ -53,12 +94,43 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 		webhookCABundle            []byte
 		migrateJobImage            string
 		maxConcurrentReconciles    int
+		maxConcurrentDSReconciles  int
+		inflightDefaults           map[string]string
+		inflightLimits             map[string]handlers.InflightLimits
+		strictKonnectivity         bool
+		dataStoreInitGrace         time.Duration
+		kubeconfigBackupSecret     string
+		kubeconfigBackupInterval   time.Duration
+		kubeconfigBackup           types.NamespacedName
+		kubeconfigBackupKeySecret  string
+		kubeconfigBackupKey        types.NamespacedName
+		cidrOverlapPolicy          string
+		otelEndpoint               string
+		dataStoreTLSValidation     string
+		dataStoreTLSMaxChainDepth  int
+		dataStoreAllowedNamespaces []string
+		dataStoreFileRoot          string
+		watchNamespace             string
+		dataStoreTriggerTimeout    time.Duration
+		dataStoreTriggerBuffer     int
+		dataStoreTriggerRate       float64
+		dataStoreHealthCheck       time.Duration
+		dataStoreTrackUsage        bool
+		dataStoreReadinessGate     bool
+		otelInsecure               bool
+		dryRun                     bool
+		logFormat                  string
+		logLevel                   string
 
 		webhookCAPath string
 	)
 
 	ctx := ctrl.SetupSignalHandler()
 
+	opts := zap.Options{
+		Development: true,
+	}
+
 	cmd := &cobra.Command{
 		Use:           "manager",
 		Short:         "Start the Kamaji Kubernetes Operator",
@@ -69,10 +141,100 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 			klog.SetOutput(io.Discard)
 			klog.LogToStderr(false)
 
+			// The logger is built once the flags are parsed, thus the setup logs are rendered with the requested format.
+			switch logFormat {
+			case "":
+			case "json":
+				zap.JSONEncoder()(&opts)
+			case "console":
+				zap.ConsoleEncoder()(&opts)
+			default:
+				return fmt.Errorf("expecting one of json, or console, for --log-format arg, got %q", logFormat)
+			}
+
+			if len(logLevel) > 0 {
+				level, levelErr := zapcore.ParseLevel(logLevel)
+				if levelErr != nil {
+					return fmt.Errorf("expecting a valid level for --log-level arg, got %q: %w", logLevel, levelErr)
+				}
+
+				opts.Level = level
+			}
+
+			ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
 			if err = cmdutils.CheckFlags(cmd.Flags(), []string{"kine-image", "datastore", "migrate-image", "tmp-directory", "pod-namespace", "webhook-service-name", "serviceaccount-name", "webhook-ca-path"}...); err != nil {
 				return err
 			}
 
+			if len(watchNamespace) > 0 {
+				if errs := validation.IsDNS1123Label(watchNamespace); len(errs) > 0 {
+					return fmt.Errorf("expecting a valid namespace for --watch-namespace arg, got %q: %s", watchNamespace, strings.Join(errs, ", "))
+				}
+			}
+
+			if len(dataStoreFileRoot) > 0 {
+				if !filepath.IsAbs(dataStoreFileRoot) {
+					return fmt.Errorf("expecting an absolute path for --datastore-file-root arg, got %q", dataStoreFileRoot)
+				}
+
+				dataStoreFileRoot = filepath.Clean(dataStoreFileRoot)
+			}
+
+			kamajiv1alpha1.ContentFileRoot = dataStoreFileRoot
+
+			if err = cmdutils.CheckPullPolicy("kine-pull-policy", kinePullPolicy); err != nil {
+				return err
+			}
+
+			if kineResources.Requests, err = cmdutils.ParseResourceList("kine-cpu-request", kineCPURequest, "kine-memory-request", kineMemoryRequest); err != nil {
+				return err
+			}
+
+			if kineResources.Limits, err = cmdutils.ParseResourceList("kine-cpu-limit", kineCPULimit, "kine-memory-limit", kineMemoryLimit); err != nil {
+				return err
+			}
+
+			if err = cmdutils.CheckBindAddress("metrics-bind-address", metricsBindAddress, true); err != nil {
+				return err
+			}
+
+			if err = cmdutils.CheckBindAddress("health-probe-bind-address", healthProbeBindAddress, false); err != nil {
+				return err
+			}
+
+			if enablePprof {
+				if err = cmdutils.CheckBindAddress("pprof-bind-address", pprofBindAddress, false); err != nil {
+					return err
+				}
+			} else {
+				pprofBindAddress = ""
+			}
+
+			if webhookBindPort <= 0 || webhookBindPort > 65535 {
+				return fmt.Errorf("expecting a valid port for --webhook-bind-port arg, got %d", webhookBindPort)
+			}
+
+			if len(leaderElectLeaseID) == 0 {
+				return fmt.Errorf("the leader election lease ID cannot be empty")
+			}
+
+			if leaderElectRetryPeriod <= 0 || leaderElectRenewDeadline <= 0 || leaderElectLeaseDuration <= leaderElectRenewDeadline {
+				return fmt.Errorf("the leader election durations must be greater than zero, and the renew deadline must be lower than the lease duration")
+			}
+
+			if leaderElectNamespace == "" {
+				leaderElectNamespace = managerNamespace
+			}
+
+			if metricsBindAddress != "" && metricsBindAddress == healthProbeBindAddress {
+				return fmt.Errorf("the metrics, and the health probe, bind addresses must be different")
+			}
+
+			if err = cmdutils.CheckWritableDirectory("tmp-directory", tmpDirectory, createTmpDirectory); err != nil {
+				return err
+			}
+
 			if webhookCABundle, err = os.ReadFile(webhookCAPath); err != nil {
 				return fmt.Errorf("unable to read webhook CA: %w", err)
 			}
@@ -85,6 +247,78 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return fmt.Errorf("the controller reconcile timeout must be greater than zero")
 			}
 
+			if finalizerTimeout < 0 {
+				return fmt.Errorf("the finalizer timeout cannot be negative")
+			}
+
+			if lbRequeueInterval <= 0 || lbRequeueMaxInterval < lbRequeueInterval {
+				return fmt.Errorf("the LoadBalancer requeue interval must be greater than zero, and not greater than the max requeue interval")
+			}
+
+			if dataStoreInitGrace < 0 {
+				return fmt.Errorf("the DataStore initialization grace period cannot be negative")
+			}
+
+			if dataStoreTriggerBuffer < 0 {
+				return fmt.Errorf("the DataStore trigger buffer cannot be negative")
+			}
+
+			if dataStoreTriggerRate < 0 {
+				return fmt.Errorf("the DataStore trigger rate cannot be negative")
+			}
+
+			if dataStoreTriggerTimeout < 0 {
+				return fmt.Errorf("the DataStore trigger timeout cannot be negative")
+			}
+
+			if maxConcurrentDSReconciles < 1 {
+				return fmt.Errorf("the DataStore controller requires at least one worker")
+			}
+
+			if dataStoreHealthCheck < 0 {
+				return fmt.Errorf("the DataStore health check interval cannot be negative")
+			}
+
+			if kubeconfigBackupSecret != "" {
+				// Scoping the informers to the watched namespace, the Secrets are cached in it, and in the Kamaji one only.
+				var namespaces []string
+				if len(watchNamespace) > 0 {
+					namespaces = []string{watchNamespace, managerNamespace}
+				}
+
+				if kubeconfigBackup, err = cmdutils.ParseNamespacedName("kubeconfig-backup-secret", kubeconfigBackupSecret, namespaces...); err != nil {
+					return err
+				}
+
+				if kubeconfigBackupKey, err = cmdutils.ParseNamespacedName("kubeconfig-backup-encryption-key", kubeconfigBackupKeySecret, namespaces...); err != nil {
+					return err
+				}
+
+				if kubeconfigBackupInterval <= 0 {
+					return fmt.Errorf("the kubeconfig backup interval must be greater than zero")
+				}
+			}
+
+			if err = handlers.CIDROverlapPolicy(cidrOverlapPolicy).Validate(); err != nil {
+				return err
+			}
+
+			if err = kamajidatastore.TLSValidationMode(dataStoreTLSValidation).Validate(); err != nil {
+				return err
+			}
+
+			if dataStoreTLSMaxChainDepth < 0 {
+				return fmt.Errorf("the DataStore TLS maximum chain depth cannot be negative")
+			}
+
+			if lbPendingTimeout < 0 {
+				return fmt.Errorf("the LoadBalancer pending timeout cannot be negative")
+			}
+
+			if inflightLimits, err = handlers.ParseInflightLimits(inflightDefaults); err != nil {
+				return err
+			}
+
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -95,21 +329,42 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 			setupLog.Info(fmt.Sprintf("Build date: %s", internal.BuildTime))
 			setupLog.Info(fmt.Sprintf("Go Version: %s", goRuntime.Version()))
 			setupLog.Info(fmt.Sprintf("Go OS/Arch: %s/%s", goRuntime.GOOS, goRuntime.GOARCH))
+			// Exposing the build information to let the running versions be tracked across the fleet.
+			metrics.Registry.MustRegister(newBuildInfoCollector())
+
+			// The default DataStore health is served by the metrics server, rather than gating the readiness probe:
+			// with the Pod not ready, the webhooks would be unavailable, including the ones required to fix the DataStore.
+			dataStoreHealth := &healthz.Handler{Checks: map[string]healthz.Checker{}}
 
 			mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 				Scheme: scheme,
 				Metrics: metricsserver.Options{
 					BindAddress: metricsBindAddress,
+					ExtraHandlers: map[string]http.Handler{
+						"/readyz/datastore": http.StripPrefix("/readyz/datastore", dataStoreHealth),
+					},
 				},
 				WebhookServer: ctrlwebhook.NewServer(ctrlwebhook.Options{
-					Port: 9443,
+					Host: webhookBindHost,
+					Port: webhookBindPort,
 				}),
 				HealthProbeBindAddress:  healthProbeBindAddress,
+				PprofBindAddress:        pprofBindAddress,
 				LeaderElection:          leaderElect,
-				LeaderElectionNamespace: managerNamespace,
-				LeaderElectionID:        "799b98bc.clastix.io",
+				LeaderElectionNamespace: leaderElectNamespace,
+				LeaderElectionID:        leaderElectLeaseID,
+				LeaseDuration:           &leaderElectLeaseDuration,
+				RenewDeadline:           &leaderElectRenewDeadline,
+				RetryPeriod:             &leaderElectRetryPeriod,
 				NewCache: func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
 					opts.SyncPeriod = &cacheResyncPeriod
+					// Only the Tenant Control Plane Pods are watched, there's no need to cache the other ones.
+					opts.ByObject = map[client.Object]cache.ByObject{
+						&corev1.Pod{}: {Label: labels.SelectorFromSet(labels.Set{constants.ControlPlaneLabelResource: constants.ControlPlaneDeploymentLabelValue})},
+					}
+					if len(watchNamespace) > 0 {
+						scopeCacheOptions(&opts, watchNamespace, managerNamespace, dataStoreAllowedNamespaces)
+					}
 
 					return cache.New(config, opts)
 				},
@@ -120,9 +375,31 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return err
 			}
 
-			tcpChannel, certChannel := make(controllers.TenantControlPlaneChannel), make(controllers.CertificateChannel)
+			tcpChannel, certChannel := make(controllers.TenantControlPlaneChannel, dataStoreTriggerBuffer), make(controllers.CertificateChannel)
+
+			var dataStoreTriggerLimiter *rate.Limiter
+			if dataStoreTriggerRate > 0 {
+				dataStoreTriggerLimiter = rate.NewLimiter(rate.Limit(dataStoreTriggerRate), 1)
+			}
 
-			if err = (&controllers.DataStore{Client: mgr.GetClient(), TenantControlPlaneTrigger: tcpChannel}).SetupWithManager(mgr); err != nil {
+			dataStoreController := &controllers.DataStore{
+				Client:                    mgr.GetClient(),
+				EventRecorder:             mgr.GetEventRecorderFor("kamaji-datastore"),
+				DefaultDataStoreName:      datastore,
+				TenantControlPlaneTrigger: tcpChannel,
+				TriggerRateLimiter:        dataStoreTriggerLimiter,
+				TriggerTimeout:            dataStoreTriggerTimeout,
+				MaxConcurrentReconciles:   maxConcurrentDSReconciles,
+				HealthCheckInterval:       dataStoreHealthCheck,
+				ReconcileTimeout:          controllerReconcileTimeout,
+				DryRun:                    dryRun,
+				TrackUsage:                dataStoreTrackUsage,
+				TLSValidation: kamajidatastore.TLSValidation{
+					Mode:          kamajidatastore.TLSValidationMode(dataStoreTLSValidation),
+					MaxChainDepth: dataStoreTLSMaxChainDepth,
+				},
+			}
+			if err = dataStoreController.SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "DataStore")
 
 				return err
@@ -132,10 +409,20 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				Client:    mgr.GetClient(),
 				APIReader: mgr.GetAPIReader(),
 				Config: controllers.TenantControlPlaneReconcilerConfig{
-					ReconcileTimeout:     controllerReconcileTimeout,
-					DefaultDataStoreName: datastore,
-					KineContainerImage:   kineImage,
-					TmpBaseDirectory:     tmpDirectory,
+					ReconcileTimeout:               controllerReconcileTimeout,
+					FinalizerTimeout:               finalizerTimeout,
+					DefaultDataStoreName:           datastore,
+					KineContainerImage:             kineImage,
+					KinePullPolicy:                 corev1.PullPolicy(kinePullPolicy),
+					KineResourceRequirements:       kineResources,
+					TmpBaseDirectory:               tmpDirectory,
+					RetainTmpOnError:               retainTmpOnError,
+					ConfigurationSnapshot:          configurationSnapshot,
+					LoadBalancerRequeueInterval:    lbRequeueInterval,
+					LoadBalancerRequeueMaxInterval: lbRequeueMaxInterval,
+					LoadBalancerPendingTimeout:     lbPendingTimeout,
+					DataStoreInitGrace:             dataStoreInitGrace,
+					DryRun:                         dryRun,
 				},
 				CertificateChan:         certChannel,
 				TriggerChan:             tcpChannel,
@@ -144,6 +431,7 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				KamajiService:           managerServiceName,
 				KamajiMigrateImage:      migrateJobImage,
 				MaxConcurrentReconciles: maxConcurrentReconciles,
+				EventRecorder:           mgr.GetEventRecorderFor("kamaji"),
 			}
 
 			if err = reconciler.SetupWithManager(mgr); err != nil {
@@ -158,6 +446,20 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return err
 			}
 
+			if err = (&controllers.ControlPlaneDrain{Client: mgr.GetClient(), EventRecorder: mgr.GetEventRecorderFor("kamaji-drain"), EnableMetrics: enableDrainMetrics}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "ControlPlaneDrain")
+
+				return err
+			}
+
+			if kubeconfigBackupSecret != "" {
+				if err = (&controllers.KubeconfigBackup{Client: mgr.GetClient(), Secret: kubeconfigBackup, EncryptionKey: kubeconfigBackupKey, Interval: kubeconfigBackupInterval}).SetupWithManager(mgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "KubeconfigBackup")
+
+					return err
+				}
+			}
+
 			if err = (&kamajiv1alpha1.DatastoreUsedSecret{}).SetupWithManager(ctx, mgr); err != nil {
 				setupLog.Error(err, "unable to create indexer", "indexer", "DatastoreUsedSecret")
 
@@ -170,23 +472,48 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return err
 			}
 
+			if err = (&kamajiv1alpha1.TenantControlPlaneSpecDataStore{}).SetupWithManager(ctx, mgr); err != nil {
+				setupLog.Error(err, "unable to create indexer", "indexer", "TenantControlPlaneSpecDataStore")
+
+				return err
+			}
+
+			if err = (&kamajiv1alpha1.TenantControlPlaneNetwork{}).SetupWithManager(ctx, mgr); err != nil {
+				setupLog.Error(err, "unable to create indexer", "indexer", "TenantControlPlaneNetwork")
+
+				return err
+			}
+
 			err = webhook.Register(mgr, map[routes.Route][]handlers.Handler{
 				routes.TenantControlPlaneMigrate{}: {
 					handlers.Freeze{},
 				},
 				routes.TenantControlPlaneDefaults{}: {
-					handlers.TenantControlPlaneDefaults{DefaultDatastore: datastore},
+					handlers.TenantControlPlaneDefaults{
+						Client:           mgr.GetClient(),
+						DefaultDatastore: datastore,
+						InflightDefaults: inflightLimits,
+					},
 				},
 				routes.TenantControlPlaneValidate{}: {
 					handlers.TenantControlPlaneName{},
 					handlers.TenantControlPlaneVersion{},
 					handlers.TenantControlPlaneKubeletAddresses{},
+					handlers.TenantControlPlaneService{},
+					handlers.TenantControlPlaneNetworkProfile{Client: mgr.GetClient(), CIDROverlapPolicy: handlers.CIDROverlapPolicy(cidrOverlapPolicy)},
+					handlers.TenantControlPlaneAutomation{},
+					handlers.TenantControlPlaneAPIServer{},
+					handlers.TenantControlPlaneCertificates{},
+					handlers.TenantControlPlaneAddons{},
+					handlers.TenantControlPlaneKonnectivity{Strict: strictKonnectivity},
 					handlers.TenantControlPlaneDataStore{Client: mgr.GetClient()},
 					handlers.TenantControlPlaneDeployment{
 						Client: mgr.GetClient(),
 						DeploymentBuilder: controlplane.Deployment{
 							Client:             mgr.GetClient(),
 							KineContainerImage: kineImage,
+							KinePullPolicy:     corev1.PullPolicy(kinePullPolicy),
+							KineResources:      kineResources,
 						},
 						KonnectivityBuilder: controlplane.Konnectivity{
 							Scheme: *mgr.GetScheme(),
@@ -194,7 +521,7 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 					},
 				},
 				routes.DataStoreValidate{}: {
-					handlers.DataStoreValidation{Client: mgr.GetClient()},
+					handlers.DataStoreValidation{Client: mgr.GetClient(), AllowedNamespaces: dataStoreAllowedNamespaces, FileRoot: dataStoreFileRoot},
 				},
 				routes.DataStoreSecrets{}: {
 					handlers.DataStoreSecretValidation{Client: mgr.GetClient()},
@@ -211,6 +538,7 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				MigrateServiceName:      managerServiceName,
 				MigrateServiceNamespace: managerNamespace,
 				AdminClient:             mgr.GetClient(),
+				EventRecorder:           mgr.GetEventRecorderFor("kamaji-soot"),
 			}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to set up soot manager")
 
@@ -227,6 +555,35 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 
 				return err
 			}
+			dataStoreHealth.Checks["datastore"] = dataStoreController.DefaultDataStoreReadyz(mgr.Elected())
+			// Gating the readiness is opt-in, since the webhooks are unavailable as long as the Pod is not ready.
+			if dataStoreReadinessGate {
+				if err = mgr.AddReadyzCheck("datastore", dataStoreController.DefaultDataStoreReadyz(mgr.Elected())); err != nil {
+					setupLog.Error(err, "unable to set up the default DataStore ready check")
+
+					return err
+				}
+			}
+
+			if otelEndpoint != "" {
+				setupLog.Info("exporting the reconciliation traces", "endpoint", otelEndpoint)
+
+				shutdownTracing, tracingErr := tracing.Setup(ctx, otelEndpoint, otelInsecure)
+				if tracingErr != nil {
+					setupLog.Error(tracingErr, "unable to set up the tracing")
+
+					return tracingErr
+				}
+				// The signal handler context is already cancelled upon shutdown, a dedicated one is used to flush the spans.
+				defer func() {
+					shutdownCtx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+					defer cancelFn()
+
+					if shutdownErr := shutdownTracing(shutdownCtx); shutdownErr != nil {
+						setupLog.Error(shutdownErr, "unable to flush the pending traces")
+					}
+				}()
+			}
 
 			setupLog.Info("starting manager")
 			if err = mgr.Start(ctx); err != nil {
@@ -239,28 +596,70 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 		},
 	}
 
-	// Setting zap logger
+	// Setting zap logger flags
 	zapfs := flag.NewFlagSet("zap", flag.ExitOnError)
-	opts := zap.Options{
-		Development: true,
-	}
 	opts.BindFlags(zapfs)
 	cmd.Flags().AddGoFlagSet(zapfs)
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	cmd.Flags().StringVar(&logFormat, "log-format", "", "The format of the logs, one of json, or console: it takes precedence over the zap encoder, an empty value keeps the zap flags one.")
+	cmd.Flags().StringVar(&logLevel, "log-level", "", "The minimum level of the logs, one of debug, info, warn, or error: it takes precedence over the zap log level, an empty value keeps the zap flags one.")
 	// Setting CLI flags
-	cmd.Flags().StringVar(&metricsBindAddress, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
-	cmd.Flags().StringVar(&healthProbeBindAddress, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	cmd.Flags().StringVar(&metricsBindAddress, "metrics-bind-address", ":8080", "The address the metric endpoint binds to, in the <host>:<port> form: an empty value, or 0, disables it. It also serves the /readyz/datastore endpoint, failing until the default DataStore has been validated, or when its last connection check failed, with no impact on the Pod readiness unless the DataStore readiness gate is enabled.")
+	cmd.Flags().StringVar(&healthProbeBindAddress, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to, in the <host>:<port> form.")
+	cmd.Flags().BoolVar(&enablePprof, "enable-pprof", false, "Serve the Go runtime profiling data in the format expected by the pprof visualization tool, meant for troubleshooting purposes.")
+	cmd.Flags().StringVar(&pprofBindAddress, "pprof-bind-address", "127.0.0.1:8082", "The address the pprof endpoint binds to, in the <host>:<port> form, used only if the pprof endpoint is enabled.")
+	cmd.Flags().StringVar(&webhookBindHost, "webhook-bind-host", "", "The host the webhook server binds to: an empty value listens on all the interfaces.")
+	cmd.Flags().IntVar(&webhookBindPort, "webhook-bind-port", 9443, "The port the webhook server binds to.")
 	cmd.Flags().BoolVar(&leaderElect, "leader-elect", true, "Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
+	cmd.Flags().StringVar(&leaderElectLeaseID, "leader-elect-lease-id", "799b98bc.clastix.io", "The name of the leader election Lease: multiple Kamaji instances in the same namespace require different names.")
+	cmd.Flags().StringVar(&leaderElectNamespace, "leader-elect-namespace", "", "The namespace of the leader election Lease: an empty value defaults to the Kamaji namespace.")
+	cmd.Flags().DurationVar(&leaderElectLeaseDuration, "leader-elect-lease-duration", 15*time.Second, "The duration the non-leader candidates wait before forcing the acquisition of the leadership.")
+	cmd.Flags().DurationVar(&leaderElectRenewDeadline, "leader-elect-renew-deadline", 10*time.Second, "The duration the leader retries to refresh the leadership before giving it up, must be lower than the lease duration.")
+	cmd.Flags().DurationVar(&leaderElectRetryPeriod, "leader-elect-retry-period", 2*time.Second, "The duration the leader election clients wait between the actions.")
 	cmd.Flags().StringVar(&tmpDirectory, "tmp-directory", "/tmp/kamaji", "Directory which will be used to work with temporary files.")
+	cmd.Flags().BoolVar(&createTmpDirectory, "create-tmp-directory", true, "Create the temporary files directory at startup if missing: otherwise, the startup fails if the directory doesn't exist.")
+	cmd.Flags().BoolVar(&retainTmpOnError, "retain-tmp-on-error", false, "Retain the temporary files of the failed reconciliations for debugging purposes, rather than removing them: these could contain sensitive material.")
 	cmd.Flags().StringVar(&kineImage, "kine-image", "rancher/kine:v0.9.2-amd64", "Container image along with tag to use for the Kine sidecar container (used only if etcd-storage-type is set to one of kine strategies).")
+	cmd.Flags().StringVar(&kinePullPolicy, "kine-pull-policy", "", "The pull policy of the Kine containers, one of Always, IfNotPresent, or Never, unless set by the Tenant Control Plane: an empty value infers it from the image tag.")
+	cmd.Flags().StringVar(&kineCPURequest, "kine-cpu-request", "", "The CPU request of the Kine container, unless its resources are set by the Tenant Control Plane: an empty value doesn't set it.")
+	cmd.Flags().StringVar(&kineMemoryRequest, "kine-memory-request", "", "The memory request of the Kine container, unless its resources are set by the Tenant Control Plane: an empty value doesn't set it.")
+	cmd.Flags().StringVar(&kineCPULimit, "kine-cpu-limit", "", "The CPU limit of the Kine container, unless its resources are set by the Tenant Control Plane: an empty value doesn't set it.")
+	cmd.Flags().StringVar(&kineMemoryLimit, "kine-memory-limit", "", "The memory limit of the Kine container, unless its resources are set by the Tenant Control Plane: an empty value doesn't set it.")
 	cmd.Flags().StringVar(&datastore, "datastore", "etcd", "The default DataStore that should be used by Kamaji to setup the required storage.")
 	cmd.Flags().StringVar(&migrateJobImage, "migrate-image", fmt.Sprintf("clastix/kamaji:%s", internal.GitTag), "Specify the container image to launch when a TenantControlPlane is migrated to a new datastore.")
 	cmd.Flags().IntVar(&maxConcurrentReconciles, "max-concurrent-tcp-reconciles", 1, "Specify the number of workers for the Tenant Control Plane controller (beware of CPU consumption)")
+	cmd.Flags().IntVar(&maxConcurrentDSReconciles, "max-concurrent-datastore-reconciles", 1, "Specify the number of workers for the DataStore controller, useful when many Tenant Control Planes share a few DataStores.")
 	cmd.Flags().StringVar(&managerNamespace, "pod-namespace", os.Getenv("POD_NAMESPACE"), "The Kubernetes Namespace on which the Operator is running in, required for the TenantControlPlane migration jobs.")
 	cmd.Flags().StringVar(&managerServiceName, "webhook-service-name", "kamaji-webhook-service", "The Kamaji webhook server Service name which is used to get validation webhooks, required for the TenantControlPlane migration jobs.")
 	cmd.Flags().StringVar(&managerServiceAccountName, "serviceaccount-name", os.Getenv("SERVICE_ACCOUNT"), "The Kubernetes Namespace on which the Operator is running in, required for the TenantControlPlane migration jobs.")
 	cmd.Flags().StringVar(&webhookCAPath, "webhook-ca-path", "/tmp/k8s-webhook-server/serving-certs/ca.crt", "Path to the Manager webhook server CA, required for the TenantControlPlane migration jobs.")
-	cmd.Flags().DurationVar(&controllerReconcileTimeout, "controller-reconcile-timeout", 30*time.Second, "The reconciliation request timeout before the controller withdraw the external resource calls, such as dealing with the Datastore, or the Tenant Control Plane API endpoint.")
+	cmd.Flags().DurationVar(&controllerReconcileTimeout, "controller-reconcile-timeout", 30*time.Second, "The reconciliation request timeout before the controllers withdraw the external resource calls, such as dealing with the Datastore, or the Tenant Control Plane API endpoint: it bounds both the Tenant Control Plane, and the DataStore reconciliations.")
+	cmd.Flags().DurationVar(&finalizerTimeout, "finalizer-timeout", 0, "The time a Tenant Control Plane is allowed to be in the terminating state before Kamaji gives up the DataStore clean-up and removes the finalizer, leaving the data behind: a zero value disables the timeout.")
+	cmd.Flags().DurationVar(&lbRequeueInterval, "loadbalancer-requeue-interval", 5*time.Second, "The initial delay before checking again a Tenant Control Plane waiting for its LoadBalancer Service address, doubling on each check.")
+	cmd.Flags().DurationVar(&lbRequeueMaxInterval, "loadbalancer-requeue-max-interval", time.Minute, "The maximum delay between the checks of a Tenant Control Plane waiting for its LoadBalancer Service address.")
+	cmd.Flags().DurationVar(&lbPendingTimeout, "loadbalancer-pending-timeout", 10*time.Minute, "The time a Tenant Control Plane can wait for its LoadBalancer Service address before a Warning event is emitted: a zero value disables the event.")
+	cmd.Flags().DurationVar(&dataStoreInitGrace, "datastore-init-grace", 2*time.Minute, "The time after the Tenant Control Plane creation during which the DataStore connection failures are reported as initializing, rather than errors: a zero value disables the grace period.")
+	cmd.Flags().StringVar(&kubeconfigBackupSecret, "kubeconfig-backup-secret", "", "The Secret in the <namespace>/<name> form where the admin kubeconfig of all the Tenant Control Planes are exported for backup purposes, refreshed upon rotation: an empty value disables the export.")
+	cmd.Flags().StringVar(&kubeconfigBackupKeySecret, "kubeconfig-backup-encryption-key", "", "The Secret in the <namespace>/<name> form storing under the key data key the 32 bytes AES-256 key the exported admin kubeconfigs are encrypted with: required if the kubeconfig backup Secret is set.")
+	cmd.Flags().DurationVar(&kubeconfigBackupInterval, "kubeconfig-backup-interval", time.Hour, "The interval between the periodic exports of the Tenant Control Planes admin kubeconfig, used only if the kubeconfig backup Secret is set.")
+	cmd.Flags().StringVar(&cidrOverlapPolicy, "cidr-overlap-policy", string(handlers.CIDROverlapPolicyIgnore), "How the Pod and Service CIDRs overlapping across the Tenant Control Planes sharing the kamaji.clastix.io/network label are handled, one of Ignore, Warn, or Reject.")
+	cmd.Flags().BoolVar(&configurationSnapshot, "enable-configuration-snapshot", false, "Track the effective configuration of the Tenant Control Planes in their status, updated only upon changes, exposing the number of changes as a metric.")
+	cmd.Flags().BoolVar(&enableDrainMetrics, "enable-drain-metrics", false, "Expose the metrics about the Tenant Control Plane Pods draining their connections upon termination, such as during rollouts.")
+	cmd.Flags().StringToStringVar(&inflightDefaults, "apiserver-inflight-defaults", nil, "Default kube-apiserver inflight limits per DataStore tier in the <tier>=<max-requests-inflight>/<max-mutating-requests-inflight> form, the tier is defined by the kamaji.clastix.io/datastore-tier DataStore label, or its driver if missing (e.g.: MySQL=200/100).")
+	cmd.Flags().BoolVar(&strictKonnectivity, "strict-konnectivity-requirement", false, "Reject the Tenant Control Planes disabling the Konnectivity addon when their network profile requires it, instead of allowing them with a warning.")
+	cmd.Flags().StringVar(&dataStoreTLSValidation, "datastore-tls-validation", string(kamajidatastore.TLSValidationModeNone), "How strictly the DataStore client certificates are validated, one of None, or Strict: the latter verifies they chain up to the DataStore Certificate Authority with the clientAuth extended key usage, reporting the outcome with the TLSValid condition.")
+	cmd.Flags().IntVar(&dataStoreTLSMaxChainDepth, "datastore-tls-max-chain-depth", 0, "The maximum number of certificates, including the root, of the DataStore client certificate chains verified by the Strict TLS validation: a zero value doesn't limit it.")
+	cmd.Flags().IntVar(&dataStoreTriggerBuffer, "datastore-trigger-buffer", 0, "The capacity of the channel used by the DataStore controller to trigger the reconciliation of the Tenant Control Planes using a changed DataStore: a zero value makes it unbuffered.")
+	cmd.Flags().Float64Var(&dataStoreTriggerRate, "datastore-trigger-rate", 0, "The maximum number of Tenant Control Planes reconciliations triggered per second by the DataStore changes, preserving their order: a zero value doesn't limit them.")
+	cmd.Flags().DurationVar(&dataStoreTriggerTimeout, "datastore-trigger-timeout", 0, "The time the DataStore controller waits for each Tenant Control Plane trigger to be consumed, before requeueing the remaining ones to release the worker: a zero value waits for the triggers to be consumed.")
+	cmd.Flags().DurationVar(&dataStoreHealthCheck, "datastore-healthcheck-interval", time.Minute, "The interval the connection to the DataStores is checked at, reported with the ConnectionHealthy condition: a zero value checks it upon the DataStore changes only.")
+	cmd.Flags().BoolVar(&dataStoreTrackUsage, "datastore-track-usage", true, "Report the Tenant Control Planes using the DataStores in their status, guarding their deletion with a finalizer: disabling it reduces the API writes on large clusters, the deletion of the DataStores in use is still denied by the webhook.")
+	cmd.Flags().BoolVar(&dataStoreReadinessGate, "datastore-readiness-gate", false, "Gate the Pod readiness on the default DataStore, failing until it has been validated by the elected replica, or when its last connection check failed: the webhooks are unavailable as long as the Pod is not ready, including the ones required to fix the DataStore.")
+	cmd.Flags().StringVar(&watchNamespace, "watch-namespace", "", "The namespace the Tenant Control Planes are managed in, restricting the informers to it: the DataStores are still cluster-scoped, and their Secrets are expected in the Kamaji namespace, or in the DataStore allowed namespaces. An empty value manages the Tenant Control Planes cluster-wide.")
+	cmd.Flags().StringSliceVar(&dataStoreAllowedNamespaces, "datastore-allowed-namespaces", nil, "The namespaces where the Secrets referenced by the DataStores, such as the ones containing the credentials, are allowed to live: an empty value allows any namespace.")
+	cmd.Flags().StringVar(&dataStoreFileRoot, "datastore-file-root", "", "The absolute path of the directory the file paths referenced by the DataStores must be rooted at, such as the mount path of a CSI secret store volume: an empty value disables the file paths.")
+	cmd.Flags().StringVar(&otelEndpoint, "otel-endpoint", "", "The OTLP gRPC endpoint in the <host>:<port> form where the OpenTelemetry traces of the reconciliations are exported: an empty value disables the tracing.")
+	cmd.Flags().BoolVar(&otelInsecure, "otel-insecure", false, "Export the OpenTelemetry traces with no transport security, used only if the OpenTelemetry endpoint is set.")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate the DataStores, and the Tenant Control Planes, computing their conditions without persisting any change, nor reconciling their resources: meant for the CI pipelines.")
 	cmd.Flags().DurationVar(&cacheResyncPeriod, "cache-resync-period", 10*time.Hour, "The controller-runtime.Manager cache resync period.")
 
 	cobra.OnInitialize(func() {
@@ -269,3 +668,33 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 
 	return cmd
 }
+
+// newBuildInfoCollector returns the kamaji_build_info gauge, labelled with the build information of the running Kamaji.
+func newBuildInfoCollector() prometheus.Collector {
+	buildInfo := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kamaji_build_info",
+		Help: "Build information of the running Kamaji, constantly set to 1.",
+	}, []string{"version", "commit", "goversion", "dirty"})
+	buildInfo.WithLabelValues(internal.GitTag, internal.GitCommit, goRuntime.Version(), strconv.FormatBool(len(internal.GitDirty) > 0)).Set(1)
+
+	return buildInfo
+}
+
+// scopeCacheOptions restricts the informers to the watched namespace, the cluster-scoped objects such as the DataStores are still cached:
+// the migration Jobs live in the Kamaji namespace, as the DataStore Secrets, also allowed in the DataStore allowed namespaces.
+func scopeCacheOptions(opts *cache.Options, watchNamespace, managerNamespace string, dataStoreAllowedNamespaces []string) {
+	opts.DefaultNamespaces = map[string]cache.Config{watchNamespace: {}}
+
+	if opts.ByObject == nil {
+		opts.ByObject = map[client.Object]cache.ByObject{}
+	}
+
+	kamajiNamespaces := map[string]cache.Config{watchNamespace: {}, managerNamespace: {}}
+	opts.ByObject[&batchv1.Job{}] = cache.ByObject{Namespaces: kamajiNamespaces}
+
+	secretNamespaces := map[string]cache.Config{watchNamespace: {}, managerNamespace: {}}
+	for _, namespace := range dataStoreAllowedNamespaces {
+		secretNamespaces[namespace] = cache.Config{}
+	}
+	opts.ByObject[&corev1.Secret{}] = cache.ByObject{Namespaces: secretNamespaces}
+}
@@ -0,0 +1,244 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	goRuntime "runtime"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/clastix/kamaji/internal"
+)
+
+var (
+	managerCmd     *cobra.Command
+	managerCmdOnce sync.Once
+)
+
+// preRun validates the manager flags with the given values, on top of the required ones: the webhook CA is missing,
+// thus the validation passing the flags checks fails reading it, wrapping fs.ErrNotExist.
+// The command is created once, since it sets up the signal handler.
+func preRun(t *testing.T, flags map[string]string) error {
+	t.Helper()
+
+	managerCmdOnce.Do(func() {
+		managerCmd = NewCmd(runtime.NewScheme())
+	})
+
+	managerCmd.Flags().Visit(func(flag *pflag.Flag) {
+		if err := flag.Value.Set(flag.DefValue); err != nil {
+			t.Fatal(err)
+		}
+
+		flag.Changed = false
+	})
+
+	required := map[string]string{
+		"kine-image":           "rancher/kine:v0.11.4-amd64",
+		"datastore":            "default",
+		"migrate-image":        "clastix/kamaji:latest",
+		"tmp-directory":        t.TempDir(),
+		"pod-namespace":        "kamaji-system",
+		"webhook-service-name": "kamaji-webhook-service",
+		"serviceaccount-name":  "kamaji-controller-manager",
+		"webhook-ca-path":      filepath.Join(t.TempDir(), "ca.crt"),
+		// The validation clears the pprof address when disabled, thus it's restored.
+		"pprof-bind-address": managerCmd.Flags().Lookup("pprof-bind-address").DefValue,
+	}
+
+	for _, values := range []map[string]string{required, flags} {
+		for name, value := range values {
+			if err := managerCmd.Flags().Set(name, value); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	return managerCmd.PreRunE(managerCmd, nil)
+}
+
+// expectFlags checks the flags validation outcome: the accepted flags fail upon the missing webhook CA only.
+func expectFlags(t *testing.T, flags map[string]string, wantErr bool) {
+	t.Helper()
+
+	err := preRun(t, flags)
+
+	switch {
+	case wantErr && (err == nil || errors.Is(err, fs.ErrNotExist)):
+		t.Fatalf("expected the flags %v to be rejected, got %v", flags, err)
+	case !wantErr && !errors.Is(err, fs.ErrNotExist):
+		t.Fatalf("expected the flags %v to be accepted, got %v", flags, err)
+	}
+}
+
+func TestManagerPprofFlags(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		flags   map[string]string
+		wantErr bool
+	}{
+		{name: "disabled", flags: map[string]string{}},
+		{name: "enabled with the default address", flags: map[string]string{"enable-pprof": "true"}},
+		{name: "enabled with a custom address", flags: map[string]string{"enable-pprof": "true", "pprof-bind-address": ":6060"}},
+		{name: "enabled with an invalid address", flags: map[string]string{"enable-pprof": "true", "pprof-bind-address": "localhost"}, wantErr: true},
+		{name: "enabled with no address", flags: map[string]string{"enable-pprof": "true", "pprof-bind-address": ""}, wantErr: true},
+		{name: "disabled with an invalid address", flags: map[string]string{"pprof-bind-address": "localhost"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			expectFlags(t, tc.flags, tc.wantErr)
+		})
+	}
+}
+
+func TestManagerLogFlags(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		flags   map[string]string
+		wantErr bool
+	}{
+		{name: "zap flags", flags: map[string]string{}},
+		{name: "JSON format", flags: map[string]string{"log-format": "json"}},
+		{name: "console format", flags: map[string]string{"log-format": "console"}},
+		{name: "unknown format", flags: map[string]string{"log-format": "text"}, wantErr: true},
+		{name: "debug level", flags: map[string]string{"log-level": "debug"}},
+		{name: "error level", flags: map[string]string{"log-format": "json", "log-level": "error"}},
+		{name: "unknown level", flags: map[string]string{"log-level": "verbose"}, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			expectFlags(t, tc.flags, tc.wantErr)
+		})
+	}
+}
+
+func TestBuildInfoCollector(t *testing.T) {
+	defer func(tag, commit, dirty string) {
+		internal.GitTag, internal.GitCommit, internal.GitDirty = tag, commit, dirty
+	}(internal.GitTag, internal.GitCommit, internal.GitDirty)
+
+	for _, tc := range []struct {
+		name      string
+		dirty     string
+		wantDirty string
+	}{
+		{name: "clean build", dirty: "", wantDirty: "false"},
+		{name: "dirty build", dirty: "dirty", wantDirty: "true"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			internal.GitTag, internal.GitCommit, internal.GitDirty = "v1.0.0", "0123abc", tc.dirty
+
+			want := fmt.Sprintf(`
+# HELP kamaji_build_info Build information of the running Kamaji, constantly set to 1.
+# TYPE kamaji_build_info gauge
+kamaji_build_info{commit="0123abc",dirty="%s",goversion="%s",version="v1.0.0"} 1
+`, tc.wantDirty, goRuntime.Version())
+
+			if err := testutil.CollectAndCompare(newBuildInfoCollector(), strings.NewReader(want)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestManagerWatchNamespaceFlag(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		flags   map[string]string
+		wantErr bool
+	}{
+		{name: "cluster-wide", flags: map[string]string{}},
+		{name: "namespace", flags: map[string]string{"watch-namespace": "tenants"}},
+		{name: "uppercase namespace", flags: map[string]string{"watch-namespace": "Tenants"}, wantErr: true},
+		{name: "subdomain", flags: map[string]string{"watch-namespace": "tenants.acme"}, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			expectFlags(t, tc.flags, tc.wantErr)
+		})
+	}
+}
+
+func TestScopeCacheOptions(t *testing.T) {
+	opts := cache.Options{ByObject: map[client.Object]cache.ByObject{&corev1.Pod{}: {Label: labels.Everything()}}}
+	scopeCacheOptions(&opts, "tenants", "kamaji-system", []string{"datastores"})
+
+	namespaces := func(configs map[string]cache.Config) []string {
+		names := make([]string, 0, len(configs))
+		for name := range configs {
+			names = append(names, name)
+		}
+
+		slices.Sort(names)
+
+		return names
+	}
+
+	if got := namespaces(opts.DefaultNamespaces); !slices.Equal(got, []string{"tenants"}) {
+		t.Fatalf("expected the informers scoped to the watched namespace, got %v", got)
+	}
+
+	want := map[string][]string{
+		"Pod":    nil,
+		"Job":    {"kamaji-system", "tenants"},
+		"Secret": {"datastores", "kamaji-system", "tenants"},
+	}
+
+	if len(opts.ByObject) != len(want) {
+		t.Fatalf("expected %d objects options, got %d", len(want), len(opts.ByObject))
+	}
+
+	for object, byObject := range opts.ByObject {
+		kind := reflect.TypeOf(object).Elem().Name()
+
+		wantNamespaces, ok := want[kind]
+		if !ok {
+			t.Fatalf("unexpected %s options", kind)
+		}
+
+		if got := namespaces(byObject.Namespaces); !slices.Equal(got, wantNamespaces) {
+			t.Fatalf("expected the %s informers scoped to %v, got %v", kind, wantNamespaces, got)
+		}
+
+		if kind == "Pod" && byObject.Label == nil {
+			t.Fatal("expected the Pod label selector to be preserved")
+		}
+	}
+}
+
+func TestManagerWebhookBindFlags(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		flags   map[string]string
+		wantErr bool
+	}{
+		{name: "default port", flags: map[string]string{}},
+		{name: "custom host and port", flags: map[string]string{"webhook-bind-host": "127.0.0.1", "webhook-bind-port": "10250"}},
+		{name: "highest port", flags: map[string]string{"webhook-bind-port": "65535"}},
+		{name: "zero port", flags: map[string]string{"webhook-bind-port": "0"}, wantErr: true},
+		{name: "negative port", flags: map[string]string{"webhook-bind-port": "-1"}, wantErr: true},
+		{name: "out of range port", flags: map[string]string{"webhook-bind-port": "65536"}, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			expectFlags(t, tc.flags, tc.wantErr)
+		})
+	}
+	// The port matches the one of the deployment manifests.
+	if port := managerCmd.Flags().Lookup("webhook-bind-port").DefValue; port != "9443" {
+		t.Fatalf("expected the default webhook port 9443, got %s", port)
+	}
+}
@@ -10,12 +10,16 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/datastore"
 )
 
@@ -31,6 +35,9 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 		Use:          "migrate",
 		Short:        "Migrate the data of a TenantControlPlane to another compatible DataStore",
 		SilenceUsage: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancelFn := context.WithTimeout(context.Background(), timeout)
 			defer cancelFn()
@@ -72,8 +79,9 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return err
 			}
 
-			if tcp.Status.Storage.Driver != string(targetDs.Spec.Driver) {
-				return fmt.Errorf("migration between DataStore with different driver is not supported")
+			crossDriver := tcp.Status.Storage.Driver != string(targetDs.Spec.Driver)
+			if crossDriver && tcp.GetAnnotations()[constants.CrossDriverMigrationConfirmation] != "true" {
+				return fmt.Errorf("migration between DataStore with different driver requires the %s annotation set to true", constants.CrossDriverMigrationConfirmation)
 			}
 
 			if tcp.Status.Storage.DataStoreName == targetDs.GetName() {
@@ -98,6 +106,10 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 			// Start migrating from the old Datastore to the new one
 			log.Info("migration from origin to target started")
 
+			if crossDriver {
+				return migrateCrossDriver(ctx, client, tcp, originDs, targetDs, originConnection, targetConnection)
+			}
+
 			if err = originConnection.Migrate(ctx, *tcp, targetConnection); err != nil {
 				return fmt.Errorf("unable to migrate data from %s to %s: %w", originDs.GetName(), targetDs.GetName(), err)
 			}
@@ -117,3 +129,86 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 
 	return cmd
 }
+
+// migrateCrossDriver translates the key-space between different drivers, such as kine and etcd,
+// tracking the progress in the Tenant Control Plane status and validating the imported data.
+func migrateCrossDriver(ctx context.Context, client ctrlclient.Client, tcp *kamajiv1alpha1.TenantControlPlane, originDs, targetDs *kamajiv1alpha1.DataStore, origin, target datastore.Connection) error {
+	log := ctrl.Log
+
+	status := kamajiv1alpha1.DataStoreMigrationStatus{
+		Source: originDs.GetName(),
+		Target: targetDs.GetName(),
+	}
+
+	fail := func(err error) error {
+		status.Phase, status.Message = kamajiv1alpha1.DataStoreMigrationFailed, err.Error()
+
+		if statusErr := updateMigrationStatus(ctx, client, tcp, status); statusErr != nil {
+			log.Error(statusErr, "cannot update the migration status")
+		}
+
+		return err
+	}
+
+	log.Info("exporting data from the origin DataStore")
+
+	status.Phase = kamajiv1alpha1.DataStoreMigrationExporting
+	if err := updateMigrationStatus(ctx, client, tcp, status); err != nil {
+		return err
+	}
+
+	kvs, err := origin.GetKeyValues(ctx, *tcp)
+	if err != nil {
+		return fail(fmt.Errorf("unable to export data from %s: %w", originDs.GetName(), err))
+	}
+
+	log.Info("importing data to the target DataStore", "objects", len(kvs))
+
+	status.Phase, status.ObjectCount = kamajiv1alpha1.DataStoreMigrationImporting, len(kvs)
+	if err = updateMigrationStatus(ctx, client, tcp, status); err != nil {
+		return err
+	}
+
+	if err = target.PutKeyValues(ctx, *tcp, kvs); err != nil {
+		return fail(fmt.Errorf("unable to import data to %s: %w", targetDs.GetName(), err))
+	}
+
+	log.Info("validating the imported data")
+
+	status.Phase = kamajiv1alpha1.DataStoreMigrationValidating
+	if err = updateMigrationStatus(ctx, client, tcp, status); err != nil {
+		return err
+	}
+
+	imported, err := target.GetKeyValues(ctx, *tcp)
+	if err != nil {
+		return fail(fmt.Errorf("unable to retrieve imported data from %s: %w", targetDs.GetName(), err))
+	}
+
+	if err = datastore.ValidateKeyValues(kvs, imported); err != nil {
+		return fail(fmt.Errorf("integrity validation failed: %w", err))
+	}
+
+	status.Phase, status.Checksum = kamajiv1alpha1.DataStoreMigrationCompleted, datastore.Checksum(imported)
+	if err = updateMigrationStatus(ctx, client, tcp, status); err != nil {
+		return err
+	}
+
+	log.Info("migration completed", "objects", status.ObjectCount, "checksum", status.Checksum)
+
+	return nil
+}
+
+func updateMigrationStatus(ctx context.Context, client ctrlclient.Client, tcp *kamajiv1alpha1.TenantControlPlane, status kamajiv1alpha1.DataStoreMigrationStatus) error {
+	status.LastUpdate = metav1.Now()
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := client.Get(ctx, types.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.GetName()}, tcp); err != nil {
+			return err
+		}
+
+		tcp.Status.Storage.Migration = status.DeepCopy()
+
+		return client.Status().Update(ctx, tcp)
+	})
+}
@@ -0,0 +1,29 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// CheckBindAddress ensures the value of the given flag is a valid bind address in the <host>:<port> form,
+// where the host can be omitted to listen on all the interfaces: when allowed, an empty value, or "0", disables the endpoint.
+func CheckBindAddress(flag, address string, allowDisabled bool) error {
+	if allowDisabled && (address == "" || address == "0") {
+		return nil
+	}
+
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("expecting a <host>:<port> value for --%s arg, got %q: %w", flag, address, err)
+	}
+
+	if number, err := strconv.ParseUint(port, 10, 16); err != nil || number == 0 {
+		return fmt.Errorf("expecting a valid port for --%s arg, got %q", flag, port)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+)
+
+func TestCheckBindAddress(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		address       string
+		allowDisabled bool
+		wantErr       bool
+	}{
+		{name: "host and port", address: "127.0.0.1:8080"},
+		{name: "all interfaces", address: ":8080"},
+		{name: "IPv6 host", address: "[::1]:8080"},
+		{name: "missing port", address: "127.0.0.1", wantErr: true},
+		{name: "port zero", address: ":0", wantErr: true},
+		{name: "port out of range", address: ":65536", wantErr: true},
+		{name: "named port", address: ":http", wantErr: true},
+		{name: "empty value", address: "", wantErr: true},
+		{name: "empty value, disabled", address: "", allowDisabled: true},
+		{name: "zero value, disabled", address: "0", allowDisabled: true},
+		{name: "zero value", address: "0", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckBindAddress("bind-address", tc.address, tc.allowDisabled)
+			if tc.wantErr && err == nil {
+				t.Fatalf("expected an error for %q", tc.address)
+			}
+
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected error for %q: %s", tc.address, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"os"
+)
+
+// CheckWritableDirectory ensures the value of the given flag is an existing directory, writable by Kamaji,
+// by creating and removing a probe file: when create is true, the missing directory is created.
+func CheckWritableDirectory(flag, directory string, create bool) error {
+	if create {
+		if err := os.MkdirAll(directory, os.FileMode(0o700)); err != nil {
+			return fmt.Errorf("cannot create the directory %q of --%s arg: %w", directory, flag, err)
+		}
+	}
+
+	info, err := os.Stat(directory)
+	if err != nil {
+		return fmt.Errorf("expecting an existing directory for --%s arg, got %q: %w", flag, directory, err)
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("expecting a directory for --%s arg, got the file %q", flag, directory)
+	}
+
+	probe, err := os.CreateTemp(directory, ".probe-")
+	if err != nil {
+		return fmt.Errorf("expecting a writable directory for --%s arg, got %q: %w", flag, directory, err)
+	}
+
+	_ = probe.Close()
+
+	if err = os.Remove(probe.Name()); err != nil {
+		return fmt.Errorf("cannot remove the probe file from the directory %q of --%s arg: %w", directory, flag, err)
+	}
+
+	return nil
+}
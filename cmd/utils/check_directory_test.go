@@ -0,0 +1,78 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckWritableDirectory(t *testing.T) {
+	t.Run("existing directory", func(t *testing.T) {
+		directory := t.TempDir()
+
+		if err := CheckWritableDirectory("directory", directory, false); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		// The probe file must be removed.
+		entries, err := os.ReadDir(directory)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(entries) > 0 {
+			t.Fatalf("expected an empty directory, got %d entries", len(entries))
+		}
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		if err := CheckWritableDirectory("directory", filepath.Join(t.TempDir(), "missing"), false); err == nil {
+			t.Fatal("expected an error for a missing directory")
+		}
+	})
+
+	t.Run("missing directory, created", func(t *testing.T) {
+		directory := filepath.Join(t.TempDir(), "nested", "missing")
+
+		if err := CheckWritableDirectory("directory", directory, true); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if info, err := os.Stat(directory); err != nil || !info.IsDir() {
+			t.Fatalf("expected the directory %q to be created", directory)
+		}
+	})
+
+	t.Run("file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+
+		if err := os.WriteFile(file, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := CheckWritableDirectory("directory", file, false); err == nil {
+			t.Fatal("expected an error for a file")
+		}
+	})
+
+	t.Run("read-only directory", func(t *testing.T) {
+		// The permissions are not enforced for the root user.
+		if os.Geteuid() == 0 {
+			t.Skip("running as root")
+		}
+
+		directory := t.TempDir()
+
+		if err := os.Chmod(directory, 0o500); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() { _ = os.Chmod(directory, 0o700) })
+
+		if err := CheckWritableDirectory("directory", directory, false); err == nil {
+			t.Fatal("expected an error for a read-only directory")
+		}
+	})
+}
@@ -0,0 +1,27 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ParseNamespacedName parses the value of the given flag in the <namespace>/<name> form:
+// when any namespace is given, the parsed one must be among them, such as the namespaces cached by the manager.
+func ParseNamespacedName(flag, value string, namespaces ...string) (types.NamespacedName, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return types.NamespacedName{}, fmt.Errorf("expecting a <namespace>/<name> value for --%s arg, got %q", flag, value)
+	}
+
+	if len(namespaces) > 0 && !slices.Contains(namespaces, parts[0]) {
+		return types.NamespacedName{}, fmt.Errorf("expecting the namespace of --%s arg to be one of %s, got %q", flag, strings.Join(namespaces, ", "), parts[0])
+	}
+
+	return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, nil
+}
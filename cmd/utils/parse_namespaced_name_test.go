@@ -0,0 +1,43 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParseNamespacedName(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		value      string
+		namespaces []string
+		want       types.NamespacedName
+		wantErr    bool
+	}{
+		{name: "namespaced name", value: "kamaji-system/backup", want: types.NamespacedName{Namespace: "kamaji-system", Name: "backup"}},
+		{name: "within the namespaces", value: "tenants/backup", namespaces: []string{"tenants", "kamaji-system"}, want: types.NamespacedName{Namespace: "tenants", Name: "backup"}},
+		{name: "outside the namespaces", value: "default/backup", namespaces: []string{"tenants", "kamaji-system"}, wantErr: true},
+		{name: "missing namespace", value: "/backup", wantErr: true},
+		{name: "missing name", value: "kamaji-system/", wantErr: true},
+		{name: "name only", value: "backup", wantErr: true},
+		{name: "too many parts", value: "kamaji-system/backup/key", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseNamespacedName("secret", tc.value, tc.namespaces...)
+			if tc.wantErr && err == nil {
+				t.Fatalf("expected an error for %q", tc.value)
+			}
+
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected error for %q: %s", tc.value, err)
+			}
+
+			if got != tc.want {
+				t.Fatalf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ParseResourceList returns the list of the CPU, and memory, quantities read from the given flags:
+// the empty values are skipped, and a nil list is returned when both of them are empty.
+func ParseResourceList(cpuFlag, cpu, memoryFlag, memory string) (corev1.ResourceList, error) {
+	var list corev1.ResourceList
+
+	for _, item := range []struct {
+		flag  string
+		name  corev1.ResourceName
+		value string
+	}{
+		{flag: cpuFlag, name: corev1.ResourceCPU, value: cpu},
+		{flag: memoryFlag, name: corev1.ResourceMemory, value: memory},
+	} {
+		if len(item.value) == 0 {
+			continue
+		}
+
+		quantity, err := resource.ParseQuantity(item.value)
+		if err != nil {
+			return nil, fmt.Errorf("expecting a valid quantity for --%s arg, got %q: %w", item.flag, item.value, err)
+		}
+
+		if list == nil {
+			list = corev1.ResourceList{}
+		}
+
+		list[item.name] = quantity
+	}
+
+	return list, nil
+}
+
+// CheckPullPolicy ensures the value of the given flag is a valid image pull policy: an empty value is allowed.
+func CheckPullPolicy(flag, policy string) error {
+	switch corev1.PullPolicy(policy) {
+	case "", corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+		return nil
+	default:
+		return fmt.Errorf("expecting one of %s, %s, or %s for --%s arg, got %q", corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever, flag, policy)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestParseResourceList(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		cpu     string
+		memory  string
+		want    corev1.ResourceList
+		wantErr bool
+	}{
+		{name: "empty values"},
+		{name: "cpu only", cpu: "100m", want: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}},
+		{name: "memory only", memory: "128Mi", want: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")}},
+		{name: "cpu and memory", cpu: "1", memory: "1Gi", want: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")}},
+		{name: "invalid cpu", cpu: "one", wantErr: true},
+		{name: "invalid memory", memory: "1GB", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseResourceList("cpu", tc.cpu, "memory", tc.memory)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if tc.want == nil && got != nil {
+				t.Fatalf("expected a nil list, got %v", got)
+			}
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+
+			for name, quantity := range tc.want {
+				if value, ok := got[name]; !ok || !value.Equal(quantity) {
+					t.Fatalf("expected %s %s, got %s", name, quantity.String(), value.String())
+				}
+			}
+		})
+	}
+}
+
+func TestCheckPullPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		policy  string
+		wantErr bool
+	}{
+		{policy: ""},
+		{policy: "Always"},
+		{policy: "IfNotPresent"},
+		{policy: "Never"},
+		{policy: "always", wantErr: true},
+		{policy: "Sometimes", wantErr: true},
+	} {
+		err := CheckPullPolicy("pull-policy", tc.policy)
+		if tc.wantErr && err == nil {
+			t.Fatalf("expected an error for %q", tc.policy)
+		}
+
+		if !tc.wantErr && err != nil {
+			t.Fatalf("unexpected error for %q: %s", tc.policy, err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package validate
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+func NewCmd(scheme *runtime.Scheme) *cobra.Command {
+	return &cobra.Command{
+		Use:          "validate-datastore <file>",
+		Short:        "Validate the TLS content of a DataStore manifest offline, with no need for a running cluster",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifest, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("cannot read the DataStore manifest: %w", err)
+			}
+
+			object, _, err := serializer.NewCodecFactory(scheme).UniversalDeserializer().Decode(manifest, nil, nil)
+			if err != nil {
+				return fmt.Errorf("cannot decode the DataStore manifest: %w", err)
+			}
+
+			ds, ok := object.(*kamajiv1alpha1.DataStore)
+			if !ok {
+				return fmt.Errorf("expecting a DataStore manifest, got %s", object.GetObjectKind().GroupVersionKind().Kind)
+			}
+			// The Secret, and file, references can't be resolved without a cluster, thus their checks are skipped.
+			unresolved, err := datastore.ValidateContent(context.Background(), datastore.InlineContentResolver(), *ds)
+			if err != nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "FAIL\t%s: %s\n", ds.GetName(), err.Error())
+
+				return fmt.Errorf("the DataStore %s is not valid", ds.GetName())
+			}
+
+			for _, name := range unresolved {
+				fmt.Fprintf(cmd.OutOrStdout(), "SKIP\t%s: the %s is unresolved, skipped\n", ds.GetName(), name)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "PASS\t%s\n", ds.GetName())
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package validate
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestValidateDataStore(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kamaji"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(0, 0, 7),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crt := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	malformed := base64.StdEncoding.EncodeToString([]byte("malformed"))
+
+	dataStore := func(ca, privateKey string) string {
+		return fmt.Sprintf(`apiVersion: kamaji.clastix.io/v1alpha1
+kind: DataStore
+metadata:
+  name: default
+spec:
+  driver: etcd
+  endpoints:
+  - etcd:2379
+  tlsConfig:
+    certificateAuthority:
+      certificate:
+        %s
+    clientCertificate:
+      certificate:
+        content: %s
+      privateKey:
+        %s
+`, ca, crt, privateKey)
+	}
+
+	scheme := runtime.NewScheme()
+	if err = kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		manifest string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "valid",
+			manifest: dataStore("content: "+crt, "content: "+keyPEM),
+			want:     []string{"PASS\tdefault"},
+		},
+		{
+			name:     "unresolved Secret",
+			manifest: dataStore("content: "+crt, "secretReference: {name: etcd-certs, namespace: kamaji-system, keyPath: tls.key}"),
+			want:     []string{"SKIP\tdefault: ", "PASS\tdefault"},
+		},
+		{
+			name:     "invalid",
+			manifest: dataStore("content: "+malformed, "content: "+keyPEM),
+			want:     []string{"FAIL\tdefault: invalid Certificate Authority certificate"},
+			wantErr:  true,
+		},
+		{
+			name:     "not a DataStore",
+			manifest: "apiVersion: kamaji.clastix.io/v1alpha1\nkind: TenantControlPlane\nmetadata:\n  name: tcp\n",
+			wantErr:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "datastore.yaml")
+			if err := os.WriteFile(path, []byte(tc.manifest), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+
+			cmd := NewCmd(scheme)
+			cmd.SetOut(&out)
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs([]string{path})
+
+			if err := cmd.Execute(); (err != nil) != tc.wantErr {
+				t.Fatalf("expected the error %t, got %v", tc.wantErr, err)
+			}
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			if len(tc.want) > 0 && len(lines) != len(tc.want) {
+				t.Fatalf("expected %d lines, got %q", len(tc.want), out.String())
+			}
+
+			for i, want := range tc.want {
+				if !strings.HasPrefix(lines[i], want) {
+					t.Fatalf("expected the line %q, got %q", want, lines[i])
+				}
+			}
+		})
+	}
+}
@@ -78,12 +78,21 @@ func (s *CertificateLifecycle) Reconcile(ctx context.Context, request reconcile.
 	if deadline.After(crt.NotAfter) {
 		logger.Info("certificate near expiration, must be rotated")
 
-		s.Channel <- event.GenericEvent{Object: &kamajiv1alpha1.TenantControlPlane{
+		tcp := kamajiv1alpha1.TenantControlPlane{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      secret.GetOwnerReferences()[0].Name,
 				Namespace: secret.Namespace,
 			},
-		}}
+		}
+		// The suspended Tenant Control Planes have no running Pods: the certificates are renewed upon the
+		// resume reconciliation, avoiding the rotation churn while suspended.
+		if err = s.client.Get(ctx, client.ObjectKeyFromObject(&tcp), &tcp); err == nil && tcp.IsSuspended() {
+			logger.Info("Tenant Control Plane is suspended, skipping certificate rotation")
+
+			return reconcile.Result{}, nil
+		}
+
+		s.Channel <- event.GenericEvent{Object: &tcp}
 
 		logger.Info("certificate rotation triggered")
 
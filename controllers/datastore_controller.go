@@ -5,42 +5,257 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/finalizers"
+	"github.com/clastix/kamaji/internal/datastore"
 )
 
 type DataStore struct {
-	Client client.Client
+	Client        client.Client
+	EventRecorder record.EventRecorder
+	// TLSValidation verifies the data store client certificates: the Tenant Control Planes reconciliation
+	// is not triggered when they're not valid, the outcome is reported with the TLSValid condition.
+	TLSValidation datastore.TLSValidation
 	// TenantControlPlaneTrigger is the channel used to communicate across the controllers:
 	// if a Data Source is updated we have to be sure that the reconciliation of the certificates content
 	// for each Tenant Control Plane is put in place properly.
 	TenantControlPlaneTrigger TenantControlPlaneChannel
+	// TriggerRateLimiter throttles the Tenant Control Planes triggers, shared across the DataStores reconciliations
+	// to avoid a thundering herd upon the changes of widely-shared DataStores: when nil, the triggers are not throttled.
+	TriggerRateLimiter *rate.Limiter
+	// TriggerTimeout bounds each send to the TenantControlPlaneTrigger channel: once expired, the remaining triggers
+	// are dispatched upon the requeue, releasing the worker. A zero value waits for the channel to be consumed.
+	TriggerTimeout time.Duration
+	// MaxConcurrentReconciles is the number of workers of the DataStore controller: the reconciliations are safe under concurrency,
+	// since the status updates rely on the optimistic locking, and the shared state such as the backoff is synchronised.
+	MaxConcurrentReconciles int
+	// HealthCheckInterval is the interval the connection to the data store is checked at:
+	// a zero value checks it upon the DataStore changes only.
+	HealthCheckInterval time.Duration
+	// ReconcileTimeout bounds the reconciliation, including the Secrets retrieval, the connection check,
+	// and the Tenant Control Planes triggers: a zero value doesn't bound it.
+	ReconcileTimeout time.Duration
+
+	// DefaultDataStoreName is the DataStore used by the Tenant Control Planes with no explicit one:
+	// the DefaultDataStoreReadyz check fails until it has been successfully validated once.
+	DefaultDataStoreName string
+
+	notFoundBackoff dataStoreBackoff
+	triggers        dataStoreTriggers
+	// defaultValidated is set upon the first successful validation of the default DataStore.
+	defaultValidated atomic.Bool
+	// defaultConnectionErr is the outcome of the last connection check of the default DataStore, cached for the readiness check.
+	defaultConnectionErr atomic.Pointer[error]
+	// DryRun performs the validations and computes the conditions, without persisting any change:
+	// the writes are submitted in dry-run mode, and the Tenant Control Planes are not triggered.
+	DryRun bool
+	// TrackUsage reports the Tenant Control Planes using the DataStore in its status, guarding its deletion with a finalizer:
+	// when disabled, the status and the finalizer are not updated upon the Tenant Control Planes changes.
+	TrackUsage bool
+
+	clock clock.Clock
+}
+
+var dataStoreTenantControlPlanesGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "kamaji_datastore_tenant_control_planes",
+	Help: "Number of Tenant Control Planes using the DataStore.",
+}, []string{"datastore"})
+
+// dataStoreHealthCheckTimeout bounds the connection check, avoiding to block the reconciliation on unreachable endpoints.
+const dataStoreHealthCheckTimeout = 5 * time.Second
+
+// dataStoreValidationTimeMinRefresh is the minimum interval the last validation time is refreshed at.
+const dataStoreValidationTimeMinRefresh = time.Minute
+
+const (
+	// dataStoreNotFoundBackoffBase is the initial requeue delay of a DataStore referencing missing Secrets, doubling on each failure.
+	dataStoreNotFoundBackoffBase = 5 * time.Second
+	// dataStoreNotFoundBackoffMax caps the requeue delay of a DataStore referencing missing Secrets.
+	dataStoreNotFoundBackoffMax = 5 * time.Minute
+)
+
+// dataStoreBackoff tracks the consecutive failures of the DataStores, computing a capped exponential backoff with jitter:
+// it's shared across the concurrent reconciliations, and kept in memory only.
+type dataStoreBackoff struct {
+	mu       sync.Mutex
+	failures map[string]int
+}
+
+// next records a failure of the given DataStore, returning the delay before checking it again.
+func (b *dataStoreBackoff) next(name string) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures == nil {
+		b.failures = make(map[string]int)
+	}
+
+	delay := dataStoreNotFoundBackoffBase << b.failures[name]
+	if delay <= 0 || delay > dataStoreNotFoundBackoffMax {
+		delay = dataStoreNotFoundBackoffMax
+	} else {
+		b.failures[name]++
+	}
+
+	return wait.Jitter(delay, 0.1)
+}
+
+// reset forgets the failures of the given DataStore.
+func (b *dataStoreBackoff) reset(name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.failures, name)
+}
+
+// dataStoreTriggers tracks the fingerprint of the DataStores the Tenant Control Planes have been last triggered with:
+// the periodic health checks don't trigger them again, unless the DataStore, its Secrets, or its health, changed.
+// It's shared across the concurrent reconciliations, and kept in memory only, thus a restart triggers them once.
+type dataStoreTriggers struct {
+	mu           sync.Mutex
+	fingerprints map[string]string
+	// progress tracks the Tenant Control Planes already triggered with a fingerprint not recorded yet,
+	// letting the triggers interrupted by the reconcile timeout, or by the trigger one, resume rather than restart.
+	progress map[string]dataStoreTriggersProgress
+}
+
+type dataStoreTriggersProgress struct {
+	fingerprint string
+	triggered   sets.Set[string]
+}
+
+// changed returns true if the given fingerprint differs from the one the Tenant Control Planes have been last triggered with.
+func (t *dataStoreTriggers) changed(name, fingerprint string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	last, found := t.fingerprints[name]
+
+	return !found || last != fingerprint
+}
+
+// record stores the fingerprint the Tenant Control Planes of the given DataStore have been triggered with.
+func (t *dataStoreTriggers) record(name, fingerprint string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.fingerprints == nil {
+		t.fingerprints = make(map[string]string)
+	}
+
+	t.fingerprints[name] = fingerprint
+	delete(t.progress, name)
+}
+
+// pending returns the given Tenant Control Planes not triggered yet with the given fingerprint.
+func (t *dataStoreTriggers) pending(name, fingerprint string, tcps []kamajiv1alpha1.TenantControlPlane) []kamajiv1alpha1.TenantControlPlane {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	progress, found := t.progress[name]
+	if !found || progress.fingerprint != fingerprint {
+		return tcps
+	}
+
+	pending := make([]kamajiv1alpha1.TenantControlPlane, 0, len(tcps))
+
+	for _, tcp := range tcps {
+		if !progress.triggered.Has(getNamespacedName(tcp.GetNamespace(), tcp.GetName()).String()) {
+			pending = append(pending, tcp)
+		}
+	}
+
+	return pending
+}
+
+// triggered records the given Tenant Control Plane has been triggered with the given fingerprint.
+func (t *dataStoreTriggers) triggered(name, fingerprint string, tcp *kamajiv1alpha1.TenantControlPlane) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.progress == nil {
+		t.progress = make(map[string]dataStoreTriggersProgress)
+	}
+
+	progress, found := t.progress[name]
+	if !found || progress.fingerprint != fingerprint {
+		progress = dataStoreTriggersProgress{fingerprint: fingerprint, triggered: sets.New[string]()}
+		t.progress[name] = progress
+	}
+
+	progress.triggered.Insert(getNamespacedName(tcp.GetNamespace(), tcp.GetName()).String())
+}
+
+// forget drops the fingerprint, and the progress, of the given DataStore.
+func (t *dataStoreTriggers) forget(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.fingerprints, name)
+	delete(t.progress, name)
 }
 
 //+kubebuilder:rbac:groups=kamaji.clastix.io,resources=datastores,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=kamaji.clastix.io,resources=datastores/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=datastores/finalizers,verbs=update
 
-func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	log := log.FromContext(ctx)
+func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (_ reconcile.Result, err error) {
+	log := log.FromContext(ctx).WithValues("datastore", request.Name)
+	// Propagating the logger values to the helpers, and to the DataStore connection.
+	ctx = logr.NewContext(ctx, log)
+
+	if r.ReconcileTimeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, r.ReconcileTimeout)
+		defer cancelFn()
+	}
 
 	ds := &kamajiv1alpha1.DataStore{}
-	if err := r.Client.Get(ctx, request.NamespacedName, ds); err != nil {
+	if err = r.Client.Get(ctx, request.NamespacedName, ds); err != nil {
 		if k8serrors.IsNotFound(err) {
+			dataStoreTenantControlPlanesGauge.DeleteLabelValues(request.Name)
+			r.notFoundBackoff.reset(request.Name)
+			r.triggers.forget(request.Name)
+
 			return reconcile.Result{}, nil
 		}
 
@@ -48,40 +263,615 @@ func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (r
 
 		return reconcile.Result{}, err
 	}
+	// The calls withdrawn by the timeout could hide it behind their own errors, or be ignored such as the connection check:
+	// reporting it, and returning the context error to let the request be retried.
+	defer func() {
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return
+		}
+
+		r.EventRecorder.Eventf(ds, corev1.EventTypeWarning, "ReconcileTimeout", "the reconciliation has not been completed within %s", r.ReconcileTimeout.String())
+
+		if err == nil {
+			err = ctx.Err()
+		}
+	}()
+
+	// The stored status is tracked to skip the no-op updates.
+	storedStatus := ds.Status.DeepCopy()
+
+	var tcps []kamajiv1alpha1.TenantControlPlane
+
+	if r.TrackUsage {
+		var err error
+
+		if tcps, err = r.tenantControlPlanes(ctx, ds); err != nil {
+			log.Error(err, "cannot retrieve list of the Tenant Control Plane using the following instance")
+
+			return reconcile.Result{}, err
+		}
+		// Updating the status with the list of Tenant Control Plane using the following Data Source
+		tcpSets := sets.NewString()
+		for _, tcp := range tcps {
+			tcpSets.Insert(getNamespacedName(tcp.GetNamespace(), tcp.GetName()).String())
+		}
+
+		ds.Status.UsedBy = tcpSets.List()
+		dataStoreTenantControlPlanesGauge.WithLabelValues(ds.GetName()).Set(float64(len(ds.Status.UsedBy)))
+	} else {
+		ds.Status.UsedBy = nil
+		// With no usage tracking, the series is dropped rather than reporting no Tenant Control Planes.
+		dataStoreTenantControlPlanesGauge.DeleteLabelValues(ds.GetName())
+	}
+
+	if ds.GetDeletionTimestamp() != nil {
+		return r.handleDeletion(ctx, ds)
+	}
+	// With no usage tracking, the in-use finalizer is left untouched, since the usage is unknown.
+	if r.TrackUsage {
+		if err := r.syncFinalizer(ctx, ds); err != nil {
+			log.Error(err, "cannot update the finalizer for the given instance")
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	// The Secrets referenced by the DataStore are read once, rather than for each content reference.
+	contentClient := datastore.NewContentClient(r.Client)
+
+	contentErr := r.validateContent(ctx, contentClient, ds)
+	tlsErr := r.validateTLS(ctx, contentClient, ds)
+	// The next client certificate doesn't block the reconciliation, since the current one is still used.
+	r.validateNextClientCertificate(ctx, contentClient, ds)
+	// The connection is checked with valid content only, since it's required to build the client.
+	if contentErr == nil {
+		r.checkConnection(ctx, contentClient, ds)
+	}
+
+	if contentErr == nil && tlsErr == nil && meta.IsStatusConditionTrue(ds.Status.Conditions, kamajiv1alpha1.DataStoreConnectionHealthyCondition) {
+		r.refreshValidationTime(ds)
+	}
+
+	if !equality.Semantic.DeepEqual(*storedStatus, ds.Status) {
+		if err := r.Client.Status().Update(ctx, ds); err != nil {
+			log.Error(err, "cannot update the status for the given instance")
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	if contentErr != nil {
+		// The referenced Secrets could be created later: rather than a hot loop, the DataStore is checked again with a backoff,
+		// although the Secrets changes trigger its reconciliation too.
+		if k8serrors.IsNotFound(contentErr) {
+			requeueAfter := r.notFoundBackoff.next(ds.GetName())
+
+			log.Info("the TLS content is not available yet, skipping the Tenant Control Planes reconciliation", "reason", contentErr.Error(), "requeueAfter", requeueAfter.String())
+
+			return reconcile.Result{RequeueAfter: requeueAfter}, nil
+		}
+
+		log.Error(contentErr, "the TLS content is not valid, skipping the Tenant Control Planes reconciliation")
+
+		return reconcile.Result{}, contentErr
+	}
+
+	r.notFoundBackoff.reset(ds.GetName())
+
+	if tlsErr != nil {
+		log.Info("the TLS configuration is not valid, skipping the Tenant Control Planes reconciliation", "reason", tlsErr.Error())
+
+		return reconcile.Result{RequeueAfter: r.HealthCheckInterval}, nil
+	}
+
+	if ds.GetName() == r.DefaultDataStoreName && !r.defaultValidated.Swap(true) {
+		log.Info("the default DataStore has been validated")
+	}
+
+	log.V(1).Info("the TLS content is valid")
+	// The Tenant Control Planes using the DataStore are triggered only upon the changes of the DataStore, of its Secrets,
+	// or of its health, rather than upon each periodic health check.
+	fingerprint, err := r.fingerprint(ctx, contentClient, ds)
+	if err != nil {
+		log.Error(err, "cannot compute the fingerprint of the following instance")
+
+		return reconcile.Result{}, err
+	}
+
+	switch {
+	case !r.triggers.changed(ds.GetName(), fingerprint):
+		tcps = nil
+	case !r.TrackUsage:
+		// With no usage tracking, the Tenant Control Planes using the DataStore are retrieved for the triggers only.
+		if tcps, err = r.tenantControlPlanes(ctx, ds); err != nil {
+			log.Error(err, "cannot retrieve list of the Tenant Control Plane using the following instance")
+
+			return reconcile.Result{}, err
+		}
+	}
+	// The Tenant Control Planes waiting for the DataStore creation are triggered along with the ones using it.
+	waiting, err := r.waitingTenantControlPlanes(ctx, ds)
+	if err != nil {
+		log.Error(err, "cannot retrieve list of the Tenant Control Plane waiting for the following instance")
+
+		return reconcile.Result{}, err
+	}
+
+	if len(tcps) == 0 && len(waiting) == 0 {
+		r.triggers.record(ds.GetName(), fingerprint)
+
+		log.V(1).Info("no Tenant Control Planes to be triggered")
+
+		return reconcile.Result{RequeueAfter: r.HealthCheckInterval}, nil
+	}
+
+	triggered := make([]kamajiv1alpha1.TenantControlPlane, 0, len(tcps)+len(waiting))
+	triggered = append(triggered, tcps...)
+	triggered = append(triggered, waiting...)
+
+	if r.DryRun {
+		log.Info("dry-run mode, skipping the Tenant Control Planes triggers", "tenantControlPlanes", ds.Status.UsedBy, "waiting", len(waiting))
+
+		return reconcile.Result{RequeueAfter: r.HealthCheckInterval}, nil
+	}
+
+	return r.dispatch(ctx, ds, fingerprint, triggered)
+}
+
+// dispatch triggers the reconciliation of the given Tenant Control Planes, recording the fingerprint once all of them
+// have been triggered: the send is aborted upon the shutdown, since the channel could be no more consumed.
+// The triggers are throttled in the listing order, which is preserved: the ones interrupted by the reconcile timeout,
+// or by the trigger one, are resumed upon the retry, skipping the Tenant Control Planes already triggered.
+func (r *DataStore) dispatch(ctx context.Context, ds *kamajiv1alpha1.DataStore, fingerprint string, tcps []kamajiv1alpha1.TenantControlPlane) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	pending := r.triggers.pending(ds.GetName(), fingerprint, tcps)
+
+	for index, i := range pending {
+		tcp := i
+
+		if r.TriggerRateLimiter != nil {
+			if err := r.TriggerRateLimiter.Wait(ctx); err != nil {
+				log.Info("the reconciliation has been cancelled, resuming the remaining Tenant Control Planes triggers upon the retry", "reason", err.Error(), "remaining", len(pending)-index)
+
+				return reconcile.Result{}, err
+			}
+		}
+
+		sent, triggerErr := r.trigger(ctx, &tcp)
+		if triggerErr != nil {
+			log.Info("the reconciliation has been cancelled, resuming the remaining Tenant Control Planes triggers upon the retry", "remaining", len(pending)-index)
+
+			return reconcile.Result{}, triggerErr
+		}
+
+		if !sent {
+			log.Info("the Tenant Control Planes triggers are not consumed, requeueing the remaining ones", "remaining", len(pending)-index, "timeout", r.TriggerTimeout.String())
+
+			return reconcile.Result{Requeue: true}, nil
+		}
+
+		r.triggers.triggered(ds.GetName(), fingerprint, &tcp)
+	}
+
+	r.triggers.record(ds.GetName(), fingerprint)
+
+	log.Info("triggered the reconciliation of the Tenant Control Planes", "count", len(pending), "total", len(tcps))
+
+	return reconcile.Result{RequeueAfter: r.HealthCheckInterval}, nil
+}
+
+// fingerprint returns the state of the given DataStore the Tenant Control Planes depend on: its generation,
+// the resource versions of the referenced Secrets, the digest of the referenced files, and its connection health.
+// The files are hashed since their rotation, such as by a CSI secret store driver, doesn't change the DataStore.
+func (r *DataStore) fingerprint(ctx context.Context, contentClient client.Client, ds *kamajiv1alpha1.DataStore) (string, error) {
+	refs := (&kamajiv1alpha1.DatastoreUsedSecret{}).ExtractValue()(ds)
+	slices.Sort(refs)
+
+	versions := make([]string, 0, len(refs))
+
+	for _, ref := range slices.Compact(refs) {
+		namespace, name, _ := strings.Cut(ref, "/")
+
+		secret := &corev1.Secret{}
+		// The missing Secrets, such as the ones of a pending next client certificate, are tracked with no version:
+		// their creation changes the fingerprint.
+		if err := contentClient.Get(ctx, k8stypes.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil && !k8serrors.IsNotFound(err) {
+			return "", err
+		}
+
+		versions = append(versions, ref+"@"+secret.GetResourceVersion())
+	}
+
+	files := sha256.New()
 
+	for _, ref := range dataStoreContentRefs(ds) {
+		// The bare content has precedence over the file path, and it's tracked by the generation.
+		if len(ref.Content) > 0 || len(ref.FilePath) == 0 {
+			continue
+		}
+
+		// The unreadable files are tracked as such, since the ones of the next client certificate could be not mounted yet.
+		content, err := ref.GetContent(ctx, contentClient)
+		if err != nil {
+			_, _ = fmt.Fprintf(files, "%s:unavailable;", ref.FilePath)
+
+			continue
+		}
+
+		_, _ = fmt.Fprintf(files, "%s:%x;", ref.FilePath, sha256.Sum256(content))
+	}
+
+	healthy := meta.IsStatusConditionTrue(ds.Status.Conditions, kamajiv1alpha1.DataStoreConnectionHealthyCondition)
+
+	return fmt.Sprintf("generation=%d,secrets=%s,files=%x,healthy=%t", ds.GetGeneration(), strings.Join(versions, ";"), files.Sum(nil), healthy), nil
+}
+
+// dataStoreContentRefs returns the content references of the given DataStore, in a stable order.
+func dataStoreContentRefs(ds *kamajiv1alpha1.DataStore) []kamajiv1alpha1.ContentRef {
+	tlsConfig := ds.Spec.TLSConfig
+
+	refs := []kamajiv1alpha1.ContentRef{tlsConfig.CertificateAuthority.Certificate, tlsConfig.ClientCertificate.Certificate, tlsConfig.ClientCertificate.PrivateKey}
+
+	if tlsConfig.CertificateAuthority.PrivateKey != nil {
+		refs = append(refs, *tlsConfig.CertificateAuthority.PrivateKey)
+	}
+
+	if next := tlsConfig.ClientCertificate.Next; next != nil {
+		refs = append(refs, next.Certificate, next.PrivateKey)
+	}
+
+	if auth := ds.Spec.BasicAuth; auth != nil {
+		refs = append(refs, auth.Username, auth.Password)
+	}
+
+	return refs
+}
+
+// trigger sends the given Tenant Control Plane to the TenantControlPlaneTrigger channel, returning false when the send
+// has not been completed within the trigger timeout, or the context error when the reconciliation has been cancelled.
+func (r *DataStore) trigger(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	var timeout <-chan time.Time
+
+	if r.TriggerTimeout > 0 {
+		timer := time.NewTimer(r.TriggerTimeout)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	select {
+	case r.TenantControlPlaneTrigger <- event.GenericEvent{Object: tcp}:
+		return true, nil
+	case <-timeout:
+		return false, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// DefaultDataStoreReadyz returns a check failing until the default DataStore has been successfully validated once,
+// or when its last connection check failed: the outcome of the periodic check is reused, avoiding to probe the DataStore
+// upon each request. Gating the Pod readiness is opt-in, since the webhooks required to fix the DataStore would be unavailable.
+// The replicas not elected as leader don't run the controller, thus they're reported healthy.
+func (r *DataStore) DefaultDataStoreReadyz(elected <-chan struct{}) healthz.Checker {
+	return func(*http.Request) error {
+		select {
+		case <-elected:
+		default:
+			return nil
+		}
+
+		if !r.defaultValidated.Load() {
+			return fmt.Errorf("the default DataStore %s has not been validated yet", r.DefaultDataStoreName)
+		}
+
+		if err := r.defaultConnectionErr.Load(); err != nil && *err != nil {
+			return fmt.Errorf("the default DataStore %s is not reachable: %w", r.DefaultDataStoreName, *err)
+		}
+
+		return nil
+	}
+}
+
+// tenantControlPlanes returns the Tenant Control Planes using the given DataStore: they're retrieved from the cache
+// through the TenantControlPlaneStatusDataStore indexer, thus the ones using other DataStores are not fetched.
+func (r *DataStore) tenantControlPlanes(ctx context.Context, ds *kamajiv1alpha1.DataStore) ([]kamajiv1alpha1.TenantControlPlane, error) {
 	tcpList := kamajiv1alpha1.TenantControlPlaneList{}
 
 	if err := r.Client.List(ctx, &tcpList, client.MatchingFieldsSelector{
 		Selector: fields.OneTermEqualSelector(kamajiv1alpha1.TenantControlPlaneUsedDataStoreKey, ds.GetName()),
 	}); err != nil {
-		log.Error(err, "cannot retrieve list of the Tenant Control Plane using the following instance")
+		return nil, err
+	}
 
-		return reconcile.Result{}, err
+	return tcpList.Items, nil
+}
+
+// waitingTenantControlPlanes returns the Tenant Control Planes requesting the given DataStore,
+// whose reconciliation has been halted since it was not found: they're retrieved through the TenantControlPlaneSpecDataStore indexer.
+func (r *DataStore) waitingTenantControlPlanes(ctx context.Context, ds *kamajiv1alpha1.DataStore) ([]kamajiv1alpha1.TenantControlPlane, error) {
+	tcpList := kamajiv1alpha1.TenantControlPlaneList{}
+
+	if err := r.Client.List(ctx, &tcpList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(kamajiv1alpha1.TenantControlPlaneSpecDataStoreKey, ds.GetName()),
+	}); err != nil {
+		return nil, err
 	}
-	// Updating the status with the list of Tenant Control Plane using the following Data Source
-	tcpSets := sets.NewString()
+
+	var waiting []kamajiv1alpha1.TenantControlPlane
+
 	for _, tcp := range tcpList.Items {
-		tcpSets.Insert(getNamespacedName(tcp.GetNamespace(), tcp.GetName()).String())
+		if meta.IsStatusConditionTrue(tcp.Status.Conditions, kamajiv1alpha1.DataStoreNotFoundCondition) {
+			waiting = append(waiting, tcp)
+		}
+	}
+
+	return waiting, nil
+}
+
+// checkConnection sets the ConnectionHealthy condition according to the outcome of the connection check
+// to the data store endpoints, emitting an event upon its transitions.
+func (r *DataStore) checkConnection(ctx context.Context, contentClient client.Client, ds *kamajiv1alpha1.DataStore) {
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.DataStoreConnectionHealthyCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Connected",
+		Message:            "the data store endpoints are reachable",
+		ObservedGeneration: ds.GetGeneration(),
+	}
+
+	checkCtx, cancelFn := context.WithTimeout(ctx, dataStoreHealthCheckTimeout)
+	defer cancelFn()
+
+	err := func() error {
+		connection, err := datastore.NewStorageConnection(checkCtx, contentClient, *ds)
+		if err != nil {
+			return err
+		}
+		defer connection.Close()
+
+		return connection.Check(checkCtx)
+	}()
+	if err != nil {
+		log.FromContext(ctx).Info("the data store endpoints are not reachable", "reason", err.Error())
+
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ConnectionFailed"
+		condition.Message = err.Error()
+	}
+
+	current := meta.FindStatusCondition(ds.Status.Conditions, condition.Type)
+
+	switch {
+	case err != nil && (current == nil || current.Status != metav1.ConditionFalse):
+		r.EventRecorder.Event(ds, corev1.EventTypeWarning, "ConnectionFailed", condition.Message)
+	case err == nil && current != nil && current.Status == metav1.ConditionFalse:
+		r.EventRecorder.Event(ds, corev1.EventTypeNormal, "ConnectionRestored", condition.Message)
+	}
+
+	if ds.GetName() == r.DefaultDataStoreName {
+		r.defaultConnectionErr.Store(&err)
+	}
+
+	meta.SetStatusCondition(&ds.Status.Conditions, condition)
+}
+
+// refreshValidationTime records the successful validation of the given DataStore: since the status update triggers
+// the reconciliation again, the time is refreshed at most once per health check interval, rather than upon each reconciliation.
+func (r *DataStore) refreshValidationTime(ds *kamajiv1alpha1.DataStore) {
+	interval := max(r.HealthCheckInterval, dataStoreValidationTimeMinRefresh)
+
+	if last := ds.Status.LastValidationTime; last != nil && r.clock.Since(last.Time) < interval {
+		return
+	}
+
+	now := metav1.NewTime(r.clock.Now())
+	ds.Status.LastValidationTime = &now
+}
+
+// syncFinalizer adds the in-use finalizer to the given DataStore when used by Tenant Control Planes,
+// removing it otherwise.
+func (r *DataStore) syncFinalizer(ctx context.Context, ds *kamajiv1alpha1.DataStore) error {
+	inUse := len(ds.Status.UsedBy) > 0
+	// The DataStore is patched only upon the finalizer changes.
+	if inUse == controllerutil.ContainsFinalizer(ds, finalizers.DataStoreInUseFinalizer) {
+		return nil
+	}
+
+	return r.patchFinalizer(ctx, ds, inUse)
+}
+
+// patchFinalizer adds, or removes, the in-use finalizer of the given DataStore with a patch,
+// rather than an update, to avoid the conflicts with the concurrent changes of the DataStore.
+func (r *DataStore) patchFinalizer(ctx context.Context, ds *kamajiv1alpha1.DataStore, add bool) error {
+	original := ds.DeepCopy()
+
+	if add {
+		controllerutil.AddFinalizer(ds, finalizers.DataStoreInUseFinalizer)
+	} else {
+		controllerutil.RemoveFinalizer(ds, finalizers.DataStoreInUseFinalizer)
 	}
 
-	ds.Status.UsedBy = tcpSets.List()
+	status := ds.Status.DeepCopy()
+
+	if err := r.Client.Patch(ctx, ds, client.MergeFrom(original)); err != nil {
+		return err
+	}
+	// The patch is overwriting the status with the stored one.
+	ds.Status = *status
+
+	return nil
+}
+
+// handleDeletion blocks the deletion of the given DataStore as long as it's used by Tenant Control Planes,
+// which are still requiring it to store their data: with no usage tracking, the deletion is not blocked.
+func (r *DataStore) handleDeletion(ctx context.Context, ds *kamajiv1alpha1.DataStore) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	if !r.TrackUsage || len(ds.Status.UsedBy) == 0 {
+		if controllerutil.ContainsFinalizer(ds, finalizers.DataStoreInUseFinalizer) {
+			if err := r.patchFinalizer(ctx, ds, false); err != nil {
+				log.Error(err, "cannot remove the finalizer for the given instance")
+
+				return reconcile.Result{}, err
+			}
+		}
+
+		return reconcile.Result{}, nil
+	}
 
 	if err := r.Client.Status().Update(ctx, ds); err != nil {
 		log.Error(err, "cannot update the status for the given instance")
 
 		return reconcile.Result{}, err
 	}
-	// Triggering the reconciliation of the Tenant Control Plane upon a Secret change
-	for _, i := range tcpList.Items {
-		tcp := i
 
-		r.TenantControlPlaneTrigger <- event.GenericEvent{Object: &tcp}
+	log.Info("the DataStore is still in use, deletion is blocked", "usedBy", ds.Status.UsedBy)
+
+	r.EventRecorder.Eventf(ds, corev1.EventTypeWarning, "DataStoreInUse",
+		"the DataStore cannot be deleted since still used by the Tenant Control Planes: %s", strings.Join(ds.Status.UsedBy, ", "))
+	// The Tenant Control Planes changes are enqueuing the DataStore too, the requeue is a safety net.
+	return reconcile.Result{RequeueAfter: r.HealthCheckInterval}, nil
+}
+
+// validateContent sets the Ready condition according to the TLS content resolution, emitting a Warning event
+// describing the failure, and a Normal one once the content is valid again.
+func (r *DataStore) validateContent(ctx context.Context, contentClient client.Client, ds *kamajiv1alpha1.DataStore) error {
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.DataStoreReadyCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ContentValid",
+		Message:            "the TLS content of the DataStore is valid",
+		ObservedGeneration: ds.GetGeneration(),
 	}
 
-	return reconcile.Result{}, nil
+	_, err := datastore.ValidateContent(ctx, datastore.ClientContentResolver(contentClient), *ds)
+
+	var contentErr datastore.ContentError
+	if errors.As(err, &contentErr) {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = contentErr.ConditionReason
+		condition.Message = contentErr.Error()
+	}
+
+	current := meta.FindStatusCondition(ds.Status.Conditions, condition.Type)
+
+	switch {
+	case err != nil && (current == nil || current.Message != condition.Message):
+		r.EventRecorder.Event(ds, corev1.EventTypeWarning, contentErr.EventReason, condition.Message)
+	case err == nil && current != nil && current.Status == metav1.ConditionFalse:
+		r.EventRecorder.Event(ds, corev1.EventTypeNormal, "ValidationSucceeded", condition.Message)
+	}
+
+	meta.SetStatusCondition(&ds.Status.Conditions, condition)
+
+	return err
 }
 
-func (r *DataStore) SetupWithManager(mgr controllerruntime.Manager) error {
+// validateNextClientCertificate sets the NextClientCertificateValid condition when the next client certificate is specified,
+// emitting a Warning event describing the failure, and a Normal one once it's valid again: the next client certificate
+// is presented only when valid, thus the current one is still used rather than failing the reconciliation.
+func (r *DataStore) validateNextClientCertificate(ctx context.Context, contentClient client.Client, ds *kamajiv1alpha1.DataStore) {
+	next := ds.Spec.TLSConfig.ClientCertificate.Next
+	if next == nil {
+		meta.RemoveStatusCondition(&ds.Status.Conditions, kamajiv1alpha1.DataStoreNextClientCertificateValidCondition)
+
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.DataStoreNextClientCertificateValidCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Valid",
+		Message:            "the next client certificate is valid",
+		ObservedGeneration: ds.GetGeneration(),
+	}
+
+	_, _, err := datastore.ValidateNextClientCertificate(ctx, contentClient, *next)
+	if err != nil {
+		log.FromContext(ctx).Info("the next client certificate is not valid, the current one is still used", "reason", err.Error())
+
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Invalid"
+		condition.Message = err.Error()
+	}
+
+	current := meta.FindStatusCondition(ds.Status.Conditions, condition.Type)
+
+	switch {
+	case err != nil && (current == nil || current.Message != condition.Message):
+		r.EventRecorder.Event(ds, corev1.EventTypeWarning, "InvalidNextClientCertificate", condition.Message)
+	case err == nil && current != nil && current.Status == metav1.ConditionFalse:
+		r.EventRecorder.Event(ds, corev1.EventTypeNormal, "ValidNextClientCertificate", condition.Message)
+	}
+
+	meta.SetStatusCondition(&ds.Status.Conditions, condition)
+}
+
+// validateTLS sets the TLSValid condition according to the TLS validation outcome, emitting an event upon its transitions.
+func (r *DataStore) validateTLS(ctx context.Context, contentClient client.Client, ds *kamajiv1alpha1.DataStore) error {
+	if r.TLSValidation.Mode != datastore.TLSValidationModeStrict {
+		meta.RemoveStatusCondition(&ds.Status.Conditions, kamajiv1alpha1.DataStoreTLSValidCondition)
+
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.DataStoreTLSValidCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Verified",
+		Message:            "the client certificates chain up to the Certificate Authority",
+		ObservedGeneration: ds.GetGeneration(),
+	}
+
+	err := r.TLSValidation.Verify(ctx, contentClient, *ds)
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "VerificationFailed"
+		condition.Message = err.Error()
+	}
+
+	current := meta.FindStatusCondition(ds.Status.Conditions, condition.Type)
+
+	switch {
+	case err != nil && (current == nil || current.Message != condition.Message):
+		r.EventRecorder.Event(ds, corev1.EventTypeWarning, "InvalidTLSConfiguration", condition.Message)
+	case err == nil && current != nil && current.Status == metav1.ConditionFalse:
+		r.EventRecorder.Event(ds, corev1.EventTypeNormal, "ValidTLSConfiguration", condition.Message)
+	}
+
+	meta.SetStatusCondition(&ds.Status.Conditions, condition)
+
+	return err
+}
+
+// dataStoresForSecret maps the Secret changes, such as the rotation of the TLS material, to the DataStores referencing it
+// rather than to all of them: the references of the TLS configuration, and of the basic authentication,
+// are resolved by the DatastoreUsedSecret indexer. The revalidated DataStores trigger in turn the reconciliation
+// of the Tenant Control Planes using them.
+func (r *DataStore) dataStoresForSecret(ctx context.Context, object client.Object) []reconcile.Request {
+	dsList := kamajiv1alpha1.DataStoreList{}
+
+	if err := r.Client.List(ctx, &dsList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(kamajiv1alpha1.DatastoreUsedSecretNamespacedNameKey, getNamespacedName(object.GetNamespace(), object.GetName()).String()),
+	}); err != nil {
+		log.FromContext(ctx).Error(err, "cannot retrieve the DataStores referencing the Secret", "secret", getNamespacedName(object.GetNamespace(), object.GetName()).String())
+
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(dsList.Items))
+	for _, ds := range dsList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: ds.GetName()}})
+	}
+
+	return requests
+}
+
+// tenantControlPlaneDataStoreHandler enqueues the DataStore used by the Tenant Control Plane events: upon the updates,
+// both the previous and the current DataStore are enqueued, thus the usage is updated on both sides of a migration.
+func tenantControlPlaneDataStoreHandler() handler.EventHandler {
 	enqueueFn := func(tcp *kamajiv1alpha1.TenantControlPlane, limitingInterface workqueue.RateLimitingInterface) {
 		if dataStoreName := tcp.Status.Storage.DataStoreName; len(dataStoreName) > 0 {
 			limitingInterface.AddRateLimited(reconcile.Request{
@@ -92,21 +882,39 @@ func (r *DataStore) SetupWithManager(mgr controllerruntime.Manager) error {
 		}
 	}
 	//nolint:forcetypeassert
+	return handler.Funcs{
+		CreateFunc: func(_ context.Context, createEvent event.CreateEvent, limitingInterface workqueue.RateLimitingInterface) {
+			enqueueFn(createEvent.Object.(*kamajiv1alpha1.TenantControlPlane), limitingInterface)
+		},
+		UpdateFunc: func(_ context.Context, updateEvent event.UpdateEvent, limitingInterface workqueue.RateLimitingInterface) {
+			enqueueFn(updateEvent.ObjectOld.(*kamajiv1alpha1.TenantControlPlane), limitingInterface)
+			enqueueFn(updateEvent.ObjectNew.(*kamajiv1alpha1.TenantControlPlane), limitingInterface)
+		},
+		DeleteFunc: func(_ context.Context, deleteEvent event.DeleteEvent, limitingInterface workqueue.RateLimitingInterface) {
+			enqueueFn(deleteEvent.Object.(*kamajiv1alpha1.TenantControlPlane), limitingInterface)
+		},
+	}
+}
+
+func (r *DataStore) SetupWithManager(mgr controllerruntime.Manager) error {
+	metrics.Registry.MustRegister(dataStoreTenantControlPlanesGauge)
+
+	r.clock = clock.RealClock{}
+
+	if r.DryRun {
+		r.Client = client.NewDryRunClient(r.Client)
+	}
+
 	return controllerruntime.NewControllerManagedBy(mgr).
 		For(&kamajiv1alpha1.DataStore{}, builder.WithPredicates(
 			predicate.ResourceVersionChangedPredicate{},
 		)).
-		WatchesRawSource(source.Kind(mgr.GetCache(), &kamajiv1alpha1.TenantControlPlane{}), handler.Funcs{
-			CreateFunc: func(_ context.Context, createEvent event.CreateEvent, limitingInterface workqueue.RateLimitingInterface) {
-				enqueueFn(createEvent.Object.(*kamajiv1alpha1.TenantControlPlane), limitingInterface)
-			},
-			UpdateFunc: func(_ context.Context, updateEvent event.UpdateEvent, limitingInterface workqueue.RateLimitingInterface) {
-				enqueueFn(updateEvent.ObjectOld.(*kamajiv1alpha1.TenantControlPlane), limitingInterface)
-				enqueueFn(updateEvent.ObjectNew.(*kamajiv1alpha1.TenantControlPlane), limitingInterface)
-			},
-			DeleteFunc: func(_ context.Context, deleteEvent event.DeleteEvent, limitingInterface workqueue.RateLimitingInterface) {
-				enqueueFn(deleteEvent.Object.(*kamajiv1alpha1.TenantControlPlane), limitingInterface)
-			},
+		WatchesRawSource(source.Kind(mgr.GetCache(), &corev1.Secret{}), handler.EnqueueRequestsFromMapFunc(r.dataStoresForSecret), builder.WithPredicates(
+			predicate.ResourceVersionChangedPredicate{},
+		)).
+		WatchesRawSource(source.Kind(mgr.GetCache(), &kamajiv1alpha1.TenantControlPlane{}), tenantControlPlaneDataStoreHandler()).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
 		}).
 		Complete(r)
 }
@@ -0,0 +1,1198 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/finalizers"
+)
+
+func TestDataStoreBackoff(t *testing.T) {
+	// The delays are jittered up to the 10% of the computed value.
+	inRange := func(t *testing.T, got, want time.Duration) {
+		t.Helper()
+
+		if got < want || got > want+want/10 {
+			t.Fatalf("expected a delay within [%s, %s], got %s", want, want+want/10, got)
+		}
+	}
+
+	t.Run("base", func(t *testing.T) {
+		var b dataStoreBackoff
+
+		inRange(t, b.next("default"), dataStoreNotFoundBackoffBase)
+	})
+
+	t.Run("doubling", func(t *testing.T) {
+		var b dataStoreBackoff
+
+		for _, want := range []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second} {
+			inRange(t, b.next("default"), want)
+		}
+	})
+
+	t.Run("cap", func(t *testing.T) {
+		var b dataStoreBackoff
+
+		for i := 0; i < 64; i++ {
+			b.next("default")
+		}
+
+		inRange(t, b.next("default"), dataStoreNotFoundBackoffMax)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		var b dataStoreBackoff
+
+		b.next("default")
+		b.next("default")
+		b.reset("default")
+
+		inRange(t, b.next("default"), dataStoreNotFoundBackoffBase)
+	})
+
+	t.Run("per DataStore", func(t *testing.T) {
+		var b dataStoreBackoff
+
+		b.next("default")
+		b.next("default")
+
+		inRange(t, b.next("other"), dataStoreNotFoundBackoffBase)
+	})
+}
+
+func TestDataStoresForSecret(t *testing.T) {
+	secretRef := func(namespace, name string) *kamajiv1alpha1.SecretReference {
+		return &kamajiv1alpha1.SecretReference{
+			SecretReference: corev1.SecretReference{Namespace: namespace, Name: name},
+			KeyPath:         "content",
+		}
+	}
+
+	tls := &kamajiv1alpha1.DataStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "tls"},
+		Spec: kamajiv1alpha1.DataStoreSpec{
+			Driver: kamajiv1alpha1.EtcdDriver,
+			TLSConfig: kamajiv1alpha1.TLSConfig{
+				CertificateAuthority: kamajiv1alpha1.CertKeyPair{
+					Certificate: kamajiv1alpha1.ContentRef{SecretRef: secretRef("kamaji-system", "ca")},
+					PrivateKey:  &kamajiv1alpha1.ContentRef{SecretRef: secretRef("kamaji-system", "ca")},
+				},
+				ClientCertificate: kamajiv1alpha1.ClientCertificate{
+					Certificate: kamajiv1alpha1.ContentRef{SecretRef: secretRef("kamaji-system", "client")},
+					PrivateKey:  kamajiv1alpha1.ContentRef{SecretRef: secretRef("kamaji-system", "client")},
+					Next: &kamajiv1alpha1.ClientCertificateKeyPair{
+						Certificate: kamajiv1alpha1.ContentRef{SecretRef: secretRef("kamaji-system", "client-next")},
+						PrivateKey:  kamajiv1alpha1.ContentRef{SecretRef: secretRef("kamaji-system", "client-next")},
+					},
+				},
+			},
+		},
+	}
+
+	basicAuth := &kamajiv1alpha1.DataStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "basic-auth"},
+		Spec: kamajiv1alpha1.DataStoreSpec{
+			Driver: kamajiv1alpha1.KineMySQLDriver,
+			BasicAuth: &kamajiv1alpha1.BasicAuth{
+				Username: kamajiv1alpha1.ContentRef{SecretRef: secretRef("kamaji-system", "credentials")},
+				Password: kamajiv1alpha1.ContentRef{SecretRef: secretRef("kamaji-system", "credentials")},
+			},
+			TLSConfig: kamajiv1alpha1.TLSConfig{
+				CertificateAuthority: kamajiv1alpha1.CertKeyPair{
+					Certificate: kamajiv1alpha1.ContentRef{SecretRef: secretRef("kamaji-system", "ca")},
+				},
+				ClientCertificate: kamajiv1alpha1.ClientCertificate{
+					Certificate: kamajiv1alpha1.ContentRef{Content: []byte("client-crt")},
+					PrivateKey:  kamajiv1alpha1.ContentRef{Content: []byte("client-key")},
+				},
+			},
+		},
+	}
+
+	t.Run("indexer keys", func(t *testing.T) {
+		extract := (&kamajiv1alpha1.DatastoreUsedSecret{}).ExtractValue()
+
+		for _, tc := range []struct {
+			ds   *kamajiv1alpha1.DataStore
+			want []string
+		}{
+			{
+				ds:   tls,
+				want: []string{"kamaji-system/ca", "kamaji-system/ca", "kamaji-system/client", "kamaji-system/client", "kamaji-system/client-next", "kamaji-system/client-next"},
+			},
+			{
+				ds:   basicAuth,
+				want: []string{"kamaji-system/credentials", "kamaji-system/credentials", "kamaji-system/ca"},
+			},
+		} {
+			if got := extract(tc.ds); !slices.Equal(got, tc.want) {
+				t.Fatalf("unexpected keys for the DataStore %s, expected %v, got %v", tc.ds.GetName(), tc.want, got)
+			}
+		}
+	})
+
+	scheme := runtime.NewScheme()
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	indexer := &kamajiv1alpha1.DatastoreUsedSecret{}
+
+	r := &DataStore{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(tls, basicAuth).
+			WithIndex(indexer.Object(), indexer.Field(), indexer.ExtractValue()).
+			Build(),
+	}
+
+	for _, tc := range []struct {
+		secret string
+		want   []string
+	}{
+		{secret: "ca", want: []string{"basic-auth", "tls"}},
+		{secret: "client", want: []string{"tls"}},
+		{secret: "client-next", want: []string{"tls"}},
+		{secret: "credentials", want: []string{"basic-auth"}},
+		{secret: "unused", want: []string{}},
+	} {
+		t.Run("secret "+tc.secret, func(t *testing.T) {
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "kamaji-system", Name: tc.secret}}
+
+			want := make([]reconcile.Request, 0, len(tc.want))
+			for _, name := range tc.want {
+				want = append(want, reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: name}})
+			}
+
+			if got := r.dataStoresForSecret(context.Background(), secret); !slices.Equal(got, want) {
+				t.Fatalf("expected the requests %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestDataStoreDispatchResume(t *testing.T) {
+	ds := &kamajiv1alpha1.DataStore{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+
+	tcps := make([]kamajiv1alpha1.TenantControlPlane, 0, 10)
+	for i := 0; i < 10; i++ {
+		tcps = append(tcps, kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("tcp-%d", i)}})
+	}
+
+	channel := make(TenantControlPlaneChannel, len(tcps))
+
+	r := &DataStore{
+		TenantControlPlaneTrigger: channel,
+		// The limiter is slower than the reconcile timeout allows for the whole fan-out.
+		TriggerRateLimiter: rate.NewLimiter(rate.Every(40*time.Millisecond), 1),
+	}
+
+	var attempts int
+
+	for ; attempts < 10; attempts++ {
+		ctx, cancelFn := context.WithTimeout(context.Background(), 150*time.Millisecond)
+		_, err := r.dispatch(ctx, ds, "fingerprint", tcps)
+		cancelFn()
+
+		if err == nil {
+			break
+		}
+	}
+
+	if attempts == 0 {
+		t.Fatal("expected the fan-out to be interrupted by the reconcile timeout")
+	}
+
+	if r.triggers.changed(ds.GetName(), "fingerprint") {
+		t.Fatal("expected the fingerprint to be recorded once all the Tenant Control Planes have been triggered")
+	}
+
+	close(channel)
+
+	triggered := sets.New[string]()
+
+	for e := range channel {
+		name := e.Object.GetName()
+		if triggered.Has(name) {
+			t.Fatalf("the Tenant Control Plane %s has been triggered more than once", name)
+		}
+
+		triggered.Insert(name)
+	}
+
+	if triggered.Len() != len(tcps) {
+		t.Fatalf("expected %d triggered Tenant Control Planes, got %d", len(tcps), triggered.Len())
+	}
+}
+
+func TestDataStoreFingerprintFiles(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "ca.crt")
+
+	defer func(previous string) {
+		kamajiv1alpha1.ContentFileRoot = previous
+	}(kamajiv1alpha1.ContentFileRoot)
+
+	kamajiv1alpha1.ContentFileRoot = root
+
+	if err := os.WriteFile(path, []byte("current"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ds := &kamajiv1alpha1.DataStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Generation: 1},
+		Spec: kamajiv1alpha1.DataStoreSpec{
+			Driver: kamajiv1alpha1.EtcdDriver,
+			TLSConfig: kamajiv1alpha1.TLSConfig{
+				CertificateAuthority: kamajiv1alpha1.CertKeyPair{
+					Certificate: kamajiv1alpha1.ContentRef{FilePath: path},
+				},
+				ClientCertificate: kamajiv1alpha1.ClientCertificate{
+					Certificate: kamajiv1alpha1.ContentRef{Content: []byte("client-crt")},
+					PrivateKey:  kamajiv1alpha1.ContentRef{Content: []byte("client-key")},
+				},
+			},
+		},
+	}
+
+	r := &DataStore{}
+	c := fake.NewClientBuilder().Build()
+
+	current, err := r.fingerprint(context.Background(), c, ds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if unchanged, _ := r.fingerprint(context.Background(), c, ds); unchanged != current {
+		t.Fatalf("expected a stable fingerprint, got %q and %q", current, unchanged)
+	}
+	// Rotating the file on disk doesn't change the DataStore.
+	if err = os.WriteFile(path, []byte("rotated"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rotated, err := r.fingerprint(context.Background(), c, ds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rotated == current {
+		t.Fatal("expected the fingerprint to change upon the file rotation")
+	}
+
+	if err = os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// The missing files are tracked rather than failing, such as the ones of a next client certificate not mounted yet.
+	missing, err := r.fingerprint(context.Background(), c, ds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if missing == rotated {
+		t.Fatal("expected the fingerprint to change upon the file removal")
+	}
+}
+
+// selfSignedCertificate returns a PEM encoded self-signed certificate, and its private key.
+func selfSignedCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kamaji"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(0, 0, 7),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestDataStoreNextClientCertificate(t *testing.T) {
+	crt, keyPEM := selfSignedCertificate(t)
+
+	ds := &kamajiv1alpha1.DataStore{ObjectMeta: metav1.ObjectMeta{Name: "default", Generation: 1}}
+
+	recorder := record.NewFakeRecorder(10)
+	r := &DataStore{EventRecorder: recorder}
+	c := fake.NewClientBuilder().Build()
+
+	expectEvent := func(t *testing.T, want string) {
+		t.Helper()
+
+		select {
+		case event := <-recorder.Events:
+			if !strings.HasPrefix(event, want) {
+				t.Fatalf("expected the %q event, got %q", want, event)
+			}
+		default:
+			if len(want) > 0 {
+				t.Fatalf("expected the %q event, got none", want)
+			}
+		}
+	}
+
+	expectCondition := func(t *testing.T, want metav1.ConditionStatus) {
+		t.Helper()
+
+		condition := meta.FindStatusCondition(ds.Status.Conditions, kamajiv1alpha1.DataStoreNextClientCertificateValidCondition)
+
+		switch {
+		case len(want) == 0 && condition != nil:
+			t.Fatalf("expected no condition, got %s", condition.Status)
+		case len(want) > 0 && condition == nil:
+			t.Fatalf("expected the condition %s, got none", want)
+		case len(want) > 0 && condition.Status != want:
+			t.Fatalf("expected the condition %s, got %s: %s", want, condition.Status, condition.Message)
+		}
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		ds.Spec.TLSConfig.ClientCertificate.Next = &kamajiv1alpha1.ClientCertificateKeyPair{
+			Certificate: kamajiv1alpha1.ContentRef{Content: crt},
+			PrivateKey:  kamajiv1alpha1.ContentRef{Content: []byte("not a key")},
+		}
+
+		r.validateNextClientCertificate(context.Background(), c, ds)
+
+		expectCondition(t, metav1.ConditionFalse)
+		expectEvent(t, corev1.EventTypeWarning+" InvalidNextClientCertificate")
+	})
+
+	t.Run("still invalid", func(t *testing.T) {
+		r.validateNextClientCertificate(context.Background(), c, ds)
+
+		expectCondition(t, metav1.ConditionFalse)
+		expectEvent(t, "")
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		ds.Spec.TLSConfig.ClientCertificate.Next.PrivateKey = kamajiv1alpha1.ContentRef{Content: keyPEM}
+
+		r.validateNextClientCertificate(context.Background(), c, ds)
+
+		expectCondition(t, metav1.ConditionTrue)
+		expectEvent(t, corev1.EventTypeNormal+" ValidNextClientCertificate")
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		ds.Spec.TLSConfig.ClientCertificate.Next = nil
+
+		r.validateNextClientCertificate(context.Background(), c, ds)
+
+		expectCondition(t, "")
+		expectEvent(t, "")
+	})
+}
+
+func TestDefaultDataStoreReadyz(t *testing.T) {
+	elected, notElected := make(chan struct{}), make(chan struct{})
+	close(elected)
+
+	r := &DataStore{DefaultDataStoreName: "default"}
+
+	if err := r.DefaultDataStoreReadyz(notElected)(nil); err != nil {
+		t.Fatalf("expected the replica not elected as leader to be ready, got %v", err)
+	}
+
+	if err := r.DefaultDataStoreReadyz(elected)(nil); err == nil {
+		t.Fatal("expected the leader to be not ready until the default DataStore has been validated")
+	}
+
+	r.defaultValidated.Store(true)
+
+	if err := r.DefaultDataStoreReadyz(elected)(nil); err != nil {
+		t.Fatalf("expected the leader to be ready once the default DataStore has been validated, got %v", err)
+	}
+
+	if err := r.DefaultDataStoreReadyz(notElected)(nil); err != nil {
+		t.Fatalf("expected the replica not elected as leader to be ready, got %v", err)
+	}
+}
+
+func TestDefaultDataStoreReadyzConnection(t *testing.T) {
+	elected := make(chan struct{})
+	close(elected)
+
+	ds := &kamajiv1alpha1.DataStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec:       kamajiv1alpha1.DataStoreSpec{Driver: kamajiv1alpha1.KineMySQLDriver, Endpoints: []string{"127.0.0.1:1"}},
+	}
+
+	r := &DataStore{DefaultDataStoreName: "default", EventRecorder: record.NewFakeRecorder(10)}
+	r.defaultValidated.Store(true)
+
+	var healthy error
+	r.defaultConnectionErr.Store(&healthy)
+
+	if err := r.DefaultDataStoreReadyz(elected)(nil); err != nil {
+		t.Fatalf("expected the leader to be ready with a healthy default DataStore, got %v", err)
+	}
+	// The missing TLS content makes the connection check fail.
+	r.checkConnection(context.Background(), fake.NewClientBuilder().Build(), ds)
+
+	if !meta.IsStatusConditionFalse(ds.Status.Conditions, kamajiv1alpha1.DataStoreConnectionHealthyCondition) {
+		t.Fatal("expected the default DataStore to be reported unhealthy")
+	}
+
+	if err := r.DefaultDataStoreReadyz(elected)(nil); err == nil {
+		t.Fatal("expected the leader to be not ready once the default DataStore is unreachable")
+	}
+	// The other DataStores don't affect the readiness.
+	r.defaultConnectionErr.Store(&healthy)
+	ds.SetName("other")
+
+	r.checkConnection(context.Background(), fake.NewClientBuilder().Build(), ds)
+
+	if err := r.DefaultDataStoreReadyz(elected)(nil); err != nil {
+		t.Fatalf("expected the leader to be ready with an unreachable non-default DataStore, got %v", err)
+	}
+}
+
+func TestTenantControlPlaneDataStoreHandler(t *testing.T) {
+	tcp := func(dataStoreName string) *kamajiv1alpha1.TenantControlPlane {
+		tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tcp"}}
+		tcp.Status.Storage.DataStoreName = dataStoreName
+
+		return tcp
+	}
+
+	for _, tc := range []struct {
+		name string
+		old  string
+		new  string
+		want []string
+	}{
+		{name: "switch", old: "a", new: "b", want: []string{"a", "b"}},
+		{name: "same DataStore", old: "a", new: "a", want: []string{"a"}},
+		{name: "first assignment", old: "", new: "b", want: []string{"b"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			queue := workqueue.NewRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(0, 0))
+			defer queue.ShutDown()
+
+			tenantControlPlaneDataStoreHandler().Update(context.Background(), event.UpdateEvent{ObjectOld: tcp(tc.old), ObjectNew: tcp(tc.new)}, queue)
+
+			got := make([]string, 0, queue.Len())
+			for queue.Len() > 0 {
+				item, _ := queue.Get()
+				got = append(got, item.(reconcile.Request).Name) //nolint:forcetypeassert
+				queue.Done(item)
+			}
+
+			slices.Sort(got)
+
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("expected the DataStores %v to be enqueued, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDataStoreTriggerTimeout(t *testing.T) {
+	ds := &kamajiv1alpha1.DataStore{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	tcp := kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tcp"}}
+
+	// The channel has no capacity and no consumer, thus each send expires.
+	r := &DataStore{
+		TenantControlPlaneTrigger: make(TenantControlPlaneChannel),
+		TriggerTimeout:            10 * time.Millisecond,
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		sent, err := r.trigger(context.Background(), &tcp)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if sent {
+			t.Error("expected the trigger not to be sent")
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the trigger not to block beyond the timeout")
+	}
+
+	result, err := r.dispatch(context.Background(), ds, "fingerprint", []kamajiv1alpha1.TenantControlPlane{tcp})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !result.Requeue {
+		t.Fatal("expected the DataStore to be requeued")
+	}
+
+	if !r.triggers.changed(ds.GetName(), "fingerprint") {
+		t.Fatal("expected the fingerprint not to be recorded until the Tenant Control Planes are triggered")
+	}
+
+	// A cancelled reconciliation is reported, rather than a timeout.
+	ctx, cancelFn := context.WithCancel(context.Background())
+	cancelFn()
+
+	r.TriggerTimeout = time.Hour
+
+	if _, err = r.trigger(ctx, &tcp); err == nil {
+		t.Fatal("expected the context error")
+	}
+}
+
+func TestDataStoreRefreshValidationTime(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		interval time.Duration
+		refresh  time.Duration
+	}{
+		{name: "no health check", interval: 0, refresh: dataStoreValidationTimeMinRefresh},
+		{name: "frequent health check", interval: 10 * time.Second, refresh: dataStoreValidationTimeMinRefresh},
+		{name: "health check interval", interval: 5 * time.Minute, refresh: 5 * time.Minute},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClock := clocktesting.NewFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+			r := &DataStore{HealthCheckInterval: tc.interval, clock: fakeClock}
+			ds := &kamajiv1alpha1.DataStore{}
+
+			r.refreshValidationTime(ds)
+
+			validated := fakeClock.Now()
+			if ds.Status.LastValidationTime == nil || !ds.Status.LastValidationTime.Time.Equal(validated) {
+				t.Fatalf("expected the last validation time %s, got %v", validated, ds.Status.LastValidationTime)
+			}
+
+			fakeClock.Step(tc.refresh - time.Second)
+			r.refreshValidationTime(ds)
+
+			if !ds.Status.LastValidationTime.Time.Equal(validated) {
+				t.Fatalf("expected the last validation time to stay %s, got %s", validated, ds.Status.LastValidationTime)
+			}
+
+			fakeClock.Step(time.Second)
+			r.refreshValidationTime(ds)
+
+			if !ds.Status.LastValidationTime.Time.Equal(fakeClock.Now()) {
+				t.Fatalf("expected the last validation time to advance to %s, got %s", fakeClock.Now(), ds.Status.LastValidationTime)
+			}
+		})
+	}
+}
+
+// newDataStoreReconciler returns a DataStore reconciler backed by a fake client with the given objects,
+// and the indexers used to retrieve the Tenant Control Planes of a DataStore.
+func newDataStoreReconciler(t *testing.T, objects ...client.Object) *DataStore {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	usedDataStore, specDataStore := &kamajiv1alpha1.TenantControlPlaneStatusDataStore{}, &kamajiv1alpha1.TenantControlPlaneSpecDataStore{}
+
+	return &DataStore{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objects...).
+			WithStatusSubresource(&kamajiv1alpha1.DataStore{}).
+			WithIndex(usedDataStore.Object(), usedDataStore.Field(), usedDataStore.ExtractValue()).
+			WithIndex(specDataStore.Object(), specDataStore.Field(), specDataStore.ExtractValue()).
+			Build(),
+		EventRecorder: record.NewFakeRecorder(100),
+		clock:         clocktesting.NewFakeClock(time.Now()),
+	}
+}
+
+// usingDataStore returns a Tenant Control Plane using the given DataStore.
+func usingDataStore(name, dataStore string) *kamajiv1alpha1.TenantControlPlane {
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	tcp.Spec.DataStore = dataStore
+	tcp.Status.Storage.DataStoreName = dataStore
+
+	return tcp
+}
+
+func TestDataStoreTenantControlPlanesGauge(t *testing.T) {
+	ds := &kamajiv1alpha1.DataStore{ObjectMeta: metav1.ObjectMeta{Name: "gauge"}}
+
+	r := newDataStoreReconciler(t, ds, usingDataStore("tcp-1", "gauge"), usingDataStore("tcp-2", "gauge"), usingDataStore("tcp-3", "other"))
+	r.TrackUsage = true
+
+	request := reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: "gauge"}}
+	// The DataStore has no TLS content, the gauge is updated regardless of the validation outcome.
+	_, _ = r.Reconcile(context.Background(), request)
+
+	if got := testutil.ToFloat64(dataStoreTenantControlPlanesGauge.WithLabelValues("gauge")); got != 2 {
+		t.Fatalf("expected 2 Tenant Control Planes, got %v", got)
+	}
+
+	// The DataStore is gone.
+	if _, err := newDataStoreReconciler(t).Reconcile(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+
+	if dataStoreTenantControlPlanesGauge.DeleteLabelValues("gauge") {
+		t.Fatal("expected the series to be dropped once the DataStore is gone")
+	}
+}
+
+// validDataStore returns a DataStore with a valid TLS content, whose endpoint is not reachable.
+func validDataStore(t *testing.T, name string) *kamajiv1alpha1.DataStore {
+	t.Helper()
+
+	crt, key := selfSignedCertificate(t)
+
+	return &kamajiv1alpha1.DataStore{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: kamajiv1alpha1.DataStoreSpec{
+			Driver:    kamajiv1alpha1.KineMySQLDriver,
+			Endpoints: []string{"127.0.0.1:1"},
+			TLSConfig: kamajiv1alpha1.TLSConfig{
+				CertificateAuthority: kamajiv1alpha1.CertKeyPair{Certificate: kamajiv1alpha1.ContentRef{Content: crt}},
+				ClientCertificate: kamajiv1alpha1.ClientCertificate{
+					Certificate: kamajiv1alpha1.ContentRef{Content: crt},
+					PrivateKey:  kamajiv1alpha1.ContentRef{Content: key},
+				},
+			},
+		},
+	}
+}
+
+func TestDataStoreDryRun(t *testing.T) {
+	request := reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: "dry-run"}}
+
+	for _, tc := range []struct {
+		name   string
+		dryRun bool
+	}{
+		{name: "dry-run", dryRun: true},
+		{name: "persisted", dryRun: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newDataStoreReconciler(t, validDataStore(t, "dry-run"), usingDataStore("tcp", "dry-run"))
+			r.TrackUsage = true
+			r.TenantControlPlaneTrigger = make(TenantControlPlaneChannel, 1)
+			r.HealthCheckInterval = time.Minute
+			r.DryRun = tc.dryRun
+			// Mirroring the wrapping of the client upon the manager setup.
+			stored := r.Client
+			if tc.dryRun {
+				r.Client = client.NewDryRunClient(r.Client)
+			}
+
+			result, err := r.Reconcile(context.Background(), request)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.RequeueAfter != r.HealthCheckInterval {
+				t.Fatalf("expected the DataStore to be requeued after %s, got %s", r.HealthCheckInterval, result.RequeueAfter)
+			}
+
+			if triggered := len(r.TenantControlPlaneTrigger); (triggered > 0) == tc.dryRun {
+				t.Fatalf("expected the Tenant Control Planes to be triggered only with no dry-run, got %d triggers", triggered)
+			}
+
+			ds := &kamajiv1alpha1.DataStore{}
+			if err = stored.Get(context.Background(), request.NamespacedName, ds); err != nil {
+				t.Fatal(err)
+			}
+
+			if persisted := len(ds.Status.UsedBy) > 0 && controllerutil.ContainsFinalizer(ds, finalizers.DataStoreInUseFinalizer); persisted == tc.dryRun {
+				t.Fatalf("expected the status and the finalizer to be persisted only with no dry-run, got %v and %v", ds.Status.UsedBy, ds.GetFinalizers())
+			}
+		})
+	}
+}
+
+func TestDataStoreTrackUsageDisabled(t *testing.T) {
+	ds := validDataStore(t, "untracked")
+	ds.Status.UsedBy = []string{"default/stale"}
+	controllerutil.AddFinalizer(ds, finalizers.DataStoreInUseFinalizer)
+
+	r := newDataStoreReconciler(t, ds, usingDataStore("tcp", "untracked"))
+	r.TenantControlPlaneTrigger = make(TenantControlPlaneChannel, 1)
+
+	dataStoreTenantControlPlanesGauge.WithLabelValues("untracked").Set(1)
+
+	request := reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: "untracked"}}
+
+	if _, err := r.Reconcile(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := &kamajiv1alpha1.DataStore{}
+	if err := r.Client.Get(context.Background(), request.NamespacedName, stored); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stored.Status.UsedBy) > 0 {
+		t.Fatalf("expected no Tenant Control Planes in the status, got %v", stored.Status.UsedBy)
+	}
+	// The usage is unknown, thus the in-use finalizer is left untouched.
+	if !controllerutil.ContainsFinalizer(stored, finalizers.DataStoreInUseFinalizer) {
+		t.Fatal("expected the in-use finalizer to be left untouched")
+	}
+
+	if dataStoreTenantControlPlanesGauge.DeleteLabelValues("untracked") {
+		t.Fatal("expected the gauge series to be dropped")
+	}
+	// The Tenant Control Planes using the DataStore are still triggered.
+	if len(r.TenantControlPlaneTrigger) != 1 {
+		t.Fatalf("expected the Tenant Control Plane to be triggered, got %d triggers", len(r.TenantControlPlaneTrigger))
+	}
+	// The unchanged status is not written again.
+	if _, err := r.Reconcile(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+
+	unchanged := &kamajiv1alpha1.DataStore{}
+	if err := r.Client.Get(context.Background(), request.NamespacedName, unchanged); err != nil {
+		t.Fatal(err)
+	}
+
+	if unchanged.GetResourceVersion() != stored.GetResourceVersion() {
+		t.Fatalf("expected no status update, got the resource version %s from %s", unchanged.GetResourceVersion(), stored.GetResourceVersion())
+	}
+}
+
+func TestDataStoreConcurrentReconciles(t *testing.T) {
+	const dataStores, tcpsPerDataStore = 8, 3
+
+	names := make([]string, 0, dataStores)
+	objects := make([]client.Object, 0, dataStores*(tcpsPerDataStore+1))
+
+	for i := 0; i < dataStores; i++ {
+		name := fmt.Sprintf("concurrent-%d", i)
+		names = append(names, name)
+
+		objects = append(objects, validDataStore(t, name))
+		for j := 0; j < tcpsPerDataStore; j++ {
+			objects = append(objects, usingDataStore(fmt.Sprintf("%s-tcp-%d", name, j), name))
+		}
+	}
+
+	r := newDataStoreReconciler(t, objects...)
+	r.TrackUsage = true
+	r.TenantControlPlaneTrigger = make(TenantControlPlaneChannel, dataStores*tcpsPerDataStore)
+	r.MaxConcurrentReconciles = dataStores
+	// The workers never reconcile the same DataStore concurrently, the different ones share the reconciler state.
+	reconcileAll := func(t *testing.T) sets.Set[string] {
+		t.Helper()
+
+		var wg sync.WaitGroup
+
+		errs := make(chan error, len(names))
+
+		for _, name := range names {
+			wg.Add(1)
+
+			go func(name string) {
+				defer wg.Done()
+
+				if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: name}}); err != nil {
+					errs <- err
+				}
+			}(name)
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			t.Fatal(err)
+		}
+
+		triggered := sets.New[string]()
+		for len(r.TenantControlPlaneTrigger) > 0 {
+			triggered.Insert((<-r.TenantControlPlaneTrigger).Object.GetName())
+		}
+
+		return triggered
+	}
+
+	if triggered := reconcileAll(t); triggered.Len() != dataStores*tcpsPerDataStore {
+		t.Fatalf("expected each Tenant Control Plane to be triggered once, got %v", sets.List(triggered))
+	}
+
+	for _, name := range names {
+		ds := &kamajiv1alpha1.DataStore{}
+		if err := r.Client.Get(context.Background(), k8stypes.NamespacedName{Name: name}, ds); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(ds.Status.UsedBy) != tcpsPerDataStore {
+			t.Fatalf("expected %d Tenant Control Planes using the %s DataStore, got %v", tcpsPerDataStore, name, ds.Status.UsedBy)
+		}
+
+		if got := testutil.ToFloat64(dataStoreTenantControlPlanesGauge.WithLabelValues(name)); got != tcpsPerDataStore {
+			t.Fatalf("expected %d Tenant Control Planes in the %s DataStore gauge, got %v", tcpsPerDataStore, name, got)
+		}
+	}
+	// The fingerprints have been recorded for each DataStore, thus the unchanged ones don't trigger them again.
+	if triggered := reconcileAll(t); triggered.Len() > 0 {
+		t.Fatalf("expected no triggers for the unchanged DataStores, got %v", sets.List(triggered))
+	}
+
+	for _, name := range names {
+		dataStoreTenantControlPlanesGauge.DeleteLabelValues(name)
+	}
+}
+
+func TestDataStoreInUseFinalizer(t *testing.T) {
+	ctx := context.Background()
+	request := reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: "in-use"}}
+
+	tcp := usingDataStore("tcp", "in-use")
+
+	r := newDataStoreReconciler(t, validDataStore(t, "in-use"), tcp)
+	r.TrackUsage = true
+	r.TenantControlPlaneTrigger = make(TenantControlPlaneChannel, 1)
+	r.HealthCheckInterval = time.Minute
+
+	defer dataStoreTenantControlPlanesGauge.DeleteLabelValues("in-use")
+
+	if _, err := r.Reconcile(ctx, request); err != nil {
+		t.Fatal(err)
+	}
+
+	ds := &kamajiv1alpha1.DataStore{}
+	if err := r.Client.Get(ctx, request.NamespacedName, ds); err != nil {
+		t.Fatal(err)
+	}
+
+	if !controllerutil.ContainsFinalizer(ds, finalizers.DataStoreInUseFinalizer) {
+		t.Fatal("expected the in-use finalizer to be added to the DataStore used by a Tenant Control Plane")
+	}
+	// The deletion is blocked while the DataStore is in use.
+	if err := r.Client.Delete(ctx, ds); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := r.Reconcile(ctx, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.RequeueAfter != r.HealthCheckInterval {
+		t.Fatalf("expected the DataStore to be requeued after %s, got %s", r.HealthCheckInterval, result.RequeueAfter)
+	}
+
+	if err = r.Client.Get(ctx, request.NamespacedName, ds); err != nil {
+		t.Fatalf("expected the DataStore deletion to be blocked, got %v", err)
+	}
+
+	if !controllerutil.ContainsFinalizer(ds, finalizers.DataStoreInUseFinalizer) {
+		t.Fatal("expected the in-use finalizer to be kept")
+	}
+
+	recorder := r.EventRecorder.(*record.FakeRecorder) //nolint:forcetypeassert
+
+	var blocked bool
+
+	for len(recorder.Events) > 0 {
+		if event := <-recorder.Events; strings.HasPrefix(event, "Warning DataStoreInUse") && strings.Contains(event, "default/tcp") {
+			blocked = true
+		}
+	}
+
+	if !blocked {
+		t.Fatal("expected the DataStoreInUse event listing the Tenant Control Plane")
+	}
+	// Once no more used, the finalizer is removed, completing the deletion.
+	if err = r.Client.Delete(ctx, tcp); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = r.Reconcile(ctx, request); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = r.Client.Get(ctx, request.NamespacedName, ds); !k8serrors.IsNotFound(err) {
+		t.Fatalf("expected the DataStore to be deleted, got %v", err)
+	}
+}
+
+func TestDataStoreUnusedFinalizer(t *testing.T) {
+	ds := validDataStore(t, "unused")
+	controllerutil.AddFinalizer(ds, finalizers.DataStoreInUseFinalizer)
+
+	r := newDataStoreReconciler(t, ds)
+	r.TrackUsage = true
+
+	defer dataStoreTenantControlPlanesGauge.DeleteLabelValues("unused")
+
+	request := reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: "unused"}}
+
+	if _, err := r.Reconcile(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := &kamajiv1alpha1.DataStore{}
+	if err := r.Client.Get(context.Background(), request.NamespacedName, stored); err != nil {
+		t.Fatal(err)
+	}
+
+	if controllerutil.ContainsFinalizer(stored, finalizers.DataStoreInUseFinalizer) {
+		t.Fatal("expected the in-use finalizer to be removed from the unused DataStore")
+	}
+}
+
+func TestDataStoreTriggerShutdown(t *testing.T) {
+	request := reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: "shutdown"}}
+
+	// The channel has no capacity and is no more consumed, as upon the manager shutdown.
+	r := newDataStoreReconciler(t, validDataStore(t, "shutdown"), usingDataStore("tcp-1", "shutdown"), usingDataStore("tcp-2", "shutdown"))
+	r.TrackUsage = true
+	r.TenantControlPlaneTrigger = make(TenantControlPlaneChannel)
+
+	defer dataStoreTenantControlPlanesGauge.DeleteLabelValues("shutdown")
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+
+	done := make(chan error)
+
+	go func() {
+		_, err := r.Reconcile(ctx, request)
+		done <- err
+	}()
+
+	// Consuming the first trigger only, then shutting down.
+	select {
+	case <-r.TenantControlPlaneTrigger:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the first Tenant Control Plane to be triggered")
+	}
+
+	cancelFn()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected the context cancellation, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the triggers to be aborted upon the shutdown")
+	}
+	// The remaining trigger is resumed upon the retry.
+	r.TenantControlPlaneTrigger = make(TenantControlPlaneChannel, 2)
+
+	if _, err := r.Reconcile(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+
+	if triggered := len(r.TenantControlPlaneTrigger); triggered != 1 {
+		t.Fatalf("expected the remaining Tenant Control Plane to be triggered, got %d triggers", triggered)
+	}
+}
+
+func TestDataStorePeriodicHealthCheck(t *testing.T) {
+	ctx := context.Background()
+	request := reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: "health"}}
+
+	r := newDataStoreReconciler(t, validDataStore(t, "health"), usingDataStore("tcp", "health"))
+	r.TrackUsage = true
+	r.TenantControlPlaneTrigger = make(TenantControlPlaneChannel, 1)
+	r.HealthCheckInterval = time.Minute
+
+	defer dataStoreTenantControlPlanesGauge.DeleteLabelValues("health")
+
+	recorder := r.EventRecorder.(*record.FakeRecorder) //nolint:forcetypeassert
+
+	// check reconciles the DataStore, returning the number of the triggered Tenant Control Planes, and of the ConnectionFailed events.
+	check := func(t *testing.T) (int, int) {
+		t.Helper()
+
+		result, err := r.Reconcile(ctx, request)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result.RequeueAfter != r.HealthCheckInterval {
+			t.Fatalf("expected the DataStore to be checked again after %s, got %s", r.HealthCheckInterval, result.RequeueAfter)
+		}
+
+		var triggered, failed int
+
+		for len(r.TenantControlPlaneTrigger) > 0 {
+			<-r.TenantControlPlaneTrigger
+			triggered++
+		}
+
+		for len(recorder.Events) > 0 {
+			if strings.HasPrefix(<-recorder.Events, "Warning ConnectionFailed") {
+				failed++
+			}
+		}
+
+		return triggered, failed
+	}
+
+	if triggered, failed := check(t); triggered != 1 || failed != 1 {
+		t.Fatalf("expected the Tenant Control Plane to be triggered, and the connection failure to be reported, got %d triggers and %d events", triggered, failed)
+	}
+
+	ds := &kamajiv1alpha1.DataStore{}
+	if err := r.Client.Get(ctx, request.NamespacedName, ds); err != nil {
+		t.Fatal(err)
+	}
+
+	if condition := meta.FindStatusCondition(ds.Status.Conditions, kamajiv1alpha1.DataStoreConnectionHealthyCondition); condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "ConnectionFailed" {
+		t.Fatalf("expected the ConnectionHealthy condition to report the failure, got %v", condition)
+	}
+	// The periodic check of an unchanged DataStore neither triggers the Tenant Control Planes, nor reports the failure again.
+	if triggered, failed := check(t); triggered != 0 || failed != 0 {
+		t.Fatalf("expected no triggers and no events for the unchanged DataStore, got %d triggers and %d events", triggered, failed)
+	}
+	// The DataStore changes trigger the Tenant Control Planes again.
+	if err := r.Client.Get(ctx, request.NamespacedName, ds); err != nil {
+		t.Fatal(err)
+	}
+
+	ds.SetGeneration(ds.GetGeneration() + 1)
+
+	if err := r.Client.Update(ctx, ds); err != nil {
+		t.Fatal(err)
+	}
+
+	if triggered, _ := check(t); triggered != 1 {
+		t.Fatalf("expected the Tenant Control Plane to be triggered upon the DataStore change, got %d triggers", triggered)
+	}
+	// A zero interval disables the periodic check.
+	r.HealthCheckInterval = 0
+
+	if triggered, _ := check(t); triggered != 0 {
+		t.Fatalf("expected no triggers for the unchanged DataStore, got %d triggers", triggered)
+	}
+}
+
+func TestDataStoreTriggerRateLimiter(t *testing.T) {
+	const interval = 20 * time.Millisecond
+
+	tcps := make([]kamajiv1alpha1.TenantControlPlane, 0, 5)
+	for i := 0; i < 5; i++ {
+		tcps = append(tcps, kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("tcp-%d", i)}})
+	}
+
+	// The limiter is shared across the DataStores, thus the triggers of both of them are throttled together.
+	start := time.Now()
+
+	r := &DataStore{
+		TenantControlPlaneTrigger: make(TenantControlPlaneChannel, 2*len(tcps)),
+		TriggerRateLimiter:        rate.NewLimiter(rate.Every(interval), 1),
+	}
+
+	for _, name := range []string{"first", "second"} {
+		ds := &kamajiv1alpha1.DataStore{ObjectMeta: metav1.ObjectMeta{Name: name}}
+
+		if _, err := r.dispatch(context.Background(), ds, "fingerprint", tcps); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The burst allows the first trigger only with no wait.
+	if elapsed, want := time.Since(start), time.Duration(2*len(tcps)-1)*interval; elapsed < want {
+		t.Fatalf("expected the triggers to be throttled over %s at least, got %s", want, elapsed)
+	}
+
+	got := make([]string, 0, 2*len(tcps))
+	for len(r.TenantControlPlaneTrigger) > 0 {
+		got = append(got, (<-r.TenantControlPlaneTrigger).Object.GetName())
+	}
+
+	want := make([]string, 0, 2*len(tcps))
+	for i := 0; i < 2; i++ {
+		for _, tcp := range tcps {
+			want = append(want, tcp.GetName())
+		}
+	}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected the triggers in the listing order %v, got %v", want, got)
+	}
+	// Waiting for the limiter is aborted upon the cancellation, with no trigger sent.
+	r.TriggerRateLimiter = rate.NewLimiter(rate.Every(time.Hour), 1)
+	r.TriggerRateLimiter.Allow()
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	cancelFn()
+
+	ds := &kamajiv1alpha1.DataStore{ObjectMeta: metav1.ObjectMeta{Name: "cancelled"}}
+
+	if _, err := r.dispatch(ctx, ds, "fingerprint", tcps); err == nil {
+		t.Fatal("expected the limiter wait to be aborted")
+	}
+
+	if len(r.TenantControlPlaneTrigger) > 0 {
+		t.Fatalf("expected no triggers, got %d", len(r.TenantControlPlaneTrigger))
+	}
+
+	if !r.triggers.changed(ds.GetName(), "fingerprint") {
+		t.Fatal("expected the fingerprint not to be recorded")
+	}
+}
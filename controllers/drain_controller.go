@@ -0,0 +1,242 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+)
+
+var (
+	drainingPodsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kamaji_tenant_control_plane_draining_pods",
+		Help: "Number of Tenant Control Plane Pods draining their connections upon termination.",
+	}, []string{"namespace", "name"})
+	drainDurationHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kamaji_tenant_control_plane_drain_duration_seconds",
+		Help:    "Duration of the Tenant Control Plane Pods connections draining, from the termination request to the Pod removal.",
+		Buckets: []float64{1, 5, 10, 15, 30, 45, 60, 90, 120, 300},
+	}, []string{"namespace", "name"})
+)
+
+// ControlPlaneDrain keeps track of the Tenant Control Plane Pods draining their connections upon termination,
+// such as during a rollout, emitting an event when the draining starts and completes.
+type ControlPlaneDrain struct {
+	Client        client.Client
+	EventRecorder record.EventRecorder
+	// EnableMetrics toggles the draining metrics, exposed along with the controller-runtime ones.
+	EnableMetrics bool
+}
+
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+
+func (d *ControlPlaneDrain) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	pods := &corev1.PodList{}
+	if err := d.Client.List(ctx, pods, client.InNamespace(request.Namespace), client.MatchingLabels{
+		constants.ControlPlaneLabelKey:      request.Name,
+		constants.ControlPlaneLabelResource: constants.ControlPlaneDeploymentLabelValue,
+	}); err != nil {
+		logger.Error(err, "cannot list Tenant Control Plane Pods")
+
+		return reconcile.Result{}, err
+	}
+
+	draining := make([]kamajiv1alpha1.DrainingPod, 0, len(pods.Items))
+
+	for _, pod := range pods.Items {
+		if pod.GetDeletionTimestamp() == nil {
+			continue
+		}
+
+		draining = append(draining, kamajiv1alpha1.DrainingPod{Name: pod.GetName(), Since: d.drainStart(pod)})
+	}
+
+	sort.Slice(draining, func(i, j int) bool {
+		return draining[i].Name < draining[j].Name
+	})
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		tcp := &kamajiv1alpha1.TenantControlPlane{}
+		if err := d.Client.Get(ctx, request.NamespacedName, tcp); err != nil {
+			return err
+		}
+
+		previous, status := tcp.Status.Kubernetes.Drain, d.drainStatus(tcp, draining)
+		if equality.Semantic.DeepEqual(status, previous) {
+			return nil
+		}
+
+		tcp.Status.Kubernetes.Drain = status
+
+		if err := d.Client.Status().Update(ctx, tcp); err != nil {
+			return err
+		}
+
+		d.recordTransitions(tcp, previous, draining)
+
+		return nil
+	})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			logger.Info("resource may have been deleted, skipping")
+
+			d.deleteMetrics(request.NamespacedName)
+
+			return reconcile.Result{}, nil
+		}
+
+		logger.Error(err, "cannot update the Tenant Control Plane drain status")
+
+		return reconcile.Result{}, err
+	}
+
+	if d.EnableMetrics {
+		drainingPodsGauge.WithLabelValues(request.Namespace, request.Name).Set(float64(len(draining)))
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// drainStart returns the time the termination of the given Pod has been requested:
+// the deletion timestamp is set to the request time increased by the termination grace period.
+func (d *ControlPlaneDrain) drainStart(pod corev1.Pod) metav1.Time {
+	start := pod.GetDeletionTimestamp().Time
+
+	if grace := pod.GetDeletionGracePeriodSeconds(); grace != nil {
+		start = start.Add(-time.Duration(*grace) * time.Second)
+	}
+
+	return metav1.NewTime(start)
+}
+
+// drainStatus returns the desired drain status, tracking the last completed draining
+// for the Pods which are no more part of the draining ones.
+func (d *ControlPlaneDrain) drainStatus(tcp *kamajiv1alpha1.TenantControlPlane, draining []kamajiv1alpha1.DrainingPod) *kamajiv1alpha1.KubernetesDrainStatus {
+	status := &kamajiv1alpha1.KubernetesDrainStatus{}
+
+	if previous := tcp.Status.Kubernetes.Drain; previous != nil {
+		status.LastDrainDuration, status.LastDrainCompletion = previous.LastDrainDuration, previous.LastDrainCompletion
+
+		for _, pod := range previous.DrainingPods {
+			if containsDrainingPod(draining, pod.Name) {
+				continue
+			}
+
+			completion := metav1.Now()
+
+			status.LastDrainCompletion = &completion
+			status.LastDrainDuration = &metav1.Duration{Duration: completion.Sub(pod.Since.Time).Round(time.Second)}
+		}
+	}
+
+	if len(draining) > 0 {
+		status.DrainingPods = draining
+	}
+
+	if len(status.DrainingPods) == 0 && status.LastDrainCompletion == nil {
+		return nil
+	}
+
+	return status
+}
+
+// recordTransitions emits the events, and observes the metrics, for the Pods starting or completing the draining.
+func (d *ControlPlaneDrain) recordTransitions(tcp *kamajiv1alpha1.TenantControlPlane, status *kamajiv1alpha1.KubernetesDrainStatus, draining []kamajiv1alpha1.DrainingPod) {
+	var previous []kamajiv1alpha1.DrainingPod
+
+	if status != nil {
+		previous = status.DrainingPods
+	}
+
+	for _, pod := range draining {
+		if !containsDrainingPod(previous, pod.Name) {
+			d.EventRecorder.Eventf(tcp, corev1.EventTypeNormal, "DrainStarted", "Pod %s is draining its connections", pod.Name)
+		}
+	}
+
+	for _, pod := range previous {
+		if containsDrainingPod(draining, pod.Name) {
+			continue
+		}
+
+		duration := time.Since(pod.Since.Time)
+
+		d.EventRecorder.Eventf(tcp, corev1.EventTypeNormal, "DrainCompleted", "Pod %s completed the connections draining in %s", pod.Name, duration.Round(time.Second).String())
+
+		if d.EnableMetrics {
+			drainDurationHistogram.WithLabelValues(tcp.GetNamespace(), tcp.GetName()).Observe(duration.Seconds())
+		}
+	}
+}
+
+func (d *ControlPlaneDrain) deleteMetrics(namespacedName k8stypes.NamespacedName) {
+	if !d.EnableMetrics {
+		return
+	}
+
+	drainingPodsGauge.DeleteLabelValues(namespacedName.Namespace, namespacedName.Name)
+	drainDurationHistogram.DeleteLabelValues(namespacedName.Namespace, namespacedName.Name)
+}
+
+func containsDrainingPod(pods []kamajiv1alpha1.DrainingPod, name string) bool {
+	for _, pod := range pods {
+		if pod.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (d *ControlPlaneDrain) SetupWithManager(mgr controllerruntime.Manager) error {
+	if d.EnableMetrics {
+		metrics.Registry.MustRegister(drainingPodsGauge, drainDurationHistogram)
+	}
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("controlplane-drain").
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(func(_ context.Context, object client.Object) []reconcile.Request {
+			return []reconcile.Request{
+				{
+					NamespacedName: k8stypes.NamespacedName{
+						Namespace: object.GetNamespace(),
+						Name:      object.GetLabels()[constants.ControlPlaneLabelKey],
+					},
+				},
+			}
+		}), builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			labels := object.GetLabels()
+
+			return labels[constants.ControlPlaneLabelResource] == constants.ControlPlaneDeploymentLabelValue && len(labels[constants.ControlPlaneLabelKey]) > 0
+		}), predicate.Funcs{
+			UpdateFunc: func(updateEvent event.UpdateEvent) bool {
+				return updateEvent.ObjectOld.GetDeletionTimestamp() == nil && updateEvent.ObjectNew.GetDeletionTimestamp() != nil
+			},
+		})).
+		Complete(d)
+}
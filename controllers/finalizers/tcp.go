@@ -8,4 +8,6 @@ const (
 	DatastoreFinalizer       = "finalizer.kamaji.clastix.io"
 	DatastoreSecretFinalizer = "finalizer.kamaji.clastix.io/datastore-secret"
 	SootFinalizer            = "finalizer.kamaji.clastix.io/soot"
+	// DataStoreInUseFinalizer prevents the deletion of a DataStore still used by Tenant Control Planes.
+	DataStoreInUseFinalizer = "finalizer.kamaji.clastix.io/datastore"
 )
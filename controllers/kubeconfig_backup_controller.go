@@ -0,0 +1,154 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// KubeconfigBackup aggregates the admin kubeconfig of all the Tenant Control Planes in a single Secret,
+// meant to be backed up for the fleet recovery: the bundle is refreshed upon any kubeconfig rotation,
+// and periodically according to the given interval.
+// The kubeconfig contents are never logged, and they're encrypted with the key stored in the EncryptionKey Secret,
+// since the bundle grants the administrative access to the whole fleet.
+type KubeconfigBackup struct {
+	Client client.Client
+	// Secret is the bundle Secret, the keys are in the <namespace>_<name>.conf form.
+	Secret k8stypes.NamespacedName
+	// EncryptionKey is the Secret storing the AES-256 key the kubeconfigs are encrypted with, under the key data key:
+	// each bundle value is the AES-256-GCM ciphertext, prefixed by its nonce.
+	EncryptionKey k8stypes.NamespacedName
+	Interval      time.Duration
+}
+
+const (
+	// KubeconfigBackupEncryptionKey is the data key of the EncryptionKey Secret.
+	KubeconfigBackupEncryptionKey = "key"
+	// KubeconfigBackupEncryptionAnnotation reports the algorithm the bundle values are encrypted with.
+	KubeconfigBackupEncryptionAnnotation = "kamaji.clastix.io/encryption"
+)
+
+func (k *KubeconfigBackup) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	tcpList := &kamajiv1alpha1.TenantControlPlaneList{}
+	if err := k.Client.List(ctx, tcpList); err != nil {
+		logger.Error(err, "cannot list Tenant Control Planes")
+
+		return reconcile.Result{}, err
+	}
+
+	keySecret := &corev1.Secret{}
+	if err := k.Client.Get(ctx, k.EncryptionKey, keySecret); err != nil {
+		logger.Error(err, "cannot retrieve the kubeconfig backup encryption key", "namespace", k.EncryptionKey.Namespace, "name", k.EncryptionKey.Name)
+
+		return reconcile.Result{}, err
+	}
+
+	key := keySecret.Data[KubeconfigBackupEncryptionKey]
+	if len(key) != crypto.EncryptionKeySize {
+		err := fmt.Errorf("the kubeconfig backup encryption key must be of %d bytes, got %d", crypto.EncryptionKeySize, len(key))
+		logger.Error(err, "cannot encrypt the kubeconfig backup", "namespace", k.EncryptionKey.Namespace, "name", k.EncryptionKey.Name)
+
+		return reconcile.Result{}, err
+	}
+
+	bundle := make(map[string][]byte, len(tcpList.Items))
+
+	for _, tcp := range tcpList.Items {
+		secretName := tcp.Status.KubeConfig.Admin.SecretName
+		if len(secretName) == 0 {
+			continue
+		}
+
+		secret := &corev1.Secret{}
+		if err := k.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tcp.GetNamespace(), Name: secretName}, secret); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			logger.Error(err, "cannot retrieve the admin kubeconfig", "namespace", tcp.GetNamespace(), "name", tcp.GetName())
+
+			return reconcile.Result{}, err
+		}
+
+		kubeconfig, ok := secret.Data[kubeadmconstants.AdminKubeConfigFileName]
+		if !ok {
+			continue
+		}
+
+		encrypted, err := crypto.EncryptContent(key, kubeconfig)
+		if err != nil {
+			logger.Error(err, "cannot encrypt the admin kubeconfig", "namespace", tcp.GetNamespace(), "name", tcp.GetName())
+
+			return reconcile.Result{}, err
+		}
+
+		bundle[fmt.Sprintf("%s_%s.conf", tcp.GetNamespace(), tcp.GetName())] = encrypted
+	}
+
+	backup := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      k.Secret.Name,
+			Namespace: k.Secret.Namespace,
+		},
+	}
+
+	result, err := utilities.CreateOrUpdateWithConflict(ctx, k.Client, backup, func() error {
+		backup.SetLabels(utilities.MergeMaps(backup.GetLabels(), map[string]string{
+			"kamaji.clastix.io/component": "kubeconfig-backup",
+		}))
+		backup.SetAnnotations(utilities.MergeMaps(backup.GetAnnotations(), map[string]string{
+			KubeconfigBackupEncryptionAnnotation: "aes-256-gcm",
+		}))
+
+		backup.Type = corev1.SecretTypeOpaque
+		backup.Data = bundle
+
+		return nil
+	})
+	if err != nil {
+		logger.Error(err, "cannot update the kubeconfig backup Secret")
+
+		return reconcile.Result{}, err
+	}
+
+	logger.Info("kubeconfig backup reconciled", "result", result, "count", len(bundle))
+
+	return reconcile.Result{RequeueAfter: k.Interval}, nil
+}
+
+func (k *KubeconfigBackup) SetupWithManager(mgr controllerruntime.Manager) error {
+	// All the events are mapped to the bundle Secret, since it's reconciled as a whole.
+	enqueueBundle := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: k.Secret}}
+	})
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("kubeconfig-backup").
+		Watches(&kamajiv1alpha1.TenantControlPlane{}, enqueueBundle).
+		Watches(&corev1.Secret{}, enqueueBundle, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return object.GetNamespace() == k.EncryptionKey.Namespace && object.GetName() == k.EncryptionKey.Name
+		}))).
+		Complete(k)
+}
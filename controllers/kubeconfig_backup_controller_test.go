@@ -0,0 +1,107 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+)
+
+func TestKubeconfigBackup(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: "tcp"}}
+	tcp.Status.KubeConfig.Admin.SecretName = "tcp-admin-kubeconfig"
+
+	admin := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: "tcp-admin-kubeconfig"},
+		Data:       map[string][]byte{kubeadmconstants.AdminKubeConfigFileName: []byte("kubeconfig")},
+	}
+
+	key := bytes.Repeat([]byte{1}, crypto.EncryptionKeySize)
+
+	encryptionKey := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kamaji-system", Name: "kubeconfig-backup-key"},
+		Data:       map[string][]byte{KubeconfigBackupEncryptionKey: key},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tcp, admin, encryptionKey).Build()
+
+	k := &KubeconfigBackup{
+		Client:        c,
+		Secret:        k8stypes.NamespacedName{Namespace: "kamaji-system", Name: "kubeconfig-backup"},
+		EncryptionKey: k8stypes.NamespacedName{Namespace: "kamaji-system", Name: "kubeconfig-backup-key"},
+		Interval:      time.Hour,
+	}
+
+	if _, err := k.Reconcile(context.Background(), reconcile.Request{NamespacedName: k.Secret}); err != nil {
+		t.Fatal(err)
+	}
+
+	backup := &corev1.Secret{}
+	if err := c.Get(context.Background(), k.Secret, backup); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := backup.GetAnnotations()[KubeconfigBackupEncryptionAnnotation]; got != "aes-256-gcm" {
+		t.Fatalf("expected the encryption annotation %q, got %q", "aes-256-gcm", got)
+	}
+
+	encrypted, ok := backup.Data["tenants_tcp.conf"]
+	if !ok {
+		t.Fatalf("expected the kubeconfig of the Tenant Control Plane in the bundle, got the keys %v", backup.Data)
+	}
+
+	if bytes.Contains(encrypted, []byte("kubeconfig")) {
+		t.Fatal("expected the kubeconfig to be encrypted")
+	}
+
+	kubeconfig, err := crypto.DecryptContent(key, encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(kubeconfig) != "kubeconfig" {
+		t.Fatalf("expected the kubeconfig %q, got %q", "kubeconfig", kubeconfig)
+	}
+
+	// The bundle is not exported without a valid encryption key.
+	encryptionKey.Data[KubeconfigBackupEncryptionKey] = key[:16]
+	if err = c.Update(context.Background(), encryptionKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = k.Reconcile(context.Background(), reconcile.Request{NamespacedName: k.Secret}); err == nil {
+		t.Fatal("expected a short encryption key to be rejected")
+	}
+
+	if err = c.Delete(context.Background(), encryptionKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = k.Reconcile(context.Background(), reconcile.Request{NamespacedName: k.Secret}); err == nil {
+		t.Fatal("expected a missing encryption key to be rejected")
+	}
+}
@@ -4,7 +4,7 @@
 package controllers
 
 import (
-	"fmt"
+	"path/filepath"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
@@ -17,15 +17,20 @@ import (
 	builder "github.com/clastix/kamaji/internal/builders/controlplane"
 	"github.com/clastix/kamaji/internal/datastore"
 	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/automation"
 	ds "github.com/clastix/kamaji/internal/resources/datastore"
 	"github.com/clastix/kamaji/internal/resources/konnectivity"
+	"github.com/clastix/kamaji/internal/resources/monitoring"
 )
 
 type GroupResourceBuilderConfiguration struct {
-	client               client.Client
-	log                  logr.Logger
-	tcpReconcilerConfig  TenantControlPlaneReconcilerConfig
-	tenantControlPlane   kamajiv1alpha1.TenantControlPlane
+	client              client.Client
+	log                 logr.Logger
+	tcpReconcilerConfig TenantControlPlaneReconcilerConfig
+	tenantControlPlane  kamajiv1alpha1.TenantControlPlane
+	// tmpDirectory is the unique directory of the current reconciliation,
+	// where the resources write their intermediate files.
+	tmpDirectory         string
 	Connection           datastore.Connection
 	DataStore            kamajiv1alpha1.DataStore
 	KamajiNamespace      string
@@ -75,15 +80,16 @@ func getDefaultResources(config GroupResourceBuilderConfiguration) []resources.R
 	resources := getDataStoreMigratingResources(config.client, config.KamajiNamespace, config.KamajiMigrateImage, config.KamajiServiceAccount, config.KamajiService)
 	resources = append(resources, getUpgradeResources(config.client)...)
 	resources = append(resources, getKubernetesServiceResources(config.client)...)
-	resources = append(resources, getKubeadmConfigResources(config.client, getTmpDirectory(config.tcpReconcilerConfig.TmpBaseDirectory, config.tenantControlPlane), config.DataStore)...)
-	resources = append(resources, getKubernetesCertificatesResources(config.client, config.tcpReconcilerConfig, config.tenantControlPlane)...)
-	resources = append(resources, getKubeconfigResources(config.client, config.tcpReconcilerConfig, config.tenantControlPlane)...)
+	resources = append(resources, getKubeadmConfigResources(config.client, getTmpDirectory(config.tmpDirectory), config.DataStore)...)
+	resources = append(resources, getKubernetesCertificatesResources(config.client, config.tmpDirectory)...)
+	resources = append(resources, getKubeconfigResources(config.client, config.tmpDirectory)...)
 	resources = append(resources, getKubernetesStorageResources(config.client, config.Connection, config.DataStore)...)
 	resources = append(resources, getKonnectivityServerRequirementsResources(config.client)...)
 	resources = append(resources, getKubernetesDeploymentResources(config.client, config.tcpReconcilerConfig, config.DataStore)...)
 	resources = append(resources, getKonnectivityServerPatchResources(config.client)...)
 	resources = append(resources, getDataStoreMigratingCleanup(config.client, config.KamajiNamespace)...)
 	resources = append(resources, getKubernetesIngressResources(config.client)...)
+	resources = append(resources, getMonitoringResources(config.client)...)
 
 	return resources
 }
@@ -145,60 +151,66 @@ func getKubeadmConfigResources(c client.Client, tmpDirectory string, dataStore k
 	}
 }
 
-func getKubernetesCertificatesResources(c client.Client, tcpReconcilerConfig TenantControlPlaneReconcilerConfig, tenantControlPlane kamajiv1alpha1.TenantControlPlane) []resources.Resource {
+func getKubernetesCertificatesResources(c client.Client, tmpDirectory string) []resources.Resource {
 	return []resources.Resource{
 		&resources.CACertificate{
 			Client:       c,
-			TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+			TmpDirectory: getTmpDirectory(tmpDirectory),
+		},
+		&resources.ClusterCABundle{
+			Client: c,
 		},
 		&resources.FrontProxyCACertificate{
 			Client:       c,
-			TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+			TmpDirectory: getTmpDirectory(tmpDirectory),
 		},
 		&resources.SACertificate{
 			Client:       c,
-			TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+			TmpDirectory: getTmpDirectory(tmpDirectory),
 		},
 		&resources.APIServerCertificate{
 			Client:       c,
-			TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+			TmpDirectory: getTmpDirectory(tmpDirectory),
+		},
+		&resources.APIServerSNICertificates{
+			Client: c,
 		},
 		&resources.APIServerKubeletClientCertificate{
 			Client:       c,
-			TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+			TmpDirectory: getTmpDirectory(tmpDirectory),
 		},
 		&resources.FrontProxyClientCertificate{
 			Client:       c,
-			TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+			TmpDirectory: getTmpDirectory(tmpDirectory),
 		},
 	}
 }
 
-func getKubeconfigResources(c client.Client, tcpReconcilerConfig TenantControlPlaneReconcilerConfig, tenantControlPlane kamajiv1alpha1.TenantControlPlane) []resources.Resource {
+func getKubeconfigResources(c client.Client, tmpDirectory string) []resources.Resource {
 	return []resources.Resource{
 		&resources.KubeconfigResource{
 			Name:               "admin-kubeconfig",
 			Client:             c,
 			KubeConfigFileName: resources.AdminKubeConfigFileName,
-			TmpDirectory:       getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+			TmpDirectory:       getTmpDirectory(tmpDirectory),
 		},
 		&resources.KubeconfigResource{
 			Name:               "admin-kubeconfig",
 			Client:             c,
 			KubeConfigFileName: resources.SuperAdminKubeConfigFileName,
-			TmpDirectory:       getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+			TmpDirectory:       getTmpDirectory(tmpDirectory),
 		},
 		&resources.KubeconfigResource{
 			Name:               "controller-manager-kubeconfig",
 			Client:             c,
 			KubeConfigFileName: resources.ControllerManagerKubeConfigFileName,
-			TmpDirectory:       getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+			TmpDirectory:       getTmpDirectory(tmpDirectory),
 		},
 		&resources.KubeconfigResource{
 			Name:               "scheduler-kubeconfig",
 			Client:             c,
 			KubeConfigFileName: resources.SchedulerKubeConfigFileName,
-			TmpDirectory:       getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+			TmpDirectory:       getTmpDirectory(tmpDirectory),
 		},
 	}
 }
@@ -224,10 +236,33 @@ func getKubernetesStorageResources(c client.Client, dbConnection datastore.Conne
 
 func getKubernetesDeploymentResources(c client.Client, tcpReconcilerConfig TenantControlPlaneReconcilerConfig, dataStore kamajiv1alpha1.DataStore) []resources.Resource {
 	return []resources.Resource{
+		// The isolated components must be handled before the API Server Deployment, which aggregates their readiness.
+		&resources.KubernetesComponentDeploymentResource{
+			Client:    c,
+			DataStore: dataStore,
+			Component: builder.ControllerManagerComponent,
+		},
+		&resources.KubernetesComponentDeploymentResource{
+			Client:    c,
+			DataStore: dataStore,
+			Component: builder.SchedulerComponent,
+		},
 		&resources.KubernetesDeploymentResource{
 			Client:             c,
 			DataStore:          dataStore,
 			KineContainerImage: tcpReconcilerConfig.KineContainerImage,
+			KinePullPolicy:     tcpReconcilerConfig.KinePullPolicy,
+			KineResources:      tcpReconcilerConfig.KineResourceRequirements,
+		},
+		&resources.KubernetesReadReplicasDeploymentResource{
+			Client:             c,
+			DataStore:          dataStore,
+			KineContainerImage: tcpReconcilerConfig.KineContainerImage,
+			KinePullPolicy:     tcpReconcilerConfig.KinePullPolicy,
+			KineResources:      tcpReconcilerConfig.KineResourceRequirements,
+		},
+		&resources.KubernetesReadReplicasServiceResource{
+			Client: c,
 		},
 	}
 }
@@ -240,6 +275,14 @@ func getKubernetesIngressResources(c client.Client) []resources.Resource {
 	}
 }
 
+func getMonitoringResources(c client.Client) []resources.Resource {
+	return []resources.Resource{
+		&monitoring.MetricsServiceResource{
+			Client: c,
+		},
+	}
+}
+
 func GetExternalKonnectivityResources(c client.Client) []resources.Resource {
 	return []resources.Resource{
 		&konnectivity.Agent{Client: c},
@@ -248,6 +291,21 @@ func GetExternalKonnectivityResources(c client.Client) []resources.Resource {
 	}
 }
 
+func GetExternalAutomationResources(c client.Client) []resources.Resource {
+	return []resources.Resource{
+		&automation.ClusterRoleBindingResource{Client: c},
+		&automation.ServiceAccountResource{Client: c},
+		&automation.TokenResource{Client: c},
+	}
+}
+
+func GetExternalMonitoringResources(c client.Client) []resources.Resource {
+	return []resources.Resource{
+		&monitoring.RBACResource{Client: c},
+		&monitoring.TokenResource{Client: c},
+	}
+}
+
 func getKonnectivityServerRequirementsResources(c client.Client) []resources.Resource {
 	return []resources.Resource{
 		&konnectivity.EgressSelectorConfigurationResource{Client: c},
@@ -267,6 +325,7 @@ func getNamespacedName(namespace string, name string) k8stypes.NamespacedName {
 	return k8stypes.NamespacedName{Namespace: namespace, Name: name}
 }
 
-func getTmpDirectory(base string, tenantControlPlane kamajiv1alpha1.TenantControlPlane) string {
-	return fmt.Sprintf("%s/%s/%s", base, tenantControlPlane.GetName(), uuid.New())
+// getTmpDirectory returns a unique directory, nested in the reconciliation one, where a resource can write its intermediate files.
+func getTmpDirectory(base string) string {
+	return filepath.Join(base, uuid.New().String())
 }
@@ -0,0 +1,77 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/addons"
+)
+
+type APFBootstrap struct {
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+
+	logger logr.Logger
+}
+
+func (a *APFBootstrap) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := a.GetTenantControlPlaneFunc()
+	if err != nil {
+		a.logger.Error(err, "cannot retrieve TenantControlPlane")
+
+		return reconcile.Result{}, err
+	}
+
+	a.logger.Info("start processing")
+
+	resource := &addons.APFBootstrap{Client: a.AdminClient}
+
+	result, handlingErr := resources.Handle(ctx, resource, tcp)
+	if handlingErr != nil {
+		a.logger.Error(handlingErr, "resource process failed", "resource", resource.GetName())
+
+		return reconcile.Result{}, handlingErr
+	}
+
+	if result == controllerutil.OperationResultNone {
+		a.logger.Info("reconciliation completed")
+
+		return reconcile.Result{}, nil
+	}
+
+	if err = utils.UpdateStatus(ctx, a.AdminClient, tcp, resource); err != nil {
+		a.logger.Error(err, "update status failed")
+
+		return reconcile.Result{}, err
+	}
+
+	a.logger.Info("reconciliation completed")
+
+	return reconcile.Result{}, nil
+}
+
+func (a *APFBootstrap) SetupWithManager(mgr manager.Manager) error {
+	a.logger = mgr.GetLogger().WithName(addons.APFBootstrapResourceName)
+	a.TriggerChannel = make(chan event.GenericEvent)
+	// The API Priority and Fairness objects are not watched since the served flowcontrol.apiserver.k8s.io
+	// API version depends on the Tenant Control Plane one: the drift is reverted upon each trigger.
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named(addons.APFBootstrapResourceName).
+		WatchesRawSource(&source.Channel{Source: a.TriggerChannel}, &handler.EnqueueRequestForObject{}).
+		Complete(a)
+}
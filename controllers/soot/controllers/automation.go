@@ -0,0 +1,106 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/clastix/kamaji/controllers"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/automation"
+)
+
+type Automation struct {
+	logger logr.Logger
+
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+}
+
+func (a *Automation) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := a.GetTenantControlPlaneFunc()
+	if err != nil {
+		a.logger.Error(err, "cannot retrieve TenantControlPlane")
+
+		return reconcile.Result{}, err
+	}
+
+	for _, resource := range controllers.GetExternalAutomationResources(a.AdminClient) {
+		a.logger.Info("start processing", "resource", resource.GetName())
+
+		result, handlingErr := resources.Handle(ctx, resource, tcp)
+		if handlingErr != nil {
+			a.logger.Error(handlingErr, "resource process failed", "resource", resource.GetName())
+
+			return reconcile.Result{}, handlingErr
+		}
+
+		if result == controllerutil.OperationResultNone {
+			a.logger.Info("resource processed", "resource", resource.GetName())
+
+			continue
+		}
+
+		if err = utils.UpdateStatus(ctx, a.AdminClient, tcp, resource); err != nil {
+			a.logger.Error(err, "update status failed", "resource", resource.GetName())
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	a.logger.Info("reconciliation completed")
+	// The issued token has a limited lifetime:
+	// enqueuing back the request to rotate it before its expiration.
+	if tcp.Spec.ControlPlane.Automation != nil && tcp.Status.Automation != nil && !tcp.Status.Automation.ExpirationTimestamp.IsZero() {
+		rotation := automation.RotationTime(tcp, tcp.Status.Automation.ExpirationTimestamp.Time)
+
+		return reconcile.Result{RequeueAfter: max(time.Until(rotation), time.Second)}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (a *Automation) SetupWithManager(mgr manager.Manager) error {
+	a.logger = mgr.GetLogger().WithName("automation")
+	a.TriggerChannel = make(chan event.GenericEvent)
+
+	isManaged := func(name string) func(object client.Object) bool {
+		return func(object client.Object) bool {
+			return object.GetLabels()[constants.ControlPlaneLabelResource] == name
+		}
+	}
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		For(&corev1.ServiceAccount{}, builder.WithPredicates(predicate.NewPredicateFuncs(isManaged((&automation.ServiceAccountResource{}).GetName())))).
+		WatchesRawSource(source.Kind(mgr.GetCache(), &rbacv1.ClusterRoleBinding{}), handler.EnqueueRequestsFromMapFunc(func(_ context.Context, object client.Object) []reconcile.Request {
+			return []reconcile.Request{
+				{
+					NamespacedName: types.NamespacedName{
+						Name: object.GetName(),
+					},
+				},
+			}
+		}), builder.WithPredicates(predicate.NewPredicateFuncs(isManaged((&automation.ClusterRoleBindingResource{}).GetName())))).
+		WatchesRawSource(&source.Channel{Source: a.TriggerChannel}, &handler.EnqueueRequestForObject{}).
+		Complete(a)
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/addons"
+)
+
+type Defaults struct {
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+
+	logger logr.Logger
+}
+
+func (d *Defaults) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := d.GetTenantControlPlaneFunc()
+	if err != nil {
+		d.logger.Error(err, "cannot retrieve TenantControlPlane")
+
+		return reconcile.Result{}, err
+	}
+
+	d.logger.Info("start processing")
+
+	resource := &addons.Defaults{Client: d.AdminClient}
+
+	result, handlingErr := resources.Handle(ctx, resource, tcp)
+	if handlingErr != nil {
+		d.logger.Error(handlingErr, "resource process failed", "resource", resource.GetName())
+
+		return reconcile.Result{}, handlingErr
+	}
+
+	if result == controllerutil.OperationResultNone {
+		d.logger.Info("reconciliation completed")
+
+		return reconcile.Result{}, nil
+	}
+
+	if err = utils.UpdateStatus(ctx, d.AdminClient, tcp, resource); err != nil {
+		d.logger.Error(err, "update status failed")
+
+		return reconcile.Result{}, err
+	}
+
+	d.logger.Info("reconciliation completed")
+
+	return reconcile.Result{}, nil
+}
+
+func (d *Defaults) SetupWithManager(mgr manager.Manager) error {
+	d.logger = mgr.GetLogger().WithName("defaults")
+	d.TriggerChannel = make(chan event.GenericEvent)
+
+	isPolicyObject := builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return object.GetLabels()[constants.ControlPlaneLabelResource] == addons.DefaultsResourceName
+	}))
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		For(&schedulingv1.PriorityClass{}, isPolicyObject).
+		Watches(&corev1.LimitRange{}, &handler.EnqueueRequestForObject{}, isPolicyObject).
+		Watches(&corev1.ResourceQuota{}, &handler.EnqueueRequestForObject{}, isPolicyObject).
+		WatchesRawSource(&source.Channel{Source: d.TriggerChannel}, &handler.EnqueueRequestForObject{}).
+		Complete(d)
+}
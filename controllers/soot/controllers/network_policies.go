@@ -0,0 +1,84 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/addons"
+)
+
+type NetworkPolicies struct {
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+
+	logger logr.Logger
+}
+
+func (n *NetworkPolicies) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := n.GetTenantControlPlaneFunc()
+	if err != nil {
+		n.logger.Error(err, "cannot retrieve TenantControlPlane")
+
+		return reconcile.Result{}, err
+	}
+
+	n.logger.Info("start processing")
+
+	resource := &addons.NetworkPolicies{Client: n.AdminClient}
+
+	result, handlingErr := resources.Handle(ctx, resource, tcp)
+	if handlingErr != nil {
+		n.logger.Error(handlingErr, "resource process failed", "resource", resource.GetName())
+
+		return reconcile.Result{}, handlingErr
+	}
+
+	if result == controllerutil.OperationResultNone {
+		n.logger.Info("reconciliation completed")
+
+		return reconcile.Result{}, nil
+	}
+
+	if err = utils.UpdateStatus(ctx, n.AdminClient, tcp, resource); err != nil {
+		n.logger.Error(err, "update status failed")
+
+		return reconcile.Result{}, err
+	}
+
+	n.logger.Info("reconciliation completed")
+
+	return reconcile.Result{}, nil
+}
+
+func (n *NetworkPolicies) SetupWithManager(mgr manager.Manager) error {
+	n.logger = mgr.GetLogger().WithName("network-policies")
+	n.TriggerChannel = make(chan event.GenericEvent)
+
+	isNetworkPolicy := builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return object.GetLabels()[constants.ControlPlaneLabelResource] == addons.NetworkPoliciesResourceName
+	}))
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		For(&networkingv1.NetworkPolicy{}, isNetworkPolicy).
+		WatchesRawSource(&source.Channel{Source: n.TriggerChannel}, &handler.EnqueueRequestForObject{}).
+		Complete(n)
+}
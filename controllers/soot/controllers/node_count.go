@@ -0,0 +1,134 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+)
+
+// NodeCount keeps track of the number of nodes registered in the Tenant Control Plane,
+// setting the LargeTenant condition once the configured threshold is crossed.
+type NodeCount struct {
+	AdminClient               client.Client
+	EventRecorder             record.EventRecorder
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+
+	logger       logr.Logger
+	tenantClient client.Client
+}
+
+func (n *NodeCount) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	nodes := &corev1.NodeList{}
+	if err := n.tenantClient.List(ctx, nodes); err != nil {
+		n.logger.Error(err, "cannot list Tenant Control Plane nodes")
+
+		return reconcile.Result{}, err
+	}
+
+	count := int32(len(nodes.Items))
+
+	var tcp *kamajiv1alpha1.TenantControlPlane
+
+	var transition *metav1.Condition
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() (err error) {
+		if tcp, err = n.GetTenantControlPlaneFunc(); err != nil {
+			return err
+		}
+
+		previous := meta.FindStatusCondition(tcp.Status.Conditions, kamajiv1alpha1.LargeTenantCondition)
+		changed := tcp.Status.NodeCount != count
+
+		tcp.Status.NodeCount = count
+
+		transition = nil
+
+		switch thresholds := tcp.Spec.NodeCountThresholds; {
+		case thresholds == nil:
+			changed = meta.RemoveStatusCondition(&tcp.Status.Conditions, kamajiv1alpha1.LargeTenantCondition) || changed
+		default:
+			condition := metav1.Condition{
+				Type:               kamajiv1alpha1.LargeTenantCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             "BelowThreshold",
+				Message:            fmt.Sprintf("%d nodes registered, below the threshold of %d", count, thresholds.LargeTenant),
+				ObservedGeneration: tcp.GetGeneration(),
+			}
+
+			if count >= thresholds.LargeTenant {
+				condition.Status = metav1.ConditionTrue
+				condition.Reason = "ThresholdCrossed"
+				condition.Message = fmt.Sprintf("%d nodes registered, crossing the threshold of %d", count, thresholds.LargeTenant)
+			}
+
+			// Emitting an event upon threshold crossing, or when getting back below it.
+			if (previous == nil && condition.Status == metav1.ConditionTrue) || (previous != nil && previous.Status != condition.Status) {
+				transition = &condition
+			}
+
+			changed = meta.SetStatusCondition(&tcp.Status.Conditions, condition) || changed
+		}
+
+		if !changed {
+			return nil
+		}
+
+		return n.AdminClient.Status().Update(ctx, tcp)
+	})
+	if err != nil {
+		n.logger.Error(err, "cannot update the node count status")
+
+		return reconcile.Result{}, err
+	}
+
+	if transition != nil {
+		eventType := corev1.EventTypeNormal
+		if transition.Status == metav1.ConditionTrue {
+			eventType = corev1.EventTypeWarning
+		}
+
+		n.EventRecorder.Event(tcp, eventType, transition.Reason, transition.Message)
+	}
+
+	n.logger.Info("reconciliation completed", "nodes", count)
+
+	return reconcile.Result{}, nil
+}
+
+func (n *NodeCount) SetupWithManager(mgr manager.Manager) error {
+	n.logger = mgr.GetLogger().WithName("node_count")
+	n.tenantClient = mgr.GetClient()
+	n.TriggerChannel = make(chan event.GenericEvent)
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		// Nodes are updated frequently by the kubelet heartbeats, only the creation and deletion events are relevant.
+		For(&corev1.Node{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(event.UpdateEvent) bool {
+				return false
+			},
+		})).
+		WatchesRawSource(&source.Channel{Source: n.TriggerChannel}, &handler.EnqueueRequestForObject{}).
+		Complete(n)
+}
@@ -0,0 +1,232 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	pointer "k8s.io/utils/ptr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	reachabilityProbeName = "kamaji-reachability-probe"
+	// reachabilityProbeCommand queries the API Server through the kubernetes Service,
+	// verifying both the in-cluster DNS resolution and the Service endpoints.
+	reachabilityProbeCommand = "curl --silent --show-error --fail --max-time 10 " +
+		"--cacert /var/run/secrets/kubernetes.io/serviceaccount/ca.crt " +
+		"--header \"Authorization: Bearer $(cat /var/run/secrets/kubernetes.io/serviceaccount/token)\" " +
+		"https://kubernetes.default.svc/livez"
+)
+
+// InClusterReachability periodically runs a Job in the Tenant Cluster verifying the API Server is reachable
+// from the Tenant network, reporting the outcome with the InClusterReachability condition.
+type InClusterReachability struct {
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+
+	logger       logr.Logger
+	tenantClient client.Client
+	lastProbe    time.Time
+}
+
+func (r *InClusterReachability) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := r.GetTenantControlPlaneFunc()
+	if err != nil {
+		r.logger.Error(err, "cannot retrieve TenantControlPlane")
+
+		return reconcile.Result{}, err
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      reachabilityProbeName,
+			Namespace: metav1.NamespaceSystem,
+		},
+	}
+
+	spec := tcp.Spec.Addons.InClusterReachability
+	if spec == nil {
+		if err = r.tenantClient.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !k8serrors.IsNotFound(err) {
+			r.logger.Error(err, "cannot delete the reachability probe Job")
+
+			return reconcile.Result{}, err
+		}
+
+		return reconcile.Result{}, r.setCondition(ctx, nil)
+	}
+
+	if err = r.tenantClient.Get(ctx, client.ObjectKeyFromObject(job), job); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			r.logger.Error(err, "cannot retrieve the reachability probe Job")
+
+			return reconcile.Result{}, err
+		}
+
+		if remaining := spec.Interval.Duration - time.Since(r.lastProbe); !r.lastProbe.IsZero() && remaining > 0 {
+			return reconcile.Result{RequeueAfter: remaining}, nil
+		}
+
+		if err = r.tenantClient.Create(ctx, r.probeJob(tcp, spec)); err != nil {
+			r.logger.Error(err, "cannot create the reachability probe Job")
+
+			return reconcile.Result{}, err
+		}
+
+		r.lastProbe = time.Now()
+
+		return reconcile.Result{}, nil
+	}
+
+	condition := r.probeOutcome(job)
+	// The Job is still running, its completion will trigger a new reconciliation.
+	if condition == nil {
+		return reconcile.Result{}, nil
+	}
+
+	condition.ObservedGeneration = tcp.GetGeneration()
+
+	if err = r.setCondition(ctx, condition); err != nil {
+		r.logger.Error(err, "cannot update the reachability condition")
+
+		return reconcile.Result{}, err
+	}
+
+	if err = r.tenantClient.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !k8serrors.IsNotFound(err) {
+		r.logger.Error(err, "cannot delete the reachability probe Job")
+
+		return reconcile.Result{}, err
+	}
+
+	r.logger.Info("reconciliation completed", "reachable", condition.Status)
+
+	return reconcile.Result{RequeueAfter: spec.Interval.Duration}, nil
+}
+
+// probeOutcome returns the reachability condition according to the probe Job result,
+// or nil if the Job is not completed yet.
+func (r *InClusterReachability) probeOutcome(job *batchv1.Job) *metav1.Condition {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch c.Type { //nolint:exhaustive
+		case batchv1.JobComplete:
+			return &metav1.Condition{
+				Type:    kamajiv1alpha1.InClusterReachabilityCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  "Reachable",
+				Message: "the API Server is reachable through the kubernetes Service",
+			}
+		case batchv1.JobFailed:
+			return &metav1.Condition{
+				Type:    kamajiv1alpha1.InClusterReachabilityCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  "Unreachable",
+				Message: fmt.Sprintf("the API Server is not reachable through the kubernetes Service: %s", c.Message),
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *InClusterReachability) setCondition(ctx context.Context, condition *metav1.Condition) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		tcp, err := r.GetTenantControlPlaneFunc()
+		if err != nil {
+			return err
+		}
+
+		var changed bool
+
+		switch {
+		case condition == nil:
+			changed = meta.RemoveStatusCondition(&tcp.Status.Conditions, kamajiv1alpha1.InClusterReachabilityCondition)
+		default:
+			changed = meta.SetStatusCondition(&tcp.Status.Conditions, *condition)
+		}
+
+		if !changed {
+			return nil
+		}
+
+		return r.AdminClient.Status().Update(ctx, tcp)
+	})
+}
+
+func (r *InClusterReachability) probeJob(tcp *kamajiv1alpha1.TenantControlPlane, spec *kamajiv1alpha1.InClusterReachabilitySpec) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      reachabilityProbeName,
+			Namespace: metav1.NamespaceSystem,
+			Labels:    utilities.KamajiLabels(tcp.GetName(), reachabilityProbeName),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          pointer.To(int32(2)),
+			ActiveDeadlineSeconds: pointer.To(int64(120)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: utilities.KamajiLabels(tcp.GetName(), reachabilityProbeName),
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Tolerations: []corev1.Toleration{
+						{
+							Key:      "CriticalAddonsOnly",
+							Operator: corev1.TolerationOpExists,
+						},
+					},
+					NodeSelector: map[string]string{
+						"kubernetes.io/os": "linux",
+					},
+					Containers: []corev1.Container{
+						{
+							Name:    "probe",
+							Image:   spec.Image,
+							Command: []string{"sh", "-c", reachabilityProbeCommand},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *InClusterReachability) SetupWithManager(mgr manager.Manager) error {
+	r.logger = mgr.GetLogger().WithName("in_cluster_reachability")
+	r.tenantClient = mgr.GetClient()
+	r.TriggerChannel = make(chan event.GenericEvent)
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		For(&batchv1.Job{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return object.GetNamespace() == metav1.NamespaceSystem && object.GetLabels()[constants.ControlPlaneLabelResource] == reachabilityProbeName
+		}))).
+		WatchesRawSource(&source.Channel{Source: r.TriggerChannel}, &handler.EnqueueRequestForObject{}).
+		Complete(r)
+}
@@ -9,6 +9,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -49,6 +50,7 @@ type Manager struct {
 	MigrateServiceName      string
 	MigrateServiceNamespace string
 	AdminClient             client.Client
+	EventRecorder           record.EventRecorder
 }
 
 // retrieveTenantControlPlane is the function used to let an underlying controller of the soot manager
@@ -128,8 +130,8 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 			// The TenantControlPlane CA has been rotated, it means the running manager
 			// must be restarted to avoid certificate signed by unknown authority errors.
 			return reconcile.Result{}, m.cleanup(ctx, request, tcp)
-		case tcpStatus == kamajiv1alpha1.VersionNotReady:
-			// The TenantControlPlane is in non-ready mode, or marked for deletion:
+		case tcpStatus == kamajiv1alpha1.VersionNotReady, tcpStatus == kamajiv1alpha1.VersionSuspended:
+			// The TenantControlPlane is in non-ready, or suspended, mode, or marked for deletion:
 			// we don't want to pollute with messages due to broken connection.
 			// Once the TCP will be ready again, the event will be intercepted and the manager started back.
 			return reconcile.Result{}, m.cleanup(ctx, request, tcp)
@@ -143,7 +145,7 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 	}
 	// No need to start a soot manager if the TenantControlPlane is not ready:
 	// enqueuing back is not required since we're going to get that event once ready.
-	if tcpStatus == kamajiv1alpha1.VersionNotReady || tcpStatus == kamajiv1alpha1.VersionCARotating {
+	if tcpStatus == kamajiv1alpha1.VersionNotReady || tcpStatus == kamajiv1alpha1.VersionSuspended || tcpStatus == kamajiv1alpha1.VersionCARotating {
 		log.FromContext(ctx).Info("skipping start of the soot manager for a not ready instance")
 
 		return reconcile.Result{}, nil
@@ -210,6 +212,79 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 		return reconcile.Result{}, err
 	}
 
+	automation := &controllers.Automation{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = automation.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	monitoring := &controllers.Monitoring{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = monitoring.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	nodeCount := &controllers.NodeCount{
+		AdminClient:               m.AdminClient,
+		EventRecorder:             m.EventRecorder,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = nodeCount.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	cni := &controllers.CNI{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = cni.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	defaults := &controllers.Defaults{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = defaults.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	networkPolicies := &controllers.NetworkPolicies{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = networkPolicies.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	webhooks := &controllers.Webhooks{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = webhooks.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	apfBootstrap := &controllers.APFBootstrap{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = apfBootstrap.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	reachability := &controllers.InClusterReachability{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = reachability.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	kubeProxy := &controllers.KubeProxy{
 		AdminClient:               m.AdminClient,
 		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
@@ -284,6 +359,15 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 		triggers: []chan event.GenericEvent{
 			migrate.TriggerChannel,
 			konnectivityAgent.TriggerChannel,
+			automation.TriggerChannel,
+			monitoring.TriggerChannel,
+			nodeCount.TriggerChannel,
+			cni.TriggerChannel,
+			defaults.TriggerChannel,
+			networkPolicies.TriggerChannel,
+			webhooks.TriggerChannel,
+			apfBootstrap.TriggerChannel,
+			reachability.TriggerChannel,
 			kubeProxy.TriggerChannel,
 			coreDNS.TriggerChannel,
 			uploadKubeadmConfig.TriggerChannel,
@@ -6,17 +6,24 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/juju/mutex/v2"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -27,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -34,11 +42,17 @@ import (
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/controllers/finalizers"
 	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/datastore"
 	kamajierrors "github.com/clastix/kamaji/internal/errors"
 	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/tracing"
+	"github.com/clastix/kamaji/internal/upgrade"
 )
 
+// dataStoreInitRequeueInterval is the delay before checking again a DataStore not reachable yet during the initial provisioning.
+const dataStoreInitRequeueInterval = 5 * time.Second
+
 // TenantControlPlaneReconciler reconciles a TenantControlPlane object.
 type TenantControlPlaneReconciler struct {
 	Client                  client.Client
@@ -50,6 +64,7 @@ type TenantControlPlaneReconciler struct {
 	KamajiService           string
 	KamajiMigrateImage      string
 	MaxConcurrentReconciles int
+	EventRecorder           record.EventRecorder
 	// CertificateChan is the channel used by the CertificateLifecycleController that is checking for
 	// certificates and kubeconfig user certs validity: a generic event for the given TCP will be triggered
 	// once the validity threshold for the given certificate is reached.
@@ -61,9 +76,33 @@ type TenantControlPlaneReconciler struct {
 // TenantControlPlaneReconcilerConfig gives the necessary configuration for TenantControlPlaneReconciler.
 type TenantControlPlaneReconcilerConfig struct {
 	ReconcileTimeout     time.Duration
+	FinalizerTimeout     time.Duration
 	DefaultDataStoreName string
 	KineContainerImage   string
 	TmpBaseDirectory     string
+	// KinePullPolicy is the pull policy of the Kine containers, unless set by the Tenant Control Plane:
+	// when empty, it's inferred from the image tag.
+	KinePullPolicy corev1.PullPolicy
+	// KineResourceRequirements are the resource requirements of the Kine container, unless set by the Tenant Control Plane.
+	KineResourceRequirements corev1.ResourceRequirements
+	// RetainTmpOnError keeps the temporary directory of a failed reconciliation for debugging purposes,
+	// rather than removing it along with the intermediate files.
+	RetainTmpOnError bool
+	// ConfigurationSnapshot tracks the effective configuration of the Tenant Control Planes in their status,
+	// exposing the number of changes as a metric.
+	ConfigurationSnapshot bool
+	// LoadBalancerRequeueInterval is the initial requeue delay when waiting for the LoadBalancer address,
+	// doubling up to LoadBalancerRequeueMaxInterval.
+	LoadBalancerRequeueInterval    time.Duration
+	LoadBalancerRequeueMaxInterval time.Duration
+	// LoadBalancerPendingTimeout is the time after which a Warning event is emitted for a pending LoadBalancer address.
+	LoadBalancerPendingTimeout time.Duration
+	// DataStoreInitGrace is the time after the creation during which the DataStore connection failures
+	// are considered part of the initial provisioning, rather than errors.
+	DataStoreInitGrace time.Duration
+	// DryRun performs the validations and computes the conditions, without persisting any change:
+	// the writes are submitted in dry-run mode, and the resources, along with the DataStore, are not reconciled.
+	DryRun bool
 }
 
 //+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplanes,verbs=get;list;watch;create;update;patch;delete
@@ -74,15 +113,24 @@ type TenantControlPlaneReconcilerConfig struct {
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 
-func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	log := log.FromContext(ctx)
 
 	var cancelFn context.CancelFunc
 	ctx, cancelFn = context.WithTimeout(ctx, r.Config.ReconcileTimeout)
 	defer cancelFn()
 
+	ctx, span := tracing.Tracer().Start(ctx, "TenantControlPlane.Reconcile", trace.WithAttributes(
+		attribute.String("tenantcontrolplane.namespace", req.Namespace),
+		attribute.String("tenantcontrolplane.name", req.Name),
+	))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	tenantControlPlane, err := r.getTenantControlPlane(ctx, req.NamespacedName)()
 	if err != nil {
 		if apimachineryerrors.IsNotFound(err) {
@@ -95,6 +143,19 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 		return ctrl.Result{}, err
 	}
+	// The calls withdrawn by the timeout could hide it behind their own errors, or be ignored:
+	// reporting it, and returning the context error to let the request be retried.
+	defer func() {
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return
+		}
+
+		r.EventRecorder.Eventf(tenantControlPlane, corev1.EventTypeWarning, "ReconcileTimeout", "the reconciliation has not been completed within %s", r.Config.ReconcileTimeout.String())
+
+		if err == nil {
+			err = ctx.Err()
+		}
+	}()
 
 	releaser, err := mutex.Acquire(r.mutexSpec(tenantControlPlane))
 	if err != nil {
@@ -120,23 +181,87 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	if markedToBeDeleted && !controllerutil.ContainsFinalizer(tenantControlPlane, finalizers.DatastoreFinalizer) {
 		return ctrl.Result{}, nil
 	}
+	// The DataStore clean-up could be blocked if the DataStore is unreachable:
+	// once the finalizer timeout expired, the clean-up is skipped to let the deletion proceed.
+	if markedToBeDeleted && r.isFinalizerTimeoutExpired(tenantControlPlane) {
+		log.Info("finalizer timeout expired, skipping the DataStore clean-up: data could be left behind",
+			"timeout", r.Config.FinalizerTimeout.String(), "datastore", tenantControlPlane.Status.Storage.DataStoreName, "schema", tenantControlPlane.Status.Storage.Setup.Schema)
+
+		r.EventRecorder.Eventf(tenantControlPlane, corev1.EventTypeWarning, "FinalizerTimeout",
+			"DataStore clean-up not completed within %s, removing the finalizer: data could be left behind in the DataStore %s", r.Config.FinalizerTimeout.String(), tenantControlPlane.Status.Storage.DataStoreName)
+
+		if err = r.RemoveFinalizer(ctx, tenantControlPlane); err != nil {
+			log.Error(err, "cannot remove the finalizer")
+
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	}
 	// Retrieving the DataStore to use for the current reconciliation
-	ds, err := r.dataStore(ctx, tenantControlPlane)
+	dsCtx, dsSpan := tracing.Tracer().Start(ctx, "datastore/resolve")
+
+	ds, err := r.dataStore(dsCtx, tenantControlPlane)
 	if err != nil {
+		tracing.End(dsSpan, err)
+		// The DataStore of a Tenant Control Plane marked for deletion could be no more created:
+		// there's nothing to clean up, thus the finalizer is removed to let the deletion proceed.
+		if apimachineryerrors.IsNotFound(err) && markedToBeDeleted {
+			if r.Config.DryRun {
+				log.Info("dry-run mode, skipping the clean-up")
+
+				return ctrl.Result{}, nil
+			}
+
+			log.Info("the DataStore is missing, skipping the DataStore clean-up", "datastore", r.dataStoreName(tenantControlPlane))
+
+			if err = r.RemoveFinalizer(ctx, tenantControlPlane); err != nil {
+				log.Error(err, "cannot remove the finalizer")
+
+				return ctrl.Result{}, err
+			}
+
+			return ctrl.Result{}, nil
+		}
+		// The reconciliation is triggered by the DataStore controller once the missing DataStore is created.
+		if apimachineryerrors.IsNotFound(err) {
+			return r.dataStoreNotFound(ctx, tenantControlPlane)
+		}
+
 		log.Error(err, "cannot retrieve the DataStore for the given instance")
 
 		return ctrl.Result{}, err
 	}
 
-	dsConnection, err := datastore.NewStorageConnection(ctx, r.Client, *ds)
-	if err != nil {
-		log.Error(err, "cannot generate the DataStore connection for the given instance")
+	if err = r.setDataStoreNotFoundCondition(ctx, tenantControlPlane, metav1.ConditionFalse, "Found", fmt.Sprintf("the DataStore %s exists", ds.GetName())); err != nil {
+		tracing.End(dsSpan, err)
+		log.Error(err, "cannot update the DataStore not found condition")
 
 		return ctrl.Result{}, err
 	}
+
+	// The Secrets referenced by the DataStore are read once, rather than for each content reference.
+	dsConnection, err := datastore.NewStorageConnection(dsCtx, datastore.NewContentClient(r.Client), *ds)
+	tracing.End(dsSpan, err)
+
+	if err != nil {
+		return r.dataStoreUnreachable(ctx, tenantControlPlane, err)
+	}
 	defer dsConnection.Close()
 
+	if err = r.setDataStoreReachableCondition(ctx, tenantControlPlane, metav1.ConditionTrue, "Reachable", "the DataStore is reachable"); err != nil {
+		log.Error(err, "cannot update the DataStore reachability condition")
+
+		return ctrl.Result{}, err
+	}
+
 	if markedToBeDeleted && controllerutil.ContainsFinalizer(tenantControlPlane, finalizers.DatastoreFinalizer) {
+		if r.Config.DryRun {
+			log.Info("dry-run mode, skipping the clean-up")
+
+			return ctrl.Result{}, nil
+		}
+
 		log.Info("marked for deletion, performing clean-up")
 
 		groupDeletableResourceBuilderConfiguration := GroupDeletableResourceBuilderConfiguration{
@@ -158,14 +283,72 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 		log.Info("resource deletions have been completed")
 
+		configurationChangesCounter.DeleteLabelValues(tenantControlPlane.GetNamespace(), tenantControlPlane.GetName())
+
 		return ctrl.Result{}, nil
 	}
 
+	// Ensuring the Control Plane components versions are complying with the version skew policy:
+	// the outcome is reported as a condition, and the reconciliation is halted until the skew is solved.
+	if skewErr := r.checkVersionSkew(ctx, tenantControlPlane); skewErr != nil {
+		var violation upgrade.VersionSkewError
+		if errors.As(skewErr, &violation) {
+			log.Info("version skew policy violated, skipping reconciliation", "reason", violation.Error())
+
+			return ctrl.Result{}, nil
+		}
+
+		log.Error(skewErr, "cannot check the version skew of the Control Plane components")
+
+		return ctrl.Result{}, skewErr
+	}
+
+	if err = r.checkQuarantine(ctx, tenantControlPlane); err != nil {
+		log.Error(err, "cannot update the quarantine condition")
+
+		return ctrl.Result{}, err
+	}
+
+	if err = r.checkSuspension(ctx, tenantControlPlane); err != nil {
+		log.Error(err, "cannot update the suspension condition")
+
+		return ctrl.Result{}, err
+	}
+
+	if err = r.checkKineImage(ctx, tenantControlPlane, *ds); err != nil {
+		log.Error(err, "cannot update the Kine image compatibility condition")
+
+		return ctrl.Result{}, err
+	}
+
+	if err = r.checkKineSchema(ctx, tenantControlPlane, *ds, dsConnection); err != nil {
+		log.Error(err, "cannot update the DataStore schema mismatch condition")
+
+		return ctrl.Result{}, err
+	}
+
+	if r.Config.DryRun {
+		log.Info("dry-run mode, skipping the resources reconciliation")
+
+		return ctrl.Result{}, nil
+	}
+
+	tmpDirectory, err := r.createTmpDirectory(tenantControlPlane)
+	if err != nil {
+		log.Error(err, "cannot create the temporary directory")
+
+		return ctrl.Result{}, err
+	}
+	defer func() {
+		r.cleanupTmpDirectory(log, tmpDirectory, err)
+	}()
+
 	groupResourceBuilderConfiguration := GroupResourceBuilderConfiguration{
 		client:               r.Client,
 		log:                  log,
 		tcpReconcilerConfig:  r.Config,
 		tenantControlPlane:   *tenantControlPlane,
+		tmpDirectory:         tmpDirectory,
 		Connection:           dsConnection,
 		DataStore:            *ds,
 		KamajiNamespace:      r.KamajiNamespace,
@@ -178,6 +361,10 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	for _, resource := range registeredResources {
 		result, err := resources.Handle(ctx, resource, tenantControlPlane)
 		if err != nil {
+			if errors.As(err, &kamajierrors.NonExposedLoadBalancerError{}) {
+				return r.waitForLoadBalancer(ctx, tenantControlPlane)
+			}
+
 			if kamajierrors.ShouldReconcileErrorBeIgnored(err) {
 				log.V(1).Info("sentinel error, enqueuing back request", "error", err.Error())
 
@@ -194,6 +381,10 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		}
 
 		if err = utils.UpdateStatus(ctx, r.Client, tenantControlPlane, resource); err != nil {
+			if errors.As(err, &kamajierrors.NonExposedLoadBalancerError{}) {
+				return r.waitForLoadBalancer(ctx, tenantControlPlane)
+			}
+
 			log.Error(err, "update of the resource failed", "resource", resource.GetName())
 
 			return ctrl.Result{}, err
@@ -201,6 +392,14 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 		log.Info(fmt.Sprintf("%s has been configured", resource.GetName()))
 
+		if service, ok := resource.(*resources.KubernetesServiceResource); ok && len(service.Drift()) > 0 {
+			r.EventRecorder.Eventf(tenantControlPlane, corev1.EventTypeWarning, "ServiceDrift", "the Service drifted from the desired state (enforced: %t): %s", tenantControlPlane.IsServiceEnforced(), service.Drift())
+		}
+
+		if _, ok := resource.(*resources.KubernetesDeploymentResource); ok && !tenantControlPlane.IsGarbageCollectorEnabled() {
+			r.EventRecorder.Event(tenantControlPlane, corev1.EventTypeWarning, "GarbageCollectorDisabled", "the garbage collector is disabled, the dependent objects are not deleted along with their owners in the Tenant Cluster")
+		}
+
 		if result == resources.OperationResultEnqueueBack {
 			log.Info("requested enqueuing back", "resources", resource.GetName())
 
@@ -210,9 +409,378 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	log.Info(fmt.Sprintf("%s has been reconciled", tenantControlPlane.GetName()))
 
+	if r.Config.ConfigurationSnapshot {
+		if err = r.updateConfigurationSnapshot(ctx, tenantControlPlane); err != nil {
+			log.Error(err, "cannot update the configuration snapshot")
+
+			return ctrl.Result{}, err
+		}
+	}
+	// Enqueuing back the request to remove the previous Service Account public key once the rotation overlap is expired.
+	if remaining := resources.ServiceAccountRotationRemainingOverlap(tenantControlPlane); remaining > 0 {
+		log.V(1).Info("Service Account signing key rotation overlapping, enqueuing back request", "after", remaining.String())
+
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// createTmpDirectory creates the temporary directory of the current reconciliation: the name is unique,
+// thus concurrent reconciliations of Tenant Control Planes sharing the same name are not clobbering each other.
+func (r *TenantControlPlaneReconciler) createTmpDirectory(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (string, error) {
+	if err := os.MkdirAll(r.Config.TmpBaseDirectory, os.FileMode(0o700)); err != nil {
+		return "", err
+	}
+
+	return os.MkdirTemp(r.Config.TmpBaseDirectory, fmt.Sprintf("%s-%s-", tenantControlPlane.GetNamespace(), tenantControlPlane.GetName()))
+}
+
+// cleanupTmpDirectory removes the temporary directory of the current reconciliation, unless it failed and
+// the intermediate files must be retained for debugging purposes.
+func (r *TenantControlPlaneReconciler) cleanupTmpDirectory(log logr.Logger, tmpDirectory string, reconcileErr error) {
+	if reconcileErr != nil && r.Config.RetainTmpOnError {
+		log.Info("reconciliation failed, retaining the temporary directory", "directory", tmpDirectory)
+
+		return
+	}
+
+	if err := os.RemoveAll(tmpDirectory); err != nil {
+		log.Error(err, "cannot remove the temporary directory", "directory", tmpDirectory)
+	}
+}
+
+// checkVersionSkew updates the VersionSkewViolation condition of the given Tenant Control Plane,
+// returning the skew error in case of violation.
+func (r *TenantControlPlaneReconciler) checkVersionSkew(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.VersionSkewViolationCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             "Compliant",
+		Message:            "Control Plane components versions are complying with the version skew policy",
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+	}
+
+	skewErr := upgrade.CheckVersionSkew(*tenantControlPlane)
+
+	var violation upgrade.VersionSkewError
+
+	switch {
+	case errors.As(skewErr, &violation):
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "OutOfSkew"
+		condition.Message = violation.Error()
+	case skewErr != nil:
+		return skewErr
+	}
+
+	if meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, condition) {
+		if err := r.Client.Status().Update(ctx, tenantControlPlane); err != nil {
+			return fmt.Errorf("cannot update the version skew condition: %w", err)
+		}
+	}
+
+	return skewErr
+}
+
+// checkQuarantine updates the Quarantined condition of the given Tenant Control Plane, emitting an event upon its
+// transitions: the condition is not reported for the Tenant Control Planes which have never been quarantined.
+func (r *TenantControlPlaneReconciler) checkQuarantine(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	current := meta.FindStatusCondition(tenantControlPlane.Status.Conditions, kamajiv1alpha1.QuarantinedCondition)
+	wasQuarantined := current != nil && current.Status == metav1.ConditionTrue
+	quarantined := tenantControlPlane.IsQuarantined()
+
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.QuarantinedCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             "Released",
+		Message:            "the Tenant Control Plane has been released from the quarantine",
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+	}
+
+	switch {
+	case quarantined && !wasQuarantined:
+		r.EventRecorder.Eventf(tenantControlPlane, corev1.EventTypeWarning, "Quarantined", "the Tenant Control Plane has been quarantined with the %s annotation, scaling down its control plane Pods", constants.QuarantineAnnotation)
+	case !quarantined && wasQuarantined:
+		r.EventRecorder.Event(tenantControlPlane, corev1.EventTypeNormal, "QuarantineReleased", "the Tenant Control Plane has been released from the quarantine, scaling up its control plane Pods")
+	case !quarantined && current == nil:
+		return nil
+	}
+
+	if quarantined {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "QuarantineRequested"
+		condition.Message = fmt.Sprintf("the control plane Pods are scaled down to zero until the %s annotation is removed", constants.QuarantineAnnotation)
+	}
+
+	if meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, condition) {
+		return r.Client.Status().Update(ctx, tenantControlPlane)
+	}
+
+	return nil
+}
+
+// checkSuspension updates the Suspended condition of the given Tenant Control Plane, emitting an event upon its
+// transitions: the condition is not reported for the Tenant Control Planes which have never been suspended.
+func (r *TenantControlPlaneReconciler) checkSuspension(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	current := meta.FindStatusCondition(tenantControlPlane.Status.Conditions, kamajiv1alpha1.SuspendedCondition)
+	wasSuspended := current != nil && current.Status == metav1.ConditionTrue
+	suspended := tenantControlPlane.IsSuspended()
+
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.SuspendedCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             "Resumed",
+		Message:            "the Tenant Control Plane has been resumed",
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+	}
+
+	switch {
+	case suspended && !wasSuspended:
+		r.EventRecorder.Event(tenantControlPlane, corev1.EventTypeNormal, "Suspended", "the Tenant Control Plane has been scaled to zero replicas, scaling down its control plane Pods")
+	case !suspended && wasSuspended:
+		r.EventRecorder.Event(tenantControlPlane, corev1.EventTypeNormal, "Resumed", "the Tenant Control Plane has been scaled up, resuming its control plane Pods")
+	case !suspended && current == nil:
+		return nil
+	}
+
+	if suspended {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ScaledToZero"
+		condition.Message = "the control plane Pods are scaled down to zero, the certificates, the DataStore data, and the configuration are retained until the Tenant Control Plane is scaled up"
+	}
+
+	if meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, condition) {
+		return r.Client.Status().Update(ctx, tenantControlPlane)
+	}
+
+	return nil
+}
+
+// checkKineImage updates the KineImageCompatible condition of the given Tenant Control Plane backed by Kine,
+// emitting a Warning event when the Kine image doesn't support the DataStore driver.
+// The reconciliation is not halted, since the capability matrix could be outdated.
+func (r *TenantControlPlaneReconciler) checkKineImage(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, ds kamajiv1alpha1.DataStore) error {
+	if ds.Spec.Driver == kamajiv1alpha1.EtcdDriver {
+		if meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.KineImageCompatibleCondition) {
+			return r.Client.Status().Update(ctx, tenantControlPlane)
+		}
+
+		return nil
+	}
+
+	image := tenantControlPlane.KineImage(r.Config.KineContainerImage)
+
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.KineImageCompatibleCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Compatible",
+		Message:            fmt.Sprintf("the Kine image %s supports the %s driver", image, ds.Spec.Driver),
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+	}
+
+	var incompatible datastore.KineImageIncompatibleError
+
+	if errors.As(datastore.CheckKineImage(image, ds.Spec.Driver), &incompatible) {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Incompatible"
+		condition.Message = incompatible.Error()
+
+		if current := meta.FindStatusCondition(tenantControlPlane.Status.Conditions, condition.Type); current == nil || current.Status != condition.Status {
+			r.EventRecorder.Event(tenantControlPlane, corev1.EventTypeWarning, "IncompatibleKineImage", incompatible.Error())
+		}
+	}
+
+	if meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, condition) {
+		return r.Client.Status().Update(ctx, tenantControlPlane)
+	}
+
+	return nil
+}
+
+// checkKineSchema updates the DatastoreSchemaMismatch condition of the given Tenant Control Plane backed by Kine,
+// emitting a Warning event with the remediation when the kine table has been created by an incompatible Kine release.
+// The reconciliation is not halted, and no migration is attempted, since the kine table is owned by Kine.
+func (r *TenantControlPlaneReconciler) checkKineSchema(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, ds kamajiv1alpha1.DataStore, connection datastore.Connection) error {
+	if ds.Spec.Driver == kamajiv1alpha1.EtcdDriver || tenantControlPlane.Status.Storage.Setup.Schema == "" {
+		if meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.DataStoreSchemaMismatchCondition) {
+			return r.Client.Status().Update(ctx, tenantControlPlane)
+		}
+
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.DataStoreSchemaMismatchCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             "Compatible",
+		Message:            fmt.Sprintf("the kine table is compatible with the Kine image %s", tenantControlPlane.KineImage(r.Config.KineContainerImage)),
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+	}
+
+	var mismatch datastore.KineSchemaMismatchError
+
+	if err := connection.CheckKineSchema(ctx, *tenantControlPlane); err != nil {
+		if !errors.As(err, &mismatch) {
+			return err
+		}
+
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Mismatch"
+		condition.Message = mismatch.Error()
+
+		if current := meta.FindStatusCondition(tenantControlPlane.Status.Conditions, condition.Type); current == nil || current.Status != condition.Status {
+			r.EventRecorder.Eventf(tenantControlPlane, corev1.EventTypeWarning, "DatastoreSchemaMismatch", "%s: restore a Kine image compatible with the schema, or migrate the Tenant Control Plane to a new DataStore", mismatch.Error())
+		}
+	}
+
+	if meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, condition) {
+		return r.Client.Status().Update(ctx, tenantControlPlane)
+	}
+
+	return nil
+}
+
+// waitForLoadBalancer reports the pending LoadBalancer address with the WaitingForLoadBalancer condition,
+// requeuing the request with a delay doubling up to the configured cap: a Warning event is emitted once
+// the address is pending since longer than the configured timeout.
+func (r *TenantControlPlaneReconciler) waitForLoadBalancer(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.WaitingForLoadBalancerCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Pending",
+		Message:            "the LoadBalancer Service has not been assigned an address yet",
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+	}
+
+	var pending time.Duration
+	// The pending time is tracked by the condition transition time, preserved until the address is assigned.
+	if current := meta.FindStatusCondition(tenantControlPlane.Status.Conditions, condition.Type); current != nil && current.Status == metav1.ConditionTrue {
+		pending = r.clock.Now().Sub(current.LastTransitionTime.Time)
+	}
+
+	if timeout := r.Config.LoadBalancerPendingTimeout; timeout > 0 && pending > timeout {
+		condition.Reason = "Timeout"
+		condition.Message = fmt.Sprintf("the LoadBalancer Service has not been assigned an address since %s", pending.Round(time.Second))
+	}
+
+	if current := meta.FindStatusCondition(tenantControlPlane.Status.Conditions, condition.Type); condition.Reason == "Timeout" && (current == nil || current.Reason != condition.Reason) {
+		r.EventRecorder.Event(tenantControlPlane, corev1.EventTypeWarning, "LoadBalancerPending", condition.Message)
+	}
+
+	if meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, condition) {
+		if err := r.Client.Status().Update(ctx, tenantControlPlane); err != nil {
+			log.Error(err, "cannot update the LoadBalancer condition")
+
+			return ctrl.Result{}, err
+		}
+	}
+
+	requeue := pending
+	if requeue < r.Config.LoadBalancerRequeueInterval {
+		requeue = r.Config.LoadBalancerRequeueInterval
+	}
+
+	if requeue > r.Config.LoadBalancerRequeueMaxInterval {
+		requeue = r.Config.LoadBalancerRequeueMaxInterval
+	}
+
+	log.V(1).Info("waiting for the LoadBalancer address, enqueuing back request", "after", requeue.String())
+
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+// dataStoreUnreachable handles the DataStore connection failures: during the grace period following the creation,
+// the failure is reported as Initializing and the request is enqueued back, rather than returning an error.
+func (r *TenantControlPlaneReconciler) dataStoreUnreachable(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, connErr error) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	remaining := tenantControlPlane.GetCreationTimestamp().Add(r.Config.DataStoreInitGrace).Sub(r.clock.Now())
+	// The grace period applies to the initial provisioning only.
+	if len(tenantControlPlane.Status.Kubernetes.Version.Version) == 0 && remaining > 0 {
+		log.Info("the DataStore is not reachable yet, waiting for its initialization", "reason", connErr.Error())
+
+		if err := r.setDataStoreReachableCondition(ctx, tenantControlPlane, metav1.ConditionFalse, "Initializing", fmt.Sprintf("waiting for the DataStore initialization: %s", connErr.Error())); err != nil {
+			log.Error(err, "cannot update the DataStore reachability condition")
+
+			return ctrl.Result{}, err
+		}
+
+		requeue := dataStoreInitRequeueInterval
+		if remaining < requeue {
+			requeue = remaining
+		}
+
+		return ctrl.Result{RequeueAfter: requeue}, nil
+	}
+
+	log.Error(connErr, "cannot generate the DataStore connection for the given instance")
+
+	if current := meta.FindStatusCondition(tenantControlPlane.Status.Conditions, kamajiv1alpha1.DataStoreReachableCondition); current == nil || current.Reason != "Unreachable" {
+		r.EventRecorder.Eventf(tenantControlPlane, corev1.EventTypeWarning, "DataStoreUnreachable", "cannot connect to the DataStore %s: %s", tenantControlPlane.Status.Storage.DataStoreName, connErr.Error())
+	}
+
+	if err := r.setDataStoreReachableCondition(ctx, tenantControlPlane, metav1.ConditionFalse, "Unreachable", connErr.Error()); err != nil {
+		log.Error(err, "cannot update the DataStore reachability condition")
+	}
+
+	return ctrl.Result{}, connErr
+}
+
+// dataStoreNotFound reports the DataStore of the given Tenant Control Plane is missing with the DataStoreNotFound condition,
+// emitting a Warning event upon the transition: the request is not enqueued back, waiting for the DataStore creation.
+func (r *TenantControlPlaneReconciler) dataStoreNotFound(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	dataStoreName := r.dataStoreName(tenantControlPlane)
+
+	log.Info("the DataStore does not exist, waiting for its creation", "datastore", dataStoreName)
+
+	if current := meta.FindStatusCondition(tenantControlPlane.Status.Conditions, kamajiv1alpha1.DataStoreNotFoundCondition); current == nil || current.Status != metav1.ConditionTrue {
+		r.EventRecorder.Eventf(tenantControlPlane, corev1.EventTypeWarning, "DataStoreNotFound", "the DataStore %s does not exist", dataStoreName)
+	}
+
+	if err := r.setDataStoreNotFoundCondition(ctx, tenantControlPlane, metav1.ConditionTrue, "NotFound", fmt.Sprintf("the DataStore %s does not exist", dataStoreName)); err != nil {
+		log.Error(err, "cannot update the DataStore not found condition")
+
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *TenantControlPlaneReconciler) setDataStoreNotFoundCondition(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, status metav1.ConditionStatus, reason, message string) error {
+	changed := meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, metav1.Condition{
+		Type:               kamajiv1alpha1.DataStoreNotFoundCondition,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+	})
+	if !changed {
+		return nil
+	}
+
+	return r.Client.Status().Update(ctx, tenantControlPlane)
+}
+
+func (r *TenantControlPlaneReconciler) setDataStoreReachableCondition(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, status metav1.ConditionStatus, reason, message string) error {
+	changed := meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, metav1.Condition{
+		Type:               kamajiv1alpha1.DataStoreReachableCondition,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+	})
+	if !changed {
+		return nil
+	}
+
+	return r.Client.Status().Update(ctx, tenantControlPlane)
+}
+
 func (r *TenantControlPlaneReconciler) mutexSpec(obj client.Object) mutex.Spec {
 	return mutex.Spec{
 		Name:    strings.ReplaceAll(fmt.Sprintf("kamaji%s", obj.GetUID()), "-", ""),
@@ -227,6 +795,14 @@ func (r *TenantControlPlaneReconciler) mutexSpec(obj client.Object) mutex.Spec {
 func (r *TenantControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.clock = clock.RealClock{}
 
+	if r.Config.DryRun {
+		r.Client = client.NewDryRunClient(r.Client)
+	}
+
+	if r.Config.ConfigurationSnapshot {
+		metrics.Registry.MustRegister(configurationChangesCounter)
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		WatchesRawSource(&source.Channel{Source: r.CertificateChan}, handler.Funcs{GenericFunc: func(_ context.Context, genericEvent event.GenericEvent, limitingInterface workqueue.RateLimitingInterface) {
 			limitingInterface.AddRateLimited(ctrl.Request{
@@ -295,22 +871,36 @@ func (r *TenantControlPlaneReconciler) getTenantControlPlane(ctx context.Context
 	}
 }
 
+// isFinalizerTimeoutExpired returns true if the given Tenant Control Plane has been marked for deletion
+// since longer than the configured finalizer timeout, a zero value disables the timeout.
+func (r *TenantControlPlaneReconciler) isFinalizerTimeoutExpired(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if r.Config.FinalizerTimeout <= 0 || tenantControlPlane.GetDeletionTimestamp() == nil {
+		return false
+	}
+
+	return r.clock.Now().Sub(tenantControlPlane.GetDeletionTimestamp().Time) > r.Config.FinalizerTimeout
+}
+
 func (r *TenantControlPlaneReconciler) RemoveFinalizer(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
 	controllerutil.RemoveFinalizer(tenantControlPlane, finalizers.DatastoreFinalizer)
 
 	return r.Client.Update(ctx, tenantControlPlane)
 }
 
+// dataStoreName returns the name of the DataStore of the given Tenant Control Plane, falling back to the default one.
+func (r *TenantControlPlaneReconciler) dataStoreName(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) string {
+	if dataStoreName := tenantControlPlane.Spec.DataStore; len(dataStoreName) > 0 {
+		return dataStoreName
+	}
+
+	return r.Config.DefaultDataStoreName
+}
+
 // dataStore retrieves the override DataStore for the given Tenant Control Plane if specified,
 // otherwise fallback to the default one specified in the Kamaji setup.
 func (r *TenantControlPlaneReconciler) dataStore(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (*kamajiv1alpha1.DataStore, error) {
-	dataStoreName := tenantControlPlane.Spec.DataStore
-	if len(dataStoreName) == 0 {
-		dataStoreName = r.Config.DefaultDataStoreName
-	}
-
 	ds := &kamajiv1alpha1.DataStore{}
-	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Name: dataStoreName}, ds); err != nil {
+	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Name: r.dataStoreName(tenantControlPlane)}, ds); err != nil {
 		return nil, errors.Wrap(err, "cannot retrieve *kamajiv1alpha.DataStore object")
 	}
 
@@ -0,0 +1,144 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+var configurationChangesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kamaji_tenant_control_plane_configuration_changes_total",
+	Help: "Number of changes of the Tenant Control Plane effective configuration, as tracked by the configuration snapshot.",
+}, []string{"namespace", "name"})
+
+// updateConfigurationSnapshot takes the snapshot of the effective configuration of the given Tenant Control Plane,
+// updating its status only upon changes: the first snapshot is not accounted as a change.
+func (r *TenantControlPlaneReconciler) updateConfigurationSnapshot(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	snapshot, err := r.configurationSnapshot(ctx, tenantControlPlane)
+	if err != nil {
+		return err
+	}
+
+	previous := tenantControlPlane.Status.ConfigurationSnapshot
+	if previous != nil && previous.Checksum == snapshot.Checksum {
+		return nil
+	}
+
+	snapshot.LastChange = metav1.Now()
+	tenantControlPlane.Status.ConfigurationSnapshot = snapshot
+
+	if err = r.Client.Status().Update(ctx, tenantControlPlane); err != nil {
+		return err
+	}
+
+	if previous != nil {
+		configurationChangesCounter.WithLabelValues(tenantControlPlane.GetNamespace(), tenantControlPlane.GetName()).Inc()
+	}
+
+	return nil
+}
+
+// configurationSnapshot collects the images, and the rendered arguments checksum, of the control plane containers,
+// along with the fingerprints of the certificates.
+func (r *TenantControlPlaneReconciler) configurationSnapshot(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (*kamajiv1alpha1.ConfigurationSnapshotStatus, error) {
+	deploymentNames := []string{tenantControlPlane.GetName()}
+
+	if tenantControlPlane.Spec.ControlPlane.Deployment.ComponentIsolation {
+		deploymentNames = append(deploymentNames,
+			builder.IsolatedComponentDeploymentName(*tenantControlPlane, builder.ControllerManagerComponent),
+			builder.IsolatedComponentDeploymentName(*tenantControlPlane, builder.SchedulerComponent),
+		)
+	}
+
+	var components []kamajiv1alpha1.ComponentConfigurationSnapshot
+
+	for _, name := range deploymentNames {
+		var deployment appsv1.Deployment
+		if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: name}, &deployment); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			components = append(components, kamajiv1alpha1.ComponentConfigurationSnapshot{
+				Name:         container.Name,
+				Image:        container.Image,
+				ArgsChecksum: utilities.CalculateMapChecksum(map[string]string{"args": strings.Join(container.Args, " ")}),
+			})
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].Name < components[j].Name
+	})
+
+	fingerprints := map[string]string{}
+
+	certificates := tenantControlPlane.Status.Certificates
+
+	for name, ref := range map[string]struct{ secretName, key string }{
+		"ca":                        {certificates.CA.SecretName, kubeadmconstants.CACertName},
+		"api-server":                {certificates.APIServer.SecretName, kubeadmconstants.APIServerCertName},
+		"api-server-kubelet-client": {certificates.APIServerKubeletClient.SecretName, kubeadmconstants.APIServerKubeletClientCertName},
+		"front-proxy-ca":            {certificates.FrontProxyCA.SecretName, kubeadmconstants.FrontProxyCACertName},
+		"front-proxy-client":        {certificates.FrontProxyClient.SecretName, kubeadmconstants.FrontProxyClientCertName},
+	} {
+		if len(ref.secretName) == 0 {
+			continue
+		}
+
+		var secret corev1.Secret
+		if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: ref.secretName}, &secret); err != nil {
+			return nil, err
+		}
+
+		fingerprint, err := crypto.CertificateFingerprint(secret.Data[ref.key])
+		if err != nil {
+			return nil, err
+		}
+
+		fingerprints[name] = fingerprint
+	}
+
+	snapshot := &kamajiv1alpha1.ConfigurationSnapshotStatus{
+		Components:              components,
+		CertificateFingerprints: fingerprints,
+	}
+
+	checksum := map[string]string{
+		"kubernetes-version":         tenantControlPlane.Spec.Kubernetes.Version,
+		"controller-manager-version": tenantControlPlane.ControllerManagerVersion(),
+		"scheduler-version":          tenantControlPlane.SchedulerVersion(),
+	}
+
+	for _, component := range components {
+		checksum["component/"+component.Name] = component.Image + "@" + component.ArgsChecksum
+	}
+
+	for name, fingerprint := range fingerprints {
+		checksum["certificate/"+name] = fingerprint
+	}
+
+	snapshot.Checksum = utilities.CalculateMapChecksum(checksum)
+
+	return snapshot, nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+var _ = Describe("Deploy a DataStore with no CA certificate", func() {
+	It("should fail for the etcd driver", func() {
+		Consistently(func() error {
+			ds := &kamajiv1alpha1.DataStore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "etcd-no-ca",
+				},
+				Spec: kamajiv1alpha1.DataStoreSpec{
+					Driver:    kamajiv1alpha1.EtcdDriver,
+					Endpoints: kamajiv1alpha1.Endpoints{"etcd-server.kamaji-system.svc:2379"},
+					TLSConfig: kamajiv1alpha1.TLSConfig{
+						CertificateAuthority: kamajiv1alpha1.CertKeyPair{
+							PrivateKey: &kamajiv1alpha1.ContentRef{Content: []byte("ca-key")},
+						},
+						ClientCertificate: kamajiv1alpha1.ClientCertificate{
+							Certificate: kamajiv1alpha1.ContentRef{Content: []byte("client-crt")},
+							PrivateKey:  kamajiv1alpha1.ContentRef{Content: []byte("client-key")},
+						},
+					},
+				},
+			}
+
+			return k8sClient.Create(context.Background(), ds)
+		}, 10*time.Second, time.Second).Should(MatchError(ContainSubstring("the CA certificate is required")))
+	})
+})
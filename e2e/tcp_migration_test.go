@@ -19,6 +19,7 @@ import (
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
@@ -91,6 +92,11 @@ var _ = Describe("When migrating a Tenant Control Plane to another datastore", f
 				return err
 			}
 
+			if tcp.Annotations == nil {
+				tcp.Annotations = map[string]string{}
+			}
+
+			tcp.Annotations[constants.AllowDataStoreMigration] = "true"
 			tcp.Spec.DataStore = "etcd-silver"
 
 			return k8sClient.Update(context.Background(), tcp)
@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/blang/semver"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -24,6 +25,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	kamajiconstants "github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
@@ -42,6 +44,8 @@ const (
 
 const (
 	apiServerFlagsAnnotation = "kube-apiserver.kamaji.clastix.io/args"
+	sniCertKeyFlag           = "--tls-sni-cert-key"
+	sniCertificatesDir       = "sni"
 	// Kamaji container names.
 	apiServerContainerName    = "kube-apiserver"
 	controlPlaneContainerName = "kube-controller-manager"
@@ -50,10 +54,19 @@ const (
 	kineInitContainerName     = "chmod"
 )
 
+// minVersionWatchTerminationGracePeriod is the minimum Kubernetes version supporting the
+// --shutdown-watch-termination-grace-period flag of the kube-apiserver.
+var minVersionWatchTerminationGracePeriod = semver.MustParse("1.27.0")
+
 type Deployment struct {
 	KineContainerImage string
 	DataStore          kamajiv1alpha1.DataStore
 	Client             client.Client
+	// KinePullPolicy is the pull policy of the Kine containers, unless set by the Tenant Control Plane:
+	// when empty, it's inferred from the image tag.
+	KinePullPolicy corev1.PullPolicy
+	// KineResources are the resource requirements of the Kine container, unless set by the Tenant Control Plane.
+	KineResources corev1.ResourceRequirements
 }
 
 func (d Deployment) Build(ctx context.Context, deployment *appsv1.Deployment, tenantControlPlane kamajiv1alpha1.TenantControlPlane) {
@@ -81,8 +94,13 @@ func (d Deployment) Build(ctx context.Context, deployment *appsv1.Deployment, te
 
 func (d Deployment) setContainers(podSpec *corev1.PodSpec, tcp kamajiv1alpha1.TenantControlPlane, address string) {
 	d.buildKubeAPIServer(podSpec, tcp, address)
-	d.buildScheduler(podSpec, tcp)
-	d.buildControllerManager(podSpec, tcp)
+	// The isolated components are running in their own Deployments.
+	if tcp.Spec.ControlPlane.Deployment.ComponentIsolation {
+		d.removeContainers(podSpec, schedulerContainerName, controlPlaneContainerName)
+	} else {
+		d.buildScheduler(podSpec, tcp)
+		d.buildControllerManager(podSpec, tcp)
+	}
 	d.buildKine(podSpec, tcp)
 }
 
@@ -166,6 +184,10 @@ func (d Deployment) setVolumes(podSpec *corev1.PodSpec, tcp kamajiv1alpha1.Tenan
 	} {
 		fn(podSpec, tcp)
 	}
+
+	if tcp.Spec.ControlPlane.Deployment.ComponentIsolation {
+		d.removeVolumes(podSpec, schedulerKubeconfigVolumeName, controllerManagerKubeconfigVolumeName)
+	}
 }
 
 func (d Deployment) buildPKIVolume(podSpec *corev1.PodSpec, tcp kamajiv1alpha1.TenantControlPlane) {
@@ -195,6 +217,24 @@ func (d Deployment) buildPKIVolume(podSpec *corev1.PodSpec, tcp kamajiv1alpha1.T
 			Secret: d.secretProjection(tcp.Status.Certificates.SA.SecretName, constants.ServiceAccountPublicKeyName, constants.ServiceAccountPrivateKeyName),
 		},
 	}
+	// During the signing key rotation overlap, the API Server verifies the tokens with both the current and previous public keys.
+	// The projection is optional, since the keys are removed from the Secret once the overlap expires, before the rollout.
+	if d.serviceAccountRotationOverlapping(tcp) {
+		sources = append(sources, corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: tcp.Status.Certificates.SA.SecretName,
+				},
+				Items: []corev1.KeyToPath{
+					{
+						Key:  kamajiconstants.ServiceAccountVerificationKeysName,
+						Path: kamajiconstants.ServiceAccountVerificationKeysName,
+					},
+				},
+				Optional: pointer.To(true),
+			},
+		})
+	}
 
 	if d.DataStore.Spec.Driver == kamajiv1alpha1.EtcdDriver {
 		sources = append(sources, corev1.VolumeProjection{
@@ -220,6 +260,40 @@ func (d Deployment) buildPKIVolume(podSpec *corev1.PodSpec, tcp kamajiv1alpha1.T
 		})
 	}
 
+	if bundle := tcp.Status.Certificates.ClusterCABundle; bundle != nil {
+		sources = append(sources, corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: bundle.SecretName,
+				},
+				Items: []corev1.KeyToPath{
+					{
+						Key:  kamajiconstants.ClusterCABundleName,
+						Path: kamajiconstants.ClusterCABundleName,
+					},
+				},
+			},
+		})
+	}
+
+	if sniCerts := d.apiServerSNICertificates(tcp); len(sniCerts) > 0 {
+		items := make([]corev1.KeyToPath, 0, 2*len(sniCerts))
+
+		for i := range sniCerts {
+			certName, keyName := utilities.SNICertificateKeyNames(i)
+			items = append(items, corev1.KeyToPath{Key: certName, Path: path.Join(sniCertificatesDir, certName)}, corev1.KeyToPath{Key: keyName, Path: path.Join(sniCertificatesDir, keyName)})
+		}
+
+		sources = append(sources, corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: tcp.Status.Certificates.APIServerSNI.SecretName,
+				},
+				Items: items,
+			},
+		})
+	}
+
 	podSpec.Volumes[index].Name = kubernetesPKIVolumeName
 	podSpec.Volumes[index].VolumeSource = corev1.VolumeSource{
 		Projected: &corev1.ProjectedVolumeSource{
@@ -360,7 +434,8 @@ func (d Deployment) buildScheduler(podSpec *corev1.PodSpec, tenantControlPlane k
 	args["--leader-elect"] = "true" //nolint:goconst
 
 	podSpec.Containers[index].Name = schedulerContainerName
-	podSpec.Containers[index].Image = tenantControlPlane.Spec.ControlPlane.Deployment.RegistrySettings.KubeSchedulerImage(tenantControlPlane.Spec.Kubernetes.Version)
+	podSpec.Containers[index].Image = tenantControlPlane.Spec.ControlPlane.Deployment.RegistrySettings.KubeSchedulerImage(tenantControlPlane.SchedulerVersion())
+	podSpec.Containers[index].ImagePullPolicy = imagePullPolicy(tenantControlPlane, podSpec.Containers[index].Image)
 	podSpec.Containers[index].Command = []string{"kube-scheduler"}
 	podSpec.Containers[index].Args = utilities.ArgsFromMapToSlice(args)
 	podSpec.Containers[index].LivenessProbe = &corev1.Probe{
@@ -449,11 +524,20 @@ func (d Deployment) buildControllerManager(podSpec *corev1.PodSpec, tenantContro
 	args["--cluster-cidr"] = tenantControlPlane.Spec.NetworkProfile.PodCIDR
 	args["--requestheader-client-ca-file"] = path.Join(v1beta3.DefaultCertificatesDir, constants.FrontProxyCACertName)
 	args["--root-ca-file"] = path.Join(v1beta3.DefaultCertificatesDir, constants.CACertName)
+	// The root CA file is published to the kube-root-ca.crt ConfigMaps, thus the in-cluster clients trust the whole bundle.
+	if tenantControlPlane.Status.Certificates.ClusterCABundle != nil {
+		args["--root-ca-file"] = path.Join(v1beta3.DefaultCertificatesDir, kamajiconstants.ClusterCABundleName)
+	}
 	args["--service-account-private-key-file"] = path.Join(v1beta3.DefaultCertificatesDir, constants.ServiceAccountPrivateKeyName)
 	args["--use-service-account-credentials"] = "true"
+	// The flag is set only when disabled, avoiding the roll out of the existing Tenant Control Planes.
+	if !tenantControlPlane.IsGarbageCollectorEnabled() {
+		args["--enable-garbage-collector"] = "false"
+	}
 
 	podSpec.Containers[index].Name = "kube-controller-manager"
-	podSpec.Containers[index].Image = tenantControlPlane.Spec.ControlPlane.Deployment.RegistrySettings.KubeControllerManagerImage(tenantControlPlane.Spec.Kubernetes.Version)
+	podSpec.Containers[index].Image = tenantControlPlane.Spec.ControlPlane.Deployment.RegistrySettings.KubeControllerManagerImage(tenantControlPlane.ControllerManagerVersion())
+	podSpec.Containers[index].ImagePullPolicy = imagePullPolicy(tenantControlPlane, podSpec.Containers[index].Image)
 	podSpec.Containers[index].Command = []string{"kube-controller-manager"}
 	podSpec.Containers[index].Args = utilities.ArgsFromMapToSlice(args)
 	podSpec.Containers[index].LivenessProbe = &corev1.Probe{
@@ -575,7 +659,7 @@ func (d Deployment) buildKubeAPIServer(podSpec *corev1.PodSpec, tenantControlPla
 	args := d.buildKubeAPIServerCommand(tenantControlPlane, address, utilities.ArgsFromSliceToMap(podSpec.Containers[index].Args))
 
 	podSpec.Containers[index].Name = apiServerContainerName
-	podSpec.Containers[index].Args = utilities.ArgsFromMapToSlice(args)
+	podSpec.Containers[index].Args = append(utilities.ArgsFromMapToSlice(args), d.apiServerSNIArgs(tenantControlPlane)...)
 	podSpec.Containers[index].Image = tenantControlPlane.Spec.ControlPlane.Deployment.RegistrySettings.KubeAPIServerImage(tenantControlPlane.Spec.Kubernetes.Version)
 	podSpec.Containers[index].Command = []string{"kube-apiserver"}
 	podSpec.Containers[index].LivenessProbe = &corev1.Probe{
@@ -620,7 +704,7 @@ func (d Deployment) buildKubeAPIServer(podSpec *corev1.PodSpec, tenantControlPla
 		SuccessThreshold:    1,
 		FailureThreshold:    3,
 	}
-	podSpec.Containers[index].ImagePullPolicy = corev1.PullAlways
+	podSpec.Containers[index].ImagePullPolicy = imagePullPolicy(tenantControlPlane, podSpec.Containers[index].Image)
 	// Volume mounts
 	var extraVolumeMounts []corev1.VolumeMount
 
@@ -682,11 +766,11 @@ func (d Deployment) buildKubeAPIServerCommand(tenantControlPlane kamajiv1alpha1.
 	}
 
 	desiredArgs := map[string]string{
-		"--allow-privileged":                   "true",
+		"--allow-privileged":                   strconv.FormatBool(d.allowPrivileged(tenantControlPlane)),
 		"--authorization-mode":                 "Node,RBAC",
 		"--advertise-address":                  address,
 		"--client-ca-file":                     path.Join(v1beta3.DefaultCertificatesDir, constants.CACertName),
-		"--enable-admission-plugins":           strings.Join(tenantControlPlane.Spec.Kubernetes.AdmissionControllers.ToSlice(), ","),
+		"--enable-admission-plugins":           strings.Join(d.admissionControllers(tenantControlPlane).ToSlice(), ","),
 		"--enable-bootstrap-token-auth":        "true",
 		"--service-cluster-ip-range":           tenantControlPlane.Spec.NetworkProfile.ServiceCIDR,
 		"--kubelet-client-certificate":         path.Join(v1beta3.DefaultCertificatesDir, constants.APIServerKubeletClientCertName),
@@ -701,7 +785,7 @@ func (d Deployment) buildKubeAPIServerCommand(tenantControlPlane kamajiv1alpha1.
 		"--requestheader-username-headers":     "X-Remote-User",
 		"--secure-port":                        fmt.Sprintf("%d", tenantControlPlane.Spec.NetworkProfile.Port),
 		"--service-account-issuer":             "https://kubernetes.default.svc.cluster.local",
-		"--service-account-key-file":           path.Join(v1beta3.DefaultCertificatesDir, constants.ServiceAccountPublicKeyName),
+		"--service-account-key-file":           path.Join(v1beta3.DefaultCertificatesDir, d.serviceAccountKeyFile(tenantControlPlane)),
 		"--service-account-signing-key-file":   path.Join(v1beta3.DefaultCertificatesDir, constants.ServiceAccountPrivateKeyName),
 		"--tls-cert-file":                      path.Join(v1beta3.DefaultCertificatesDir, constants.APIServerCertName),
 		"--tls-private-key-file":               path.Join(v1beta3.DefaultCertificatesDir, constants.APIServerKeyName),
@@ -710,6 +794,7 @@ func (d Deployment) buildKubeAPIServerCommand(tenantControlPlane kamajiv1alpha1.
 	switch d.DataStore.Spec.Driver {
 	case kamajiv1alpha1.KineMySQLDriver, kamajiv1alpha1.KinePostgreSQLDriver:
 		desiredArgs["--etcd-servers"] = "http://127.0.0.1:2379"
+		desiredArgs["--storage-media-type"] = d.storageMediaType(tenantControlPlane, kamajiv1alpha1.StorageMediaTypeJSON)
 	case kamajiv1alpha1.EtcdDriver:
 		httpsEndpoints := make([]string, 0, len(d.DataStore.Spec.Endpoints))
 
@@ -717,12 +802,45 @@ func (d Deployment) buildKubeAPIServerCommand(tenantControlPlane kamajiv1alpha1.
 			httpsEndpoints = append(httpsEndpoints, fmt.Sprintf("https://%s", ep))
 		}
 
-		desiredArgs["--etcd-compaction-interval"] = "0"
+		// The API Server compaction is disabled by default, delegating it to etcd.
+		compactionInterval := "0"
+		if opts := tenantControlPlane.Spec.DataStoreOptions; opts != nil && opts.Etcd != nil && opts.Etcd.APIServerCompactionInterval != nil {
+			compactionInterval = opts.Etcd.APIServerCompactionInterval.Duration.String()
+		}
+
+		desiredArgs["--etcd-compaction-interval"] = compactionInterval
 		desiredArgs["--etcd-prefix"] = fmt.Sprintf("/%s", tenantControlPlane.Status.Storage.Setup.Schema)
 		desiredArgs["--etcd-servers"] = strings.Join(httpsEndpoints, ",")
 		desiredArgs["--etcd-cafile"] = "/etc/kubernetes/pki/etcd/ca.crt"
 		desiredArgs["--etcd-certfile"] = "/etc/kubernetes/pki/etcd/server.crt"
 		desiredArgs["--etcd-keyfile"] = "/etc/kubernetes/pki/etcd/server.key"
+		desiredArgs["--storage-media-type"] = d.storageMediaType(tenantControlPlane, kamajiv1alpha1.StorageMediaTypeProtobuf)
+	}
+
+	if nodePortRange := tenantControlPlane.Spec.NetworkProfile.ServiceNodePortRange; len(nodePortRange) > 0 {
+		desiredArgs["--service-node-port-range"] = nodePortRange
+	} else {
+		delete(current, "--service-node-port-range")
+	}
+
+	// The SNI certificates flag is repeatable, thus it can't be handled by the arguments map:
+	// when managed by Kamaji, the flags are appended upon the kube-apiserver container build.
+	delete(current, sniCertKeyFlag)
+
+	if len(d.apiServerSNICertificates(tenantControlPlane)) > 0 {
+		delete(extraArgs, sniCertKeyFlag)
+	}
+
+	// The optional flags are removed from the current ones when not desired anymore,
+	// otherwise a previous setting would be kept due to the merge with the current arguments.
+	for flag, value := range utilities.MergeMaps(d.apiServerRequestHandlingArgs(tenantControlPlane), d.apiServerEndpointReconcilerArgs(tenantControlPlane), d.apiServerOIDCArgs(tenantControlPlane), d.apiServerGarbageCollectorArgs(tenantControlPlane), d.apiServerGracefulShutdownArgs(tenantControlPlane)) {
+		if len(value) == 0 {
+			delete(current, flag)
+
+			continue
+		}
+
+		desiredArgs[flag] = value
 	}
 
 	// Order matters, here: extraArgs could try to overwrite some arguments managed by Kamaji and that would be crucial.
@@ -730,6 +848,257 @@ func (d Deployment) buildKubeAPIServerCommand(tenantControlPlane kamajiv1alpha1.
 	return utilities.MergeMaps(extraArgs, current, desiredArgs)
 }
 
+// apiServerRequestHandlingArgs returns the kube-apiserver flags related to the request handling, and caching:
+// an empty value means the flag is not set and the kube-apiserver default must be used.
+func (d Deployment) apiServerRequestHandlingArgs(tenantControlPlane kamajiv1alpha1.TenantControlPlane) map[string]string {
+	args := map[string]string{
+		"--request-timeout":                "",
+		"--min-request-timeout":            "",
+		"--enable-priority-and-fairness":   "",
+		"--max-requests-inflight":          "",
+		"--max-mutating-requests-inflight": "",
+		"--watch-cache":                    "",
+		"--default-watch-cache-size":       "",
+		"--watch-cache-sizes":              "",
+		"--cors-allowed-origins":           "",
+	}
+
+	apiServer := tenantControlPlane.Spec.ControlPlane.APIServer
+	if apiServer == nil {
+		return args
+	}
+
+	if apiServer.RequestTimeout != nil {
+		args["--request-timeout"] = apiServer.RequestTimeout.Duration.String()
+	}
+
+	if apiServer.MinRequestTimeout != nil {
+		args["--min-request-timeout"] = fmt.Sprintf("%d", *apiServer.MinRequestTimeout)
+	}
+
+	if apiServer.APF != nil && apiServer.APF.Enabled != nil {
+		args["--enable-priority-and-fairness"] = strconv.FormatBool(*apiServer.APF.Enabled)
+	}
+
+	if apiServer.MaxRequestsInflight != nil {
+		args["--max-requests-inflight"] = fmt.Sprintf("%d", *apiServer.MaxRequestsInflight)
+	}
+
+	if apiServer.MaxMutatingRequestsInflight != nil {
+		args["--max-mutating-requests-inflight"] = fmt.Sprintf("%d", *apiServer.MaxMutatingRequestsInflight)
+	}
+
+	if apiServer.Tuning != nil && apiServer.Tuning.WatchCache != nil {
+		args["--watch-cache"] = strconv.FormatBool(*apiServer.Tuning.WatchCache)
+	}
+
+	if apiServer.Tuning != nil && apiServer.Tuning.DefaultWatchCacheSize != nil {
+		args["--default-watch-cache-size"] = fmt.Sprintf("%d", *apiServer.Tuning.DefaultWatchCacheSize)
+	}
+
+	if apiServer.Tuning != nil {
+		args["--watch-cache-sizes"] = d.watchCacheSizes(apiServer.Tuning.WatchCacheSizes)
+	}
+
+	args["--cors-allowed-origins"] = strings.Join(apiServer.CORSAllowedOrigins, ",")
+
+	return args
+}
+
+// watchCacheSizes renders the --watch-cache-sizes flag value, equivalent to its repetition per resource:
+// the resources are sorted to avoid rolling out the control plane Pods on the map iteration order.
+func (d Deployment) watchCacheSizes(sizes map[string]int32) string {
+	resources := make([]string, 0, len(sizes))
+	for resource := range sizes {
+		resources = append(resources, resource)
+	}
+
+	sort.Strings(resources)
+
+	values := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		values = append(values, fmt.Sprintf("%s#%d", resource, sizes[resource]))
+	}
+
+	return strings.Join(values, ",")
+}
+
+// apiServerOIDCArgs returns the kube-apiserver flags related to the OpenID Connect authentication:
+// an empty value means the flag is not set and the kube-apiserver default must be used.
+func (d Deployment) apiServerOIDCArgs(tenantControlPlane kamajiv1alpha1.TenantControlPlane) map[string]string {
+	args := map[string]string{
+		"--oidc-issuer-url":      "",
+		"--oidc-client-id":       "",
+		"--oidc-username-claim":  "",
+		"--oidc-username-prefix": "",
+		"--oidc-groups-claim":    "",
+		"--oidc-groups-prefix":   "",
+		"--oidc-signing-algs":    "",
+	}
+
+	apiServer := tenantControlPlane.Spec.ControlPlane.APIServer
+	if apiServer == nil || apiServer.OIDC == nil {
+		return args
+	}
+
+	oidc := apiServer.OIDC
+
+	args["--oidc-issuer-url"] = oidc.IssuerURL
+	args["--oidc-client-id"] = oidc.ClientID
+	args["--oidc-username-claim"] = oidc.UsernameClaim
+	args["--oidc-username-prefix"] = oidc.UsernamePrefix
+	args["--oidc-groups-claim"] = oidc.GroupsClaim
+	args["--oidc-groups-prefix"] = oidc.GroupsPrefix
+
+	algs := make([]string, 0, len(oidc.SigningAlgs))
+	for _, alg := range oidc.SigningAlgs {
+		algs = append(algs, string(alg))
+	}
+
+	args["--oidc-signing-algs"] = strings.Join(algs, ",")
+
+	return args
+}
+
+// apiServerGarbageCollectorArgs returns the kube-apiserver garbage collector flag, which must be synced with the
+// kube-controller-manager one: an empty value means the flag is not set and the kube-apiserver default must be used.
+func (d Deployment) apiServerGarbageCollectorArgs(tenantControlPlane kamajiv1alpha1.TenantControlPlane) map[string]string {
+	args := map[string]string{
+		"--enable-garbage-collector": "",
+	}
+
+	if !tenantControlPlane.IsGarbageCollectorEnabled() {
+		args["--enable-garbage-collector"] = "false"
+	}
+
+	return args
+}
+
+// apiServerGracefulShutdownArgs returns the kube-apiserver flags related to the graceful shutdown:
+// an empty value means the flag is not set and the kube-apiserver default must be used.
+func (d Deployment) apiServerGracefulShutdownArgs(tenantControlPlane kamajiv1alpha1.TenantControlPlane) map[string]string {
+	args := map[string]string{
+		"--shutdown-watch-termination-grace-period": "",
+	}
+
+	apiServer := tenantControlPlane.Spec.ControlPlane.APIServer
+	if apiServer == nil || apiServer.GracefulShutdown == nil {
+		return args
+	}
+
+	if period := apiServer.GracefulShutdown.WatchTerminationGracePeriod; period != nil && SupportsWatchTerminationGracePeriod(tenantControlPlane.Spec.Kubernetes.Version) {
+		args["--shutdown-watch-termination-grace-period"] = period.Duration.String()
+	}
+
+	return args
+}
+
+// SupportsWatchTerminationGracePeriod returns true if the kube-apiserver of the given Kubernetes version
+// supports the --shutdown-watch-termination-grace-period flag.
+func SupportsWatchTerminationGracePeriod(version string) bool {
+	parsedVersion, err := semver.ParseTolerant(version)
+	if err != nil {
+		return false
+	}
+
+	return semver.Version{Major: parsedVersion.Major, Minor: parsedVersion.Minor}.GTE(minVersionWatchTerminationGracePeriod)
+}
+
+// apiServerEndpointReconcilerArgs returns the kube-apiserver flags related to the kubernetes Service endpoints:
+// an empty value means the flag is not set and the kube-apiserver default must be used.
+func (d Deployment) apiServerEndpointReconcilerArgs(tenantControlPlane kamajiv1alpha1.TenantControlPlane) map[string]string {
+	args := map[string]string{
+		"--endpoint-reconciler-type": "",
+		"--apiserver-count":          "",
+	}
+
+	apiServer := tenantControlPlane.Spec.ControlPlane.APIServer
+	if apiServer == nil || len(apiServer.EndpointReconcilerType) == 0 {
+		return args
+	}
+
+	args["--endpoint-reconciler-type"] = string(apiServer.EndpointReconcilerType)
+	// The master-count reconciler expects the number of running API Servers to be declared.
+	if apiServer.EndpointReconcilerType == kamajiv1alpha1.EndpointReconcilerTypeMasterCount {
+		replicas := int32(1)
+		if r := tenantControlPlane.Spec.ControlPlane.Deployment.Replicas; r != nil && *r > 0 {
+			replicas = *r
+		}
+
+		args["--apiserver-count"] = fmt.Sprintf("%d", replicas)
+	}
+
+	return args
+}
+
+// allowPrivileged returns true unless the privileged containers have been explicitly disallowed.
+func (d Deployment) allowPrivileged(tenantControlPlane kamajiv1alpha1.TenantControlPlane) bool {
+	if apiServer := tenantControlPlane.Spec.ControlPlane.APIServer; apiServer != nil && apiServer.AllowPrivileged != nil {
+		return *apiServer.AllowPrivileged
+	}
+
+	return true
+}
+
+func (d Deployment) serviceAccountRotationOverlapping(tenantControlPlane kamajiv1alpha1.TenantControlPlane) bool {
+	rotation := tenantControlPlane.Status.Certificates.SA.Rotation
+
+	return rotation != nil && rotation.Phase == kamajiv1alpha1.KeyRotationPhaseOverlapping
+}
+
+// serviceAccountKeyFile returns the file holding the public keys used to verify the Service Account tokens.
+func (d Deployment) serviceAccountKeyFile(tenantControlPlane kamajiv1alpha1.TenantControlPlane) string {
+	if d.serviceAccountRotationOverlapping(tenantControlPlane) {
+		return kamajiconstants.ServiceAccountVerificationKeysName
+	}
+
+	return constants.ServiceAccountPublicKeyName
+}
+
+// storageMediaType returns the kube-apiserver storage media type, falling back to the given DataStore driver default.
+func (d Deployment) storageMediaType(tenantControlPlane kamajiv1alpha1.TenantControlPlane, driverDefault kamajiv1alpha1.StorageMediaType) string {
+	if apiServer := tenantControlPlane.Spec.ControlPlane.APIServer; apiServer != nil && apiServer.Tuning != nil && len(apiServer.Tuning.StorageMediaType) > 0 {
+		return string(apiServer.Tuning.StorageMediaType)
+	}
+
+	return string(driverDefault)
+}
+
+// admissionControllers returns the enabled Admission Controllers, giving precedence to the explicit ordered list.
+func (d Deployment) admissionControllers(tenantControlPlane kamajiv1alpha1.TenantControlPlane) kamajiv1alpha1.AdmissionControllers {
+	if apiServer := tenantControlPlane.Spec.ControlPlane.APIServer; apiServer != nil && len(apiServer.AdmissionControllersOrder) > 0 {
+		return apiServer.AdmissionControllersOrder
+	}
+
+	return tenantControlPlane.Spec.Kubernetes.AdmissionControllers
+}
+
+// apiServerSNICertificates returns the SNI certificates once their Secret has been reconciled.
+func (d Deployment) apiServerSNICertificates(tenantControlPlane kamajiv1alpha1.TenantControlPlane) []kamajiv1alpha1.SNICertificate {
+	if tenantControlPlane.Spec.ControlPlane.APIServer == nil || tenantControlPlane.Status.Certificates.APIServerSNI == nil {
+		return nil
+	}
+
+	return tenantControlPlane.Spec.ControlPlane.APIServer.SNICerts
+}
+
+// apiServerSNIArgs returns the --tls-sni-cert-key flags, preserving the order of the specification
+// since the kube-apiserver gives precedence to the first certificate matching a hostname.
+func (d Deployment) apiServerSNIArgs(tenantControlPlane kamajiv1alpha1.TenantControlPlane) []string {
+	sniCerts := d.apiServerSNICertificates(tenantControlPlane)
+
+	args := make([]string, 0, len(sniCerts))
+
+	for i, sniCert := range sniCerts {
+		certName, keyName := utilities.SNICertificateKeyNames(i)
+		certPath, keyPath := path.Join(v1beta3.DefaultCertificatesDir, sniCertificatesDir, certName), path.Join(v1beta3.DefaultCertificatesDir, sniCertificatesDir, keyName)
+
+		args = append(args, fmt.Sprintf("%s=%s,%s:%s", sniCertKeyFlag, certPath, keyPath, strings.Join(sniCert.Hostnames, ",")))
+	}
+
+	return args
+}
+
 func (d Deployment) secretProjection(secretName, certKeyName, keyName string) *corev1.SecretProjection {
 	return &corev1.SecretProjection{
 		LocalObjectReference: corev1.LocalObjectReference{
@@ -793,6 +1162,32 @@ func (d Deployment) buildKineVolume(podSpec *corev1.PodSpec, tcp kamajiv1alpha1.
 	}
 }
 
+func (d Deployment) removeVolumes(podSpec *corev1.PodSpec, names ...string) {
+	for _, volumeName := range names {
+		if found, index := utilities.HasNamedVolume(podSpec.Volumes, volumeName); found {
+			var volumes []corev1.Volume
+
+			volumes = append(volumes, podSpec.Volumes[:index]...)
+			volumes = append(volumes, podSpec.Volumes[index+1:]...)
+
+			podSpec.Volumes = volumes
+		}
+	}
+}
+
+func (d Deployment) removeContainers(podSpec *corev1.PodSpec, names ...string) {
+	for _, containerName := range names {
+		if found, index := utilities.HasNamedContainer(podSpec.Containers, containerName); found {
+			var containers []corev1.Container
+
+			containers = append(containers, podSpec.Containers[:index]...)
+			containers = append(containers, podSpec.Containers[index+1:]...)
+
+			podSpec.Containers = containers
+		}
+	}
+}
+
 func (d Deployment) removeKineContainers(podSpec *corev1.PodSpec) {
 	// Removing the kine container, if present
 	if found, index := utilities.HasNamedContainer(podSpec.Containers, kineContainerName); found {
@@ -830,7 +1225,8 @@ func (d Deployment) buildKine(podSpec *corev1.PodSpec, tcp kamajiv1alpha1.Tenant
 	}
 
 	podSpec.InitContainers[index].Name = kineInitContainerName
-	podSpec.InitContainers[index].Image = d.KineContainerImage
+	podSpec.InitContainers[index].Image = tcp.KineImage(d.KineContainerImage)
+	podSpec.InitContainers[index].ImagePullPolicy = d.kineImagePullPolicy(tcp)
 	podSpec.InitContainers[index].Command = []string{"sh"}
 	podSpec.InitContainers[index].Args = []string{
 		"-c",
@@ -873,8 +1269,24 @@ func (d Deployment) buildKine(podSpec *corev1.PodSpec, tcp kamajiv1alpha1.Tenant
 	args["--cert-file"] = "/certs/server.crt"
 	args["--key-file"] = "/certs/server.key"
 
+	if opts := tcp.Spec.DataStoreOptions; opts != nil && opts.Kine != nil && opts.Kine.ConnectionPool != nil {
+		pool := opts.Kine.ConnectionPool
+
+		if pool.MaxIdleConnections != nil {
+			args["--datastore-max-idle-connections"] = fmt.Sprintf("%d", *pool.MaxIdleConnections)
+		}
+
+		if pool.MaxOpenConnections != nil {
+			args["--datastore-max-open-connections"] = fmt.Sprintf("%d", *pool.MaxOpenConnections)
+		}
+
+		if pool.ConnectionMaxLifetime != nil {
+			args["--datastore-connection-max-lifetime"] = pool.ConnectionMaxLifetime.Duration.String()
+		}
+	}
+
 	podSpec.Containers[index].Name = kineContainerName
-	podSpec.Containers[index].Image = d.KineContainerImage
+	podSpec.Containers[index].Image = tcp.KineImage(d.KineContainerImage)
 	podSpec.Containers[index].Command = []string{"/bin/kine"}
 	podSpec.Containers[index].Args = utilities.ArgsFromMapToSlice(args)
 	podSpec.Containers[index].VolumeMounts = []corev1.VolumeMount{
@@ -906,17 +1318,65 @@ func (d Deployment) buildKine(podSpec *corev1.PodSpec, tcp kamajiv1alpha1.Tenant
 			Protocol:      corev1.ProtocolTCP,
 		},
 	}
+	podSpec.Containers[index].LivenessProbe = d.kineProbe(tcp)
+	podSpec.Containers[index].ReadinessProbe = d.kineProbe(tcp)
 
-	podSpec.Containers[index].ImagePullPolicy = corev1.PullAlways
+	podSpec.Containers[index].ImagePullPolicy = d.kineImagePullPolicy(tcp)
 
 	switch {
-	case tcp.Spec.ControlPlane.Deployment.Resources == nil:
-		podSpec.Containers[index].Resources = corev1.ResourceRequirements{}
-	case tcp.Spec.ControlPlane.Deployment.Resources.Kine != nil:
+	case tcp.Spec.ControlPlane.Deployment.Resources != nil && tcp.Spec.ControlPlane.Deployment.Resources.Kine != nil:
 		podSpec.Containers[index].Resources = *tcp.Spec.ControlPlane.Deployment.Resources.Kine
 	default:
-		podSpec.Containers[index].Resources = corev1.ResourceRequirements{}
+		podSpec.Containers[index].Resources = *d.KineResources.DeepCopy()
+	}
+}
+
+// kineImagePullPolicy returns the pull policy of the Kine containers: the Tenant Control Plane one takes precedence
+// over the Kamaji default, falling back to the one inferred from the image tag.
+func (d Deployment) kineImagePullPolicy(tcp kamajiv1alpha1.TenantControlPlane) corev1.PullPolicy {
+	if len(tcp.Spec.ControlPlane.Deployment.ImagePullPolicy) == 0 && len(d.KinePullPolicy) > 0 {
+		return d.KinePullPolicy
 	}
+
+	return imagePullPolicy(tcp, tcp.KineImage(d.KineContainerImage))
+}
+
+// kineProbe returns the probe of the Kine container, checking the storage endpoint is served:
+// Kine starts listening once the connection to the SQL backend has been established.
+func (d Deployment) kineProbe(tcp kamajiv1alpha1.TenantControlPlane) *corev1.Probe {
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt(2379),
+			},
+		},
+		InitialDelaySeconds: 0,
+		TimeoutSeconds:      1,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		FailureThreshold:    3,
+	}
+
+	opts := tcp.Spec.DataStoreOptions
+	if opts == nil || opts.Kine == nil || opts.Kine.Probes == nil {
+		return probe
+	}
+
+	timings := opts.Kine.Probes
+
+	if timings.PeriodSeconds != nil {
+		probe.PeriodSeconds = *timings.PeriodSeconds
+	}
+
+	if timings.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *timings.TimeoutSeconds
+	}
+
+	if timings.FailureThreshold != nil {
+		probe.FailureThreshold = *timings.FailureThreshold
+	}
+
+	return probe
 }
 
 func (d Deployment) setSelector(deploymentSpec *appsv1.DeploymentSpec, tcp kamajiv1alpha1.TenantControlPlane) {
@@ -928,6 +1388,12 @@ func (d Deployment) setSelector(deploymentSpec *appsv1.DeploymentSpec, tcp kamaj
 }
 
 func (d Deployment) setReplicas(deploymentSpec *appsv1.DeploymentSpec, tcp kamajiv1alpha1.TenantControlPlane) {
+	if tcp.IsQuarantined() {
+		deploymentSpec.Replicas = pointer.To[int32](0)
+
+		return
+	}
+
 	deploymentSpec.Replicas = tcp.Spec.ControlPlane.Deployment.Replicas
 }
 
@@ -962,6 +1428,24 @@ func (d Deployment) templateLabels(ctx context.Context, tenantControlPlane *kama
 		"component.kamaji.clastix.io/datastore":                             tenantControlPlane.Spec.DataStore,
 	}
 
+	// The isolated components kubeconfig rotations must not roll out the API Server.
+	if tenantControlPlane.Spec.ControlPlane.Deployment.ComponentIsolation {
+		delete(labels, "component.kamaji.clastix.io/controller-manager-kubeconfig")
+		delete(labels, "component.kamaji.clastix.io/scheduler-kubeconfig")
+	}
+	// The kube-controller-manager reads the root CA file upon start, thus the bundle changes require a roll out.
+	if bundle := tenantControlPlane.Status.Certificates.ClusterCABundle; bundle != nil && !tenantControlPlane.Spec.ControlPlane.Deployment.ComponentIsolation {
+		labels["component.kamaji.clastix.io/cluster-ca-bundle"] = hash(ctx, tenantControlPlane.GetNamespace(), bundle.SecretName)
+	}
+
+	if sni := tenantControlPlane.Status.Certificates.APIServerSNI; sni != nil {
+		labels["component.kamaji.clastix.io/api-server-sni-certificates"] = hash(ctx, tenantControlPlane.GetNamespace(), sni.SecretName)
+	}
+	// Rolling the Pods upon a client certificate rotation, since the Kine sidecar doesn't reload it.
+	if d.DataStore.Spec.TLSConfig.ClientCertificate.Next != nil {
+		labels["component.kamaji.clastix.io/datastore-certificate"] = hash(ctx, tenantControlPlane.GetNamespace(), tenantControlPlane.Status.Storage.Certificate.SecretName)
+	}
+
 	return labels
 }
 
@@ -1064,3 +1548,23 @@ func (d Deployment) setToleration(spec *corev1.PodSpec, tcp kamajiv1alpha1.Tenan
 func (d Deployment) setAffinity(spec *corev1.PodSpec, tcp kamajiv1alpha1.TenantControlPlane) {
 	spec.Affinity = tcp.Spec.ControlPlane.Deployment.Affinity
 }
+
+// imagePullPolicy returns the pull policy of the containers generated by Kamaji: when not specified by the
+// Tenant Control Plane, the pinned images are pulled only if not present, the untagged, or latest, ones are always pulled.
+func imagePullPolicy(tcp kamajiv1alpha1.TenantControlPlane, image string) corev1.PullPolicy {
+	if policy := tcp.Spec.ControlPlane.Deployment.ImagePullPolicy; len(policy) > 0 {
+		return policy
+	}
+
+	if strings.Contains(image, "@") {
+		return corev1.PullIfNotPresent
+	}
+	// The tag separator must follow the last path separator, since the registry host could contain a port.
+	name := image[strings.LastIndex(image, "/")+1:]
+
+	if index := strings.LastIndex(name, ":"); index == -1 || name[index+1:] == "latest" {
+		return corev1.PullAlways
+	}
+
+	return corev1.PullIfNotPresent
+}
@@ -0,0 +1,135 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pointer "k8s.io/utils/ptr"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// IsolatedComponent is a Control Plane component which can run in a dedicated Deployment.
+type IsolatedComponent string
+
+const (
+	ControllerManagerComponent IsolatedComponent = "controller-manager"
+	SchedulerComponent         IsolatedComponent = "scheduler"
+)
+
+// isolatedComponentLabel selects the Pods of the isolated component Deployments: the Tenant Control Plane name label
+// is not set on purpose, since it's used by the Service, and the API Server Deployment selectors.
+const isolatedComponentLabel = "kamaji.clastix.io/isolated-component"
+
+// IsolatedComponentDeploymentName returns the name of the Deployment of the given isolated component.
+func IsolatedComponentDeploymentName(tcp kamajiv1alpha1.TenantControlPlane, component IsolatedComponent) string {
+	return fmt.Sprintf("%s-%s", tcp.GetName(), component)
+}
+
+// BuildIsolatedComponent builds the Deployment of the given isolated Control Plane component,
+// sharing the scheduling options, and the container specification, of the Tenant Control Plane Deployment.
+func (d Deployment) BuildIsolatedComponent(ctx context.Context, deployment *appsv1.Deployment, tenantControlPlane kamajiv1alpha1.TenantControlPlane, component IsolatedComponent) {
+	selector := map[string]string{isolatedComponentLabel: IsolatedComponentDeploymentName(tenantControlPlane, component)}
+
+	d.setLabels(deployment, utilities.MergeMaps(utilities.KamajiLabels(tenantControlPlane.GetName(), string(component)), tenantControlPlane.Spec.ControlPlane.Deployment.AdditionalMetadata.Labels))
+	d.setAnnotations(deployment, utilities.MergeMaps(deployment.Annotations, tenantControlPlane.Spec.ControlPlane.Deployment.AdditionalMetadata.Annotations))
+	d.setTemplateLabels(&deployment.Spec.Template, utilities.MergeMaps(selector, d.isolatedComponentTemplateLabels(ctx, tenantControlPlane, component)))
+	d.setNodeSelector(&deployment.Spec.Template.Spec, tenantControlPlane)
+	d.setToleration(&deployment.Spec.Template.Spec, tenantControlPlane)
+	d.setAffinity(&deployment.Spec.Template.Spec, tenantControlPlane)
+	d.setRuntimeClass(&deployment.Spec.Template.Spec, tenantControlPlane)
+
+	deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: selector}
+	deployment.Spec.Replicas = d.isolatedComponentReplicas(tenantControlPlane, component)
+
+	podSpec := &deployment.Spec.Template.Spec
+	// Volumes are rebuilt from scratch, since the Pods are running a single Kamaji container.
+	podSpec.Volumes = append([]corev1.Volume{}, tenantControlPlane.Spec.ControlPlane.Deployment.AdditionalVolumes...)
+
+	builders := []func(*corev1.PodSpec, kamajiv1alpha1.TenantControlPlane){d.buildSchedulerVolume}
+	containerName := schedulerContainerName
+
+	if component == ControllerManagerComponent {
+		builders = []func(*corev1.PodSpec, kamajiv1alpha1.TenantControlPlane){
+			d.buildPKIVolume,
+			d.buildCAVolume,
+			d.buildSSLCertsVolume,
+			d.buildShareCAVolume,
+			d.buildLocalShareCAVolume,
+			d.buildControllerManagerVolume,
+		}
+		containerName = controlPlaneContainerName
+	}
+
+	for _, fn := range builders {
+		fn(podSpec, tenantControlPlane)
+	}
+
+	if found, index := utilities.HasNamedContainer(podSpec.Containers, containerName); found {
+		podSpec.Containers = podSpec.Containers[index : index+1]
+	} else {
+		podSpec.Containers = nil
+	}
+
+	switch component {
+	case ControllerManagerComponent:
+		d.buildControllerManager(podSpec, tenantControlPlane)
+	case SchedulerComponent:
+		d.buildScheduler(podSpec, tenantControlPlane)
+	}
+
+	d.Client.Scheme().Default(deployment)
+}
+
+func (d Deployment) isolatedComponentReplicas(tenantControlPlane kamajiv1alpha1.TenantControlPlane, component IsolatedComponent) *int32 {
+	if tenantControlPlane.IsQuarantined() || tenantControlPlane.IsSuspended() {
+		return pointer.To[int32](0)
+	}
+
+	if replicas := tenantControlPlane.Spec.ControlPlane.Deployment.IsolatedComponentsReplicas; replicas != nil {
+		switch {
+		case component == ControllerManagerComponent && replicas.ControllerManager != nil:
+			return replicas.ControllerManager
+		case component == SchedulerComponent && replicas.Scheduler != nil:
+			return replicas.Scheduler
+		}
+	}
+
+	return tenantControlPlane.Spec.ControlPlane.Deployment.Replicas
+}
+
+// isolatedComponentTemplateLabels returns the hash labels of the Secrets mounted by the given component,
+// rolling out its Pods upon rotation.
+func (d Deployment) isolatedComponentTemplateLabels(ctx context.Context, tenantControlPlane kamajiv1alpha1.TenantControlPlane, component IsolatedComponent) map[string]string {
+	hash := func(secretName string) string {
+		h, _ := d.secretHashValue(ctx, d.Client, tenantControlPlane.GetNamespace(), secretName)
+
+		return h
+	}
+
+	if component == SchedulerComponent {
+		return map[string]string{
+			"component.kamaji.clastix.io/scheduler-kubeconfig": hash(tenantControlPlane.Status.KubeConfig.Scheduler.SecretName),
+		}
+	}
+
+	labels := map[string]string{
+		"component.kamaji.clastix.io/ca":                            hash(tenantControlPlane.Status.Certificates.CA.SecretName),
+		"component.kamaji.clastix.io/controller-manager-kubeconfig": hash(tenantControlPlane.Status.KubeConfig.ControllerManager.SecretName),
+		"component.kamaji.clastix.io/front-proxy-ca-certificate":    hash(tenantControlPlane.Status.Certificates.FrontProxyCA.SecretName),
+		"component.kamaji.clastix.io/service-account":               hash(tenantControlPlane.Status.Certificates.SA.SecretName),
+	}
+
+	if bundle := tenantControlPlane.Status.Certificates.ClusterCABundle; bundle != nil {
+		labels["component.kamaji.clastix.io/cluster-ca-bundle"] = hash(bundle.SecretName)
+	}
+
+	return labels
+}
@@ -0,0 +1,90 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pointer "k8s.io/utils/ptr"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// readReplicasLabel selects the Pods of the kube-apiserver read replicas: the Tenant Control Plane name label
+// is not set on purpose, since it's used by the Service, and the API Server Deployment selectors.
+const readReplicasLabel = "kamaji.clastix.io/read-replicas"
+
+// ReadReplicasName returns the name of the Deployment, and of the Service, of the kube-apiserver read replicas.
+func ReadReplicasName(tcp kamajiv1alpha1.TenantControlPlane) string {
+	return fmt.Sprintf("%s-read", tcp.GetName())
+}
+
+// ReadReplicasSelector returns the labels selecting the Pods of the kube-apiserver read replicas.
+func ReadReplicasSelector(tcp kamajiv1alpha1.TenantControlPlane) map[string]string {
+	return map[string]string{readReplicasLabel: ReadReplicasName(tcp)}
+}
+
+// BuildReadReplicas builds the Deployment of the kube-apiserver read replicas, sharing the specification of the
+// Tenant Control Plane Deployment, with no controller-manager, and scheduler, containers.
+func (d Deployment) BuildReadReplicas(ctx context.Context, deployment *appsv1.Deployment, tenantControlPlane kamajiv1alpha1.TenantControlPlane) {
+	tcp := *tenantControlPlane.DeepCopy()
+	selector := ReadReplicasSelector(tcp)
+
+	d.Build(ctx, deployment, tcp)
+
+	labels := d.templateLabels(ctx, &tcp)
+	delete(labels, "kamaji.clastix.io/name")
+
+	d.setLabels(deployment, utilities.MergeMaps(utilities.KamajiLabels(tcp.GetName(), "read-replicas"), tcp.Spec.ControlPlane.Deployment.AdditionalMetadata.Labels))
+	d.setTemplateLabels(&deployment.Spec.Template, utilities.MergeMaps(selector, labels))
+
+	deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: selector}
+	deployment.Spec.Replicas = d.readReplicas(tcp)
+
+	d.setTopologySpreadConstraints(&deployment.Spec, d.readReplicasTopologySpreadConstraints(tenantControlPlane))
+
+	podSpec := &deployment.Spec.Template.Spec
+	d.removeContainers(podSpec, schedulerContainerName, controlPlaneContainerName)
+	d.removeVolumes(podSpec, schedulerKubeconfigVolumeName, controllerManagerKubeconfigVolumeName)
+
+	d.Client.Scheme().Default(deployment)
+}
+
+func (d Deployment) readReplicas(tenantControlPlane kamajiv1alpha1.TenantControlPlane) *int32 {
+	if tenantControlPlane.IsQuarantined() || tenantControlPlane.IsSuspended() {
+		return pointer.To[int32](0)
+	}
+
+	if readReplicas := tenantControlPlane.Spec.ControlPlane.ReadReplicas; readReplicas != nil && readReplicas.Replicas != nil {
+		return readReplicas.Replicas
+	}
+
+	return pointer.To[int32](1)
+}
+
+// readReplicasTopologySpreadConstraints returns the topology spread constraints of the Tenant Control Plane,
+// selecting the read replicas Pods when no selector, or the Tenant Control Plane one, is specified.
+func (d Deployment) readReplicasTopologySpreadConstraints(tenantControlPlane kamajiv1alpha1.TenantControlPlane) []corev1.TopologySpreadConstraint {
+	tcpSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"kamaji.clastix.io/name": tenantControlPlane.GetName()}}
+
+	topologies := make([]corev1.TopologySpreadConstraint, 0, len(tenantControlPlane.Spec.ControlPlane.Deployment.TopologySpreadConstraints))
+
+	for _, topology := range tenantControlPlane.Spec.ControlPlane.Deployment.TopologySpreadConstraints {
+		topology := *topology.DeepCopy()
+
+		if apiequality.Semantic.DeepEqual(topology.LabelSelector, tcpSelector) {
+			topology.LabelSelector = nil
+		}
+
+		topologies = append(topologies, topology)
+	}
+
+	return topologies
+}
@@ -0,0 +1,210 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	pointer "k8s.io/utils/ptr"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	kamajiconstants "github.com/clastix/kamaji/internal/constants"
+)
+
+func TestWatchCacheSizesArgs(t *testing.T) {
+	for _, tc := range []struct {
+		name                      string
+		tuning                    *kamajiv1alpha1.APIServerTuningSpec
+		wantDefaultWatchCacheSize string
+		wantWatchCacheSizes       string
+	}{
+		{
+			name: "no tuning",
+		},
+		{
+			name:   "no sizes",
+			tuning: &kamajiv1alpha1.APIServerTuningSpec{},
+		},
+		{
+			name:                      "default size only",
+			tuning:                    &kamajiv1alpha1.APIServerTuningSpec{DefaultWatchCacheSize: pointer.To(int32(0))},
+			wantDefaultWatchCacheSize: "0",
+		},
+		{
+			name: "sorted sizes",
+			tuning: &kamajiv1alpha1.APIServerTuningSpec{
+				DefaultWatchCacheSize: pointer.To(int32(100)),
+				WatchCacheSizes: map[string]int32{
+					"secrets":          0,
+					"deployments.apps": 500,
+					"pods":             1000,
+				},
+			},
+			wantDefaultWatchCacheSize: "100",
+			wantWatchCacheSizes:       "deployments.apps#500,pods#1000,secrets#0",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tcp := kamajiv1alpha1.TenantControlPlane{
+				Spec: kamajiv1alpha1.TenantControlPlaneSpec{
+					ControlPlane: kamajiv1alpha1.ControlPlane{
+						APIServer: &kamajiv1alpha1.APIServerSpec{Tuning: tc.tuning},
+					},
+				},
+			}
+
+			args := Deployment{}.apiServerRequestHandlingArgs(tcp)
+			// The empty values are removing the flags from the kube-apiserver arguments.
+			for flag, want := range map[string]string{
+				"--default-watch-cache-size": tc.wantDefaultWatchCacheSize,
+				"--watch-cache-sizes":        tc.wantWatchCacheSizes,
+			} {
+				got, ok := args[flag]
+				if !ok {
+					t.Fatalf("expected the %s flag to be managed", flag)
+				}
+
+				if got != want {
+					t.Fatalf("expected %q for the %s flag, got %q", want, flag, got)
+				}
+			}
+		})
+	}
+}
+
+func TestServiceAccountVerificationKeysProjection(t *testing.T) {
+	tcp := kamajiv1alpha1.TenantControlPlane{}
+	tcp.Status.Certificates.SA.SecretName = "tcp-sa-certificate"
+	tcp.Status.Certificates.SA.Rotation = &kamajiv1alpha1.KeyRotationStatus{Phase: kamajiv1alpha1.KeyRotationPhaseOverlapping}
+
+	d := Deployment{DataStore: kamajiv1alpha1.DataStore{Spec: kamajiv1alpha1.DataStoreSpec{Driver: kamajiv1alpha1.KineMySQLDriver}}}
+
+	verificationProjection := func() *corev1.SecretProjection {
+		podSpec := &corev1.PodSpec{}
+		d.buildPKIVolume(podSpec, tcp)
+
+		for _, source := range podSpec.Volumes[0].Projected.Sources {
+			if source.Secret == nil || source.Secret.Name != "tcp-sa-certificate" {
+				continue
+			}
+
+			for _, item := range source.Secret.Items {
+				if item.Key == kamajiconstants.ServiceAccountVerificationKeysName {
+					return source.Secret
+				}
+			}
+		}
+
+		return nil
+	}
+
+	projection := verificationProjection()
+	if projection == nil {
+		t.Fatal("expected the verification keys to be projected during the rotation overlap")
+	}
+
+	// The keys are removed from the Secret before the Deployment is rolled out.
+	if projection.Optional == nil || !*projection.Optional || len(projection.Items) != 1 {
+		t.Fatalf("expected an optional projection of the verification keys only, got %+v", projection)
+	}
+
+	tcp.Status.Certificates.SA.Rotation.Phase = kamajiv1alpha1.KeyRotationPhaseCompleted
+
+	if projection = verificationProjection(); projection != nil {
+		t.Fatal("expected the verification keys not to be projected once the rotation is completed")
+	}
+}
+
+func TestKineDefaults(t *testing.T) {
+	defaults := corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")}}
+	override := corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")}}
+
+	for _, tc := range []struct {
+		name          string
+		kinePolicy    corev1.PullPolicy
+		tcpPolicy     corev1.PullPolicy
+		tcpResources  *kamajiv1alpha1.ControlPlaneComponentsResources
+		wantPolicy    corev1.PullPolicy
+		wantResources corev1.ResourceRequirements
+	}{
+		{name: "inferred from the tag", wantPolicy: corev1.PullIfNotPresent, wantResources: defaults},
+		{name: "Kamaji default", kinePolicy: corev1.PullNever, wantPolicy: corev1.PullNever, wantResources: defaults},
+		{name: "Tenant Control Plane precedence", kinePolicy: corev1.PullNever, tcpPolicy: corev1.PullAlways, wantPolicy: corev1.PullAlways, wantResources: defaults},
+		{name: "Tenant Control Plane resources", tcpResources: &kamajiv1alpha1.ControlPlaneComponentsResources{Kine: &override}, wantPolicy: corev1.PullIfNotPresent, wantResources: override},
+		{name: "no Kine resources", tcpResources: &kamajiv1alpha1.ControlPlaneComponentsResources{}, wantPolicy: corev1.PullIfNotPresent, wantResources: defaults},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tcp := kamajiv1alpha1.TenantControlPlane{}
+			tcp.Spec.ControlPlane.Deployment.ImagePullPolicy = tc.tcpPolicy
+			tcp.Spec.ControlPlane.Deployment.Resources = tc.tcpResources
+
+			d := Deployment{
+				KineContainerImage: "rancher/kine:v0.11.4-amd64",
+				KinePullPolicy:     tc.kinePolicy,
+				KineResources:      defaults,
+				DataStore:          kamajiv1alpha1.DataStore{Spec: kamajiv1alpha1.DataStoreSpec{Driver: kamajiv1alpha1.KineMySQLDriver}},
+			}
+
+			podSpec := &corev1.PodSpec{}
+			d.buildKine(podSpec, tcp)
+
+			for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+				if container.ImagePullPolicy != tc.wantPolicy {
+					t.Fatalf("expected the %s pull policy %s, got %s", container.Name, tc.wantPolicy, container.ImagePullPolicy)
+				}
+			}
+
+			if len(podSpec.Containers) == 0 {
+				t.Fatal("expected the Kine container")
+			}
+
+			if got := podSpec.Containers[0].Resources; !got.Requests.Memory().Equal(*tc.wantResources.Requests.Memory()) {
+				t.Fatalf("expected the Kine memory request %s, got %s", tc.wantResources.Requests.Memory(), got.Requests.Memory())
+			}
+		})
+	}
+}
+
+func TestKineImage(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		image      string
+		wantImage  string
+		wantPolicy corev1.PullPolicy
+	}{
+		{name: "Kamaji default", wantImage: "rancher/kine:v0.11.4-amd64", wantPolicy: corev1.PullIfNotPresent},
+		{name: "Tenant Control Plane override", image: "rancher/kine:v0.11.2-amd64", wantImage: "rancher/kine:v0.11.2-amd64", wantPolicy: corev1.PullIfNotPresent},
+		{name: "untagged override", image: "registry.local:5000/kine", wantImage: "registry.local:5000/kine", wantPolicy: corev1.PullAlways},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tcp := kamajiv1alpha1.TenantControlPlane{}
+			tcp.Spec.DataStoreOptions = &kamajiv1alpha1.DataStoreOptions{Kine: &kamajiv1alpha1.KineDataStoreOptions{Image: tc.image}}
+
+			d := Deployment{
+				KineContainerImage: "rancher/kine:v0.11.4-amd64",
+				DataStore:          kamajiv1alpha1.DataStore{Spec: kamajiv1alpha1.DataStoreSpec{Driver: kamajiv1alpha1.KineMySQLDriver}},
+			}
+
+			podSpec := &corev1.PodSpec{}
+			d.buildKine(podSpec, tcp)
+
+			containers := append(podSpec.InitContainers, podSpec.Containers...)
+			if len(containers) != 2 {
+				t.Fatalf("expected the Kine init container and container, got %d containers", len(containers))
+			}
+
+			for _, container := range containers {
+				if container.Image != tc.wantImage {
+					t.Fatalf("expected the %s image %s, got %s", container.Name, tc.wantImage, container.Image)
+				}
+
+				if container.ImagePullPolicy != tc.wantPolicy {
+					t.Fatalf("expected the %s pull policy %s, got %s", container.Name, tc.wantPolicy, container.ImagePullPolicy)
+				}
+			}
+		})
+	}
+}
@@ -33,7 +33,9 @@ type Konnectivity struct {
 	Scheme runtime.Scheme
 }
 
-func (k Konnectivity) buildKonnectivityContainer(addon *kamajiv1alpha1.KonnectivitySpec, replicas int32, podSpec *corev1.PodSpec) {
+func (k Konnectivity) buildKonnectivityContainer(tenantControlPlane kamajiv1alpha1.TenantControlPlane, replicas int32, podSpec *corev1.PodSpec) {
+	addon := tenantControlPlane.Spec.Addons.Konnectivity
+
 	found, index := utilities.HasNamedContainer(podSpec.Containers, konnectivityServerName)
 	if !found {
 		index = len(podSpec.Containers)
@@ -110,7 +112,7 @@ func (k Konnectivity) buildKonnectivityContainer(addon *kamajiv1alpha1.Konnectiv
 			ReadOnly:  false,
 		},
 	}
-	podSpec.Containers[index].ImagePullPolicy = corev1.PullAlways
+	podSpec.Containers[index].ImagePullPolicy = imagePullPolicy(tenantControlPlane, podSpec.Containers[index].Image)
 	podSpec.Containers[index].Resources = corev1.ResourceRequirements{
 		Limits:   nil,
 		Requests: nil,
@@ -254,7 +256,7 @@ func (k Konnectivity) buildVolumes(status kamajiv1alpha1.KonnectivityStatus, pod
 }
 
 func (k Konnectivity) Build(deployment *appsv1.Deployment, tenantControlPlane kamajiv1alpha1.TenantControlPlane) {
-	k.buildKonnectivityContainer(tenantControlPlane.Spec.Addons.Konnectivity, *tenantControlPlane.Spec.ControlPlane.Deployment.Replicas, &deployment.Spec.Template.Spec)
+	k.buildKonnectivityContainer(tenantControlPlane, *tenantControlPlane.Spec.ControlPlane.Deployment.Replicas, &deployment.Spec.Template.Spec)
 	k.buildVolumeMounts(&deployment.Spec.Template.Spec)
 	k.buildVolumes(tenantControlPlane.Status.Addons.Konnectivity, &deployment.Spec.Template.Spec)
 
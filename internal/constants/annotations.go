@@ -7,4 +7,16 @@ const (
 	// Checksum is the annotation label that we use to store the checksum for the resource:
 	// it allows to check by comparing it if the resource has been changed and must be aligned with the reconciliation.
 	Checksum = "kamaji.clastix.io/checksum"
+	// CrossDriverMigrationConfirmation is the annotation that must be set to "true" on a Tenant Control Plane
+	// to confirm the migration of its data to a DataStore backed by a different driver, such as from MySQL to etcd.
+	CrossDriverMigrationConfirmation = "kamaji.clastix.io/confirm-cross-driver-migration"
+	// AllowDataStoreMigration is the annotation that must be set to "true" on a Tenant Control Plane
+	// to allow the change of its DataStore, which triggers the migration of its data.
+	AllowDataStoreMigration = "kamaji.clastix.io/allow-datastore-migration"
+	// ManagedAnnotations tracks the keys of the user-provided annotations applied by Kamaji to a resource,
+	// allowing to remove them once dropped from the specification, without affecting the ones set by third parties.
+	ManagedAnnotations = "kamaji.clastix.io/managed-annotations"
+	// QuarantineAnnotation is the annotation that must be set to "true" on a Tenant Control Plane to quarantine it:
+	// its control plane Pods are scaled down to zero, stopping any access to the DataStore, until it's removed.
+	QuarantineAnnotation = "kamaji.clastix.io/quarantine"
 )
@@ -0,0 +1,13 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package constants
+
+const (
+	// ServiceAccountVerificationKeysName is the Service Account Secret key holding both the current,
+	// and the previous public keys during a signing key rotation overlap.
+	ServiceAccountVerificationKeysName = "sa-verification.pub"
+	// ClusterCABundleName is the cluster CA bundle Secret key holding the Tenant Control Plane CA,
+	// along with the additional Certificate Authorities distributed to the in-cluster clients.
+	ClusterCABundleName = "cluster-ca-bundle.crt"
+)
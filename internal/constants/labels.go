@@ -10,4 +10,13 @@ const (
 	ControlPlaneLabelKey      = "kamaji.clastix.io/name"
 	ControlPlaneLabelResource = "kamaji.clastix.io/component"
 	ControllerLabelResource   = "kamaji.clastix.io/certificate_lifecycle_controller"
+	// ControlPlaneDeploymentLabelValue is the component label value of the Tenant Control Plane Pods.
+	ControlPlaneDeploymentLabelValue = "deployment"
+
+	// DataStoreTierLabel is the DataStore label used to group DataStores with similar performances,
+	// such as for the kube-apiserver inflight defaults: when missing, the DataStore driver is used as tier.
+	DataStoreTierLabel = "kamaji.clastix.io/datastore-tier"
+	// NetworkLabel is the Tenant Control Plane label used to group the tenants sharing a routable network,
+	// such as for the detection of overlapping Pod and Service CIDRs.
+	NetworkLabel = "kamaji.clastix.io/network"
 )
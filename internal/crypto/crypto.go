@@ -5,19 +5,70 @@ package crypto
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	cryptorand "crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/hex"
 	"encoding/pem"
 	"fmt"
 	"math/big"
 	mathrand "math/rand"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	"k8s.io/client-go/util/keyutil"
 )
 
+// Supported algorithms of the generated private keys.
+const (
+	KeyAlgorithmRSA2048   = "rsa-2048"
+	KeyAlgorithmRSA4096   = "rsa-4096"
+	KeyAlgorithmECDSAP256 = "ecdsa-p256"
+	KeyAlgorithmECDSAP384 = "ecdsa-p384"
+)
+
+// GeneratePrivateKey generates a private key of the given algorithm.
+func GeneratePrivateKey(algorithm string) (crypto.Signer, error) {
+	switch algorithm {
+	case KeyAlgorithmRSA2048:
+		return rsa.GenerateKey(cryptorand.Reader, 2048)
+	case KeyAlgorithmRSA4096:
+		return rsa.GenerateKey(cryptorand.Reader, 4096)
+	case KeyAlgorithmECDSAP256:
+		return ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+	case KeyAlgorithmECDSAP384:
+		return ecdsa.GenerateKey(elliptic.P384(), cryptorand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported private key algorithm %s", algorithm)
+	}
+}
+
+// PrivateKeyAlgorithm returns the algorithm of the given private key bytes.
+func PrivateKeyAlgorithm(content []byte) (string, error) {
+	key, err := ParsePrivateKeyBytes(content)
+	if err != nil {
+		return "", err
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return fmt.Sprintf("rsa-%d", k.N.BitLen()), nil
+	case *ecdsa.PrivateKey:
+		return fmt.Sprintf("ecdsa-p%d", k.Curve.Params().BitSize), nil
+	default:
+		return "", fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
 // CheckPublicAndPrivateKeyValidity checks if the given bytes for the private and public keys are valid.
 func CheckPublicAndPrivateKeyValidity(publicKey []byte, privateKey []byte) (bool, error) {
 	if len(publicKey) == 0 || len(privateKey) == 0 {
@@ -34,7 +85,7 @@ func CheckPublicAndPrivateKeyValidity(publicKey []byte, privateKey []byte) (bool
 		return false, err
 	}
 
-	return checkPublicKeys(privKey.PublicKey, *pubKey), nil
+	return checkPublicKeys(privKey.Public(), pubKey), nil
 }
 
 // CheckCertificateAndPrivateKeyPairValidity checks if the certificate and private key pair are valid.
@@ -78,23 +129,29 @@ func ParseCertificateBytes(content []byte) (*x509.Certificate, error) {
 	return crt, nil
 }
 
-// ParsePrivateKeyBytes takes the private key bytes returning an RSA private key by parsing it.
-func ParsePrivateKeyBytes(content []byte) (*rsa.PrivateKey, error) {
-	pemContent, _ := pem.Decode(content)
-	if pemContent == nil {
+// ParsePrivateKeyBytes takes the private key bytes returning an RSA, or ECDSA, private key by parsing it.
+func ParsePrivateKeyBytes(content []byte) (crypto.Signer, error) {
+	if pemContent, _ := pem.Decode(content); pemContent == nil {
 		return nil, fmt.Errorf("no right PEM block")
 	}
 
-	privateKey, err := x509.ParsePKCS1PrivateKey(pemContent.Bytes)
+	privateKey, err := keyutil.ParsePrivateKeyPEM(content)
 	if err != nil {
-		return nil, errors.Wrap(err, "cannot parse PKCS1 Private Key")
+		return nil, errors.Wrap(err, "cannot parse Private Key")
 	}
 
-	return privateKey, nil
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case *ecdsa.PrivateKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("expected *rsa.PrivateKey or *ecdsa.PrivateKey, got %T", privateKey)
+	}
 }
 
-// ParsePublicKeyBytes takes the public key bytes returning an RSA public key by parsing it.
-func ParsePublicKeyBytes(content []byte) (*rsa.PublicKey, error) {
+// ParsePublicKeyBytes takes the public key bytes returning an RSA, or ECDSA, public key by parsing it.
+func ParsePublicKeyBytes(content []byte) (crypto.PublicKey, error) {
 	pemContent, _ := pem.Decode(content)
 	if pemContent == nil {
 		return nil, fmt.Errorf("no right PEM block")
@@ -105,12 +162,14 @@ func ParsePublicKeyBytes(content []byte) (*rsa.PublicKey, error) {
 		return nil, err
 	}
 
-	rsaPublicKey, ok := publicKey.(*rsa.PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("expected *rsa.PublicKey, got %T", rsaPublicKey)
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return key, nil
+	case *ecdsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("expected *rsa.PublicKey or *ecdsa.PublicKey, got %T", publicKey)
 	}
-
-	return rsaPublicKey, nil
 }
 
 // IsValidCertificateKeyPairBytes checks if the certificate matches the private key bounded to it.
@@ -128,13 +187,39 @@ func IsValidCertificateKeyPairBytes(certificateBytes []byte, privateKeyBytes []b
 	switch {
 	case !checkCertificateValidity(*crt):
 		return false, nil
-	case !checkPublicKeys(*crt.PublicKey.(*rsa.PublicKey), key.PublicKey): //nolint:forcetypeassert
+	case !checkPublicKeys(crt.PublicKey, key.Public()):
 		return false, nil
 	default:
 		return true, nil
 	}
 }
 
+// VerifyCertificateHostnames checks if the certificate matches the private key, and it is valid for all the given hostnames.
+func VerifyCertificateHostnames(certificate []byte, privateKey []byte, hostnames ...string) error {
+	pair, err := tls.X509KeyPair(certificate, privateKey)
+	if err != nil {
+		return errors.Wrap(err, "invalid certificate and private key pair")
+	}
+
+	crt, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return errors.Wrap(err, "cannot parse x509 Certificate")
+	}
+
+	if !checkCertificateValidity(*crt) {
+		return fmt.Errorf("certificate is expired or not yet valid")
+	}
+
+	for _, hostname := range hostnames {
+		// Wildcard hostnames are verified against a fictitious subdomain.
+		if err = crt.VerifyHostname(strings.Replace(hostname, "*", "kamaji", 1)); err != nil {
+			return errors.Wrapf(err, "certificate is not valid for hostname %s", hostname)
+		}
+	}
+
+	return nil
+}
+
 func VerifyCertificate(cert, ca []byte, usages ...x509.ExtKeyUsage) (bool, error) {
 	if len(usages) == 0 {
 		return false, fmt.Errorf("missing usages for certificate verification")
@@ -161,7 +246,233 @@ func VerifyCertificate(cert, ca []byte, usages ...x509.ExtKeyUsage) (bool, error
 	return len(chains) > 0, err
 }
 
-func generateCertificateKeyPairBytes(template *x509.Certificate, caCert *x509.Certificate, caKey *rsa.PrivateKey) (*bytes.Buffer, *bytes.Buffer, error) {
+// VerifyCertificatePrivateKey checks if the private key matches the public key of the given certificate,
+// regardless of its validity period.
+func VerifyCertificatePrivateKey(certificate []byte, privateKey []byte) error {
+	crt, err := ParseCertificateBytes(certificate)
+	if err != nil {
+		return err
+	}
+
+	key, err := ParsePrivateKeyBytes(privateKey)
+	if err != nil {
+		return err
+	}
+
+	if !checkPublicKeys(crt.PublicKey, key.Public()) {
+		return fmt.Errorf("the private key doesn't match the certificate %s", crt.Subject.String())
+	}
+
+	return nil
+}
+
+// VerifyCertificateChain verifies the given certificate, optionally followed by its intermediates,
+// chains up to the given Certificate Authority bundle, with no constraints on the usages.
+func VerifyCertificateChain(chain, ca []byte) error {
+	certificates, err := parseCertificatesBytes(chain)
+	if err != nil {
+		return errors.Wrap(err, "cannot parse the certificate chain")
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(ca) {
+		return fmt.Errorf("the Certificate Authority bundle doesn't contain any valid certificate")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, intermediate := range certificates[1:] {
+		intermediates.AddCert(intermediate)
+	}
+
+	if _, err = certificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return errors.Wrapf(err, "the certificate %s is not signed by the Certificate Authority", certificates[0].Subject.String())
+	}
+
+	return nil
+}
+
+// VerifyClientCertificateChain verifies the given client certificate, optionally followed by its intermediates,
+// chains up to the given Certificate Authority bundle with the client authentication usages:
+// the extended key usage is required to be explicitly set, and the chain cannot be longer than the given maximum depth,
+// including the root, unless it is zero.
+func VerifyClientCertificateChain(chain, ca []byte, maxDepth int) error {
+	certificates, err := parseCertificatesBytes(chain)
+	if err != nil {
+		return errors.Wrap(err, "cannot parse the client certificate chain")
+	}
+
+	leaf := certificates[0]
+
+	if !checkCertificateValidity(*leaf) {
+		return fmt.Errorf("the client certificate %s is expired or not yet valid", leaf.Subject.String())
+	}
+
+	if leaf.KeyUsage != 0 && leaf.KeyUsage&x509.KeyUsageDigitalSignature == 0 {
+		return fmt.Errorf("the client certificate %s is missing the digitalSignature key usage", leaf.Subject.String())
+	}
+
+	hasClientAuth := false
+
+	for _, usage := range leaf.ExtKeyUsage {
+		if usage == x509.ExtKeyUsageClientAuth {
+			hasClientAuth = true
+
+			break
+		}
+	}
+
+	if !hasClientAuth {
+		return fmt.Errorf("the client certificate %s is missing the clientAuth extended key usage", leaf.Subject.String())
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(ca) {
+		return fmt.Errorf("the Certificate Authority bundle doesn't contain any valid certificate")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, intermediate := range certificates[1:] {
+		intermediates.AddCert(intermediate)
+	}
+
+	chains, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "the client certificate %s doesn't chain up to the Certificate Authority", leaf.Subject.String())
+	}
+
+	if maxDepth == 0 {
+		return nil
+	}
+
+	for _, c := range chains {
+		if len(c) <= maxDepth {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the client certificate %s chain is deeper than the maximum allowed of %d certificates", leaf.Subject.String(), maxDepth)
+}
+
+// CertificateFingerprint returns the SHA-256 fingerprint of the given PEM encoded certificate, hex encoded.
+func CertificateFingerprint(content []byte) (string, error) {
+	certificate, err := ParseCertificateBytes(content)
+	if err != nil {
+		return "", err
+	}
+
+	fingerprint := sha256.Sum256(certificate.Raw)
+
+	return hex.EncodeToString(fingerprint[:]), nil
+}
+
+// VerifyCABundle checks that the given PEM encoded bundle contains Certificate Authorities only,
+// and that all of them are not expired.
+func VerifyCABundle(bundle []byte) error {
+	certificates, err := parseCertificatesBytes(bundle)
+	if err != nil {
+		return err
+	}
+
+	for _, certificate := range certificates {
+		if !certificate.IsCA {
+			return fmt.Errorf("the certificate %s is not a Certificate Authority", certificate.Subject.String())
+		}
+
+		if !checkCertificateValidity(*certificate) {
+			return fmt.Errorf("the Certificate Authority %s is expired, or not yet valid", certificate.Subject.String())
+		}
+	}
+
+	return nil
+}
+
+// EncryptionKeySize is the size of the AES-256 keys used by EncryptContent and DecryptContent.
+const EncryptionKeySize = 32
+
+// EncryptContent encrypts the given content with AES-256-GCM: the random nonce is prepended to the returned ciphertext.
+func EncryptContent(key, content []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = cryptorand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "cannot generate the nonce")
+	}
+
+	return aead.Seal(nonce, nonce, content, nil), nil
+}
+
+// DecryptContent decrypts the given ciphertext returned by EncryptContent with the same key.
+func DecryptContent(key, ciphertext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, fmt.Errorf("the ciphertext is shorter than the nonce")
+	}
+
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+
+	return aead.Open(nil, nonce, sealed, nil)
+}
+
+// newAEAD returns the AES-256-GCM cipher of the given key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != EncryptionKeySize {
+		return nil, fmt.Errorf("expecting an encryption key of %d bytes, got %d", EncryptionKeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// parseCertificatesBytes parses all the PEM encoded certificates of the given content, preserving their order.
+func parseCertificatesBytes(content []byte) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+
+	for rest := content; ; {
+		var block *pem.Block
+
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		crt, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot parse x509 Certificate")
+		}
+
+		certificates = append(certificates, crt)
+	}
+
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("no right PEM block")
+	}
+
+	return certificates, nil
+}
+
+func generateCertificateKeyPairBytes(template *x509.Certificate, caCert *x509.Certificate, caKey crypto.Signer) (*bytes.Buffer, *bytes.Buffer, error) {
 	certPrivKey, err := rsa.GenerateKey(cryptorand.Reader, 2048)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "cannot generate an RSA key")
@@ -201,11 +512,10 @@ func checkCertificateValidity(cert x509.Certificate) bool {
 	return notAfter && notBefore
 }
 
-func checkPublicKeys(a rsa.PublicKey, b rsa.PublicKey) bool {
-	isN := a.N.Cmp(b.N) == 0
-	isE := a.E == b.E
+func checkPublicKeys(a crypto.PublicKey, b crypto.PublicKey) bool {
+	key, ok := a.(interface{ Equal(x crypto.PublicKey) bool })
 
-	return isN && isE
+	return ok && key.Equal(b)
 }
 
 // NewCertificateTemplate returns the template that must be used to generate a certificate,
@@ -0,0 +1,272 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package crypto
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         crypto.Signer
+	pem         []byte
+	keyPEM      []byte
+}
+
+// issue returns a certificate for the given template, signed by the given parent, or self-signed when nil.
+func issue(t *testing.T, template *x509.Certificate, parent *testCertificate) *testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template.SerialNumber = serial
+
+	if template.NotBefore.IsZero() {
+		template.NotBefore = time.Now().Add(-time.Hour)
+	}
+
+	if template.NotAfter.IsZero() {
+		template.NotAfter = time.Now().AddDate(1, 0, 0)
+	}
+
+	signerCertificate, signerKey := template, crypto.Signer(key)
+	if parent != nil {
+		signerCertificate, signerKey = parent.certificate, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCertificate, key.Public(), signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCertificate{
+		certificate: certificate,
+		key:         key,
+		pem:         pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func caTemplate(commonName string) *x509.Certificate {
+	return &x509.Certificate{
+		Subject:               pkix.Name{CommonName: commonName},
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+}
+
+func clientTemplate(commonName string) *x509.Certificate {
+	return &x509.Certificate{
+		Subject:     pkix.Name{CommonName: commonName},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+}
+
+func concat(contents ...[]byte) []byte {
+	var result []byte
+	for _, content := range contents {
+		result = append(result, content...)
+	}
+
+	return result
+}
+
+func TestVerifyCertificateChain(t *testing.T) {
+	ca, other := issue(t, caTemplate("ca"), nil), issue(t, caTemplate("other"), nil)
+	intermediate := issue(t, caTemplate("intermediate"), ca)
+
+	expired := clientTemplate("expired")
+	expired.NotBefore, expired.NotAfter = time.Now().AddDate(-1, 0, 0), time.Now().Add(-time.Hour)
+
+	for _, tc := range []struct {
+		name    string
+		chain   []byte
+		ca      []byte
+		wantErr bool
+	}{
+		{name: "signed by the CA", chain: issue(t, clientTemplate("leaf"), ca).pem, ca: ca.pem},
+		{name: "with intermediate", chain: concat(issue(t, clientTemplate("leaf"), intermediate).pem, intermediate.pem), ca: ca.pem},
+		{name: "missing intermediate", chain: issue(t, clientTemplate("leaf"), intermediate).pem, ca: ca.pem, wantErr: true},
+		{name: "wrong CA", chain: issue(t, clientTemplate("leaf"), ca).pem, ca: other.pem, wantErr: true},
+		{name: "CA bundle", chain: issue(t, clientTemplate("leaf"), ca).pem, ca: concat(other.pem, ca.pem)},
+		{name: "expired leaf", chain: issue(t, expired, ca).pem, ca: ca.pem, wantErr: true},
+		{name: "empty CA", chain: issue(t, clientTemplate("leaf"), ca).pem, ca: nil, wantErr: true},
+		{name: "malformed chain", chain: []byte("malformed"), ca: ca.pem, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := VerifyCertificateChain(tc.chain, tc.ca); (err != nil) != tc.wantErr {
+				t.Fatalf("expected the error %t, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestVerifyClientCertificateChain(t *testing.T) {
+	ca, other := issue(t, caTemplate("ca"), nil), issue(t, caTemplate("other"), nil)
+	intermediate := issue(t, caTemplate("intermediate"), ca)
+
+	expired := clientTemplate("expired")
+	expired.NotBefore, expired.NotAfter = time.Now().AddDate(-1, 0, 0), time.Now().Add(-time.Hour)
+
+	serverOnly := clientTemplate("server")
+	serverOnly.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+
+	noDigitalSignature := clientTemplate("no-digital-signature")
+	noDigitalSignature.KeyUsage = x509.KeyUsageKeyEncipherment
+
+	leaf := issue(t, clientTemplate("leaf"), ca).pem
+	chained := concat(issue(t, clientTemplate("leaf"), intermediate).pem, intermediate.pem)
+
+	for _, tc := range []struct {
+		name     string
+		chain    []byte
+		ca       []byte
+		maxDepth int
+		wantErr  bool
+	}{
+		{name: "signed by the CA", chain: leaf, ca: ca.pem, maxDepth: 2},
+		{name: "wrong CA", chain: leaf, ca: other.pem, wantErr: true},
+		{name: "expired leaf", chain: issue(t, expired, ca).pem, ca: ca.pem, wantErr: true},
+		{name: "missing client authentication usage", chain: issue(t, serverOnly, ca).pem, ca: ca.pem, wantErr: true},
+		{name: "missing digital signature usage", chain: issue(t, noDigitalSignature, ca).pem, ca: ca.pem, wantErr: true},
+		{name: "chain within the depth", chain: chained, ca: ca.pem, maxDepth: 3},
+		{name: "too deep chain", chain: chained, ca: ca.pem, maxDepth: 2, wantErr: true},
+		{name: "unbounded depth", chain: chained, ca: ca.pem, maxDepth: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := VerifyClientCertificateChain(tc.chain, tc.ca, tc.maxDepth); (err != nil) != tc.wantErr {
+				t.Fatalf("expected the error %t, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestVerifyCertificatePrivateKey(t *testing.T) {
+	ca := issue(t, caTemplate("ca"), nil)
+	leaf, other := issue(t, clientTemplate("leaf"), ca), issue(t, clientTemplate("other"), ca)
+
+	if err := VerifyCertificatePrivateKey(leaf.pem, leaf.keyPEM); err != nil {
+		t.Fatalf("expected the private key to match, got %v", err)
+	}
+
+	if err := VerifyCertificatePrivateKey(leaf.pem, other.keyPEM); err == nil {
+		t.Fatal("expected a key mismatch")
+	}
+}
+
+func TestVerifyCABundle(t *testing.T) {
+	ca, other := issue(t, caTemplate("ca"), nil), issue(t, caTemplate("other"), nil)
+
+	expired := caTemplate("expired")
+	expired.NotBefore, expired.NotAfter = time.Now().AddDate(-1, 0, 0), time.Now().Add(-time.Hour)
+
+	for _, tc := range []struct {
+		name    string
+		bundle  []byte
+		wantErr bool
+	}{
+		{name: "single CA", bundle: ca.pem},
+		{name: "multiple CAs", bundle: concat(ca.pem, other.pem)},
+		{name: "leaf certificate", bundle: concat(ca.pem, issue(t, clientTemplate("leaf"), ca).pem), wantErr: true},
+		{name: "expired CA", bundle: concat(ca.pem, issue(t, expired, nil).pem), wantErr: true},
+		{name: "malformed", bundle: []byte("malformed"), wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := VerifyCABundle(tc.bundle); (err != nil) != tc.wantErr {
+				t.Fatalf("expected the error %t, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCertificateFingerprint(t *testing.T) {
+	ca := issue(t, caTemplate("ca"), nil)
+
+	fingerprint, err := CertificateFingerprint(ca.pem)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := sha256.Sum256(ca.certificate.Raw)
+	if fingerprint != hex.EncodeToString(want[:]) {
+		t.Fatalf("expected the fingerprint %x, got %s", want, fingerprint)
+	}
+
+	if other, _ := CertificateFingerprint(issue(t, caTemplate("ca"), nil).pem); other == fingerprint {
+		t.Fatal("expected different certificates to have different fingerprints")
+	}
+
+	if _, err = CertificateFingerprint([]byte("malformed")); err == nil {
+		t.Fatal("expected an error for a malformed certificate")
+	}
+}
+
+func TestEncryptContent(t *testing.T) {
+	key, other := make([]byte, EncryptionKeySize), make([]byte, EncryptionKeySize)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := rand.Read(other); err != nil {
+		t.Fatal(err)
+	}
+
+	ciphertext, err := EncryptContent(key, []byte("kubeconfig"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(ciphertext, []byte("kubeconfig")) {
+		t.Fatal("expected the content to be encrypted")
+	}
+
+	content, err := DecryptContent(key, ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != "kubeconfig" {
+		t.Fatalf("expected the content %q, got %q", "kubeconfig", content)
+	}
+
+	if _, err = DecryptContent(other, ciphertext); err == nil {
+		t.Fatal("expected the decryption with a different key to fail")
+	}
+
+	if _, err = EncryptContent(key[:16], []byte("kubeconfig")); err == nil {
+		t.Fatal("expected a short key to be rejected")
+	}
+}
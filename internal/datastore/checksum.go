@@ -0,0 +1,45 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+)
+
+// Checksum returns a digest of the given key-values, computed by sorting the keys:
+// it's independent of the driver that has been used to retrieve them.
+func Checksum(kvs map[string][]byte) string {
+	keys := make([]string, 0, len(kvs))
+	for key := range kvs {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	hash := sha256.New()
+
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(hash, "%d:%s:%d:", len(key), key, len(kvs[key]))
+		_, _ = hash.Write(kvs[key])
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// ValidateKeyValues ensures the target key-values are matching the origin ones,
+// both in terms of object count and content.
+func ValidateKeyValues(origin, target map[string][]byte) error {
+	if len(origin) != len(target) {
+		return fmt.Errorf("object count mismatch, expected %d, got %d", len(origin), len(target))
+	}
+
+	if originChecksum, targetChecksum := Checksum(origin), Checksum(target); originChecksum != targetChecksum {
+		return fmt.Errorf("checksum mismatch, expected %s, got %s", originChecksum, targetChecksum)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	kvs := map[string][]byte{"pods/default/a": []byte("a"), "pods/default/b": []byte("b")}
+
+	if Checksum(kvs) != Checksum(map[string][]byte{"pods/default/b": []byte("b"), "pods/default/a": []byte("a")}) {
+		t.Fatal("expected the checksum to be independent of the insertion order")
+	}
+
+	for name, other := range map[string]map[string][]byte{
+		"changed value":   {"pods/default/a": []byte("a"), "pods/default/b": []byte("c")},
+		"changed key":     {"pods/default/a": []byte("a"), "pods/default/c": []byte("b")},
+		"missing key":     {"pods/default/a": []byte("a")},
+		"shifted content": {"pods/default/a": []byte("apods/default/b"), "": []byte("b")},
+	} {
+		if Checksum(kvs) == Checksum(other) {
+			t.Fatalf("expected a different checksum for the %s", name)
+		}
+	}
+}
+
+func TestValidateKeyValues(t *testing.T) {
+	origin := map[string][]byte{"pods/default/a": []byte("a"), "pods/default/b": []byte("b")}
+
+	for _, tc := range []struct {
+		name    string
+		target  map[string][]byte
+		wantErr bool
+	}{
+		{name: "matching", target: map[string][]byte{"pods/default/a": []byte("a"), "pods/default/b": []byte("b")}},
+		{name: "count mismatch", target: map[string][]byte{"pods/default/a": []byte("a")}, wantErr: true},
+		{name: "content mismatch", target: map[string][]byte{"pods/default/a": []byte("a"), "pods/default/b": []byte("c")}, wantErr: true},
+		{name: "empty", target: nil, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateKeyValues(origin, tc.target); (err != nil) != tc.wantErr {
+				t.Fatalf("expected the error %t, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
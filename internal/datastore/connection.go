@@ -53,4 +53,14 @@ type Connection interface {
 	Check(ctx context.Context) error
 	Driver() string
 	Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection) error
+	// GetKeyValues returns the latest revision of the Kubernetes objects stored for the given Tenant Control Plane,
+	// keyed by their path relative to the kube-apiserver storage prefix.
+	GetKeyValues(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane) (map[string][]byte, error)
+	// PutKeyValues replaces the Kubernetes objects stored for the given Tenant Control Plane,
+	// the keys must be relative to the kube-apiserver storage prefix. Upon failure, the stored objects could be partial:
+	// the leftovers are removed by the next call.
+	PutKeyValues(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, kvs map[string][]byte) error
+	// CheckKineSchema ensures the kine table of the given Tenant Control Plane, if any, has been created by
+	// a Kine release supported by Kamaji, returning a KineSchemaMismatchError otherwise.
+	CheckKineSchema(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane) error
 }
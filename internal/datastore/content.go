@@ -0,0 +1,130 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+)
+
+// ContentError is returned when the TLS content of a DataStore cannot be resolved, or it's malformed.
+type ContentError struct {
+	EventReason     string
+	ConditionReason string
+	err             error
+}
+
+func (c ContentError) Error() string {
+	return c.err.Error()
+}
+
+func (c ContentError) Unwrap() error {
+	return c.err
+}
+
+// ContentResolver returns the content of the given reference: when it's not resolved, such as when the content
+// can't be retrieved offline, the checks requiring it are skipped.
+type ContentResolver func(ctx context.Context, ref kamajiv1alpha1.ContentRef) (content []byte, resolved bool, err error)
+
+// ClientContentResolver resolves the content references using the given client, as the DataStore controller does.
+func ClientContentResolver(c client.Client) ContentResolver {
+	return func(ctx context.Context, ref kamajiv1alpha1.ContentRef) ([]byte, bool, error) {
+		content, err := ref.GetContent(ctx, c)
+
+		return content, err == nil, err
+	}
+}
+
+// InlineContentResolver resolves the bare content only, leaving the Secret, and file, references unresolved.
+func InlineContentResolver() ContentResolver {
+	return func(_ context.Context, ref kamajiv1alpha1.ContentRef) ([]byte, bool, error) {
+		return ref.Content, len(ref.Content) > 0, nil
+	}
+}
+
+// ValidateContent retrieves the Certificate Authority certificate, and the client certificate and private key, of the given DataStore,
+// checking they're well-formed, and the client certificate matches the private key, and is signed by the Certificate Authority.
+// The names of the unresolved contents, whose checks have been skipped, are returned.
+// The contents are resolved sequentially: the controller reads the Secrets from the informers cache, once per reconciliation,
+// thus there are no API Server round-trips worth overlapping.
+func ValidateContent(ctx context.Context, resolve ContentResolver, ds kamajiv1alpha1.DataStore) (unresolved []string, err error) {
+	ca, caResolved, err := resolve(ctx, ds.Spec.TLSConfig.CertificateAuthority.Certificate)
+	if err == nil && caResolved {
+		if _, err = crypto.ParseCertificateBytes(ca); err != nil {
+			err = fmt.Errorf("%s: %w", contentSource(ds.Spec.TLSConfig.CertificateAuthority.Certificate), err)
+		}
+	}
+
+	if err != nil {
+		return nil, ContentError{EventReason: "InvalidCertificateAuthority", ConditionReason: "CertificateAuthorityInvalid", err: fmt.Errorf("invalid Certificate Authority certificate: %w", err)}
+	}
+
+	certificate, certificateResolved, err := resolve(ctx, ds.Spec.TLSConfig.ClientCertificate.Certificate)
+	if err == nil && certificateResolved {
+		if _, err = crypto.ParseCertificateBytes(certificate); err != nil {
+			err = fmt.Errorf("%s: %w", contentSource(ds.Spec.TLSConfig.ClientCertificate.Certificate), err)
+		}
+	}
+
+	if err != nil {
+		return nil, ContentError{EventReason: "InvalidClientCertificate", ConditionReason: "ClientCertificateInvalid", err: fmt.Errorf("invalid client certificate: %w", err)}
+	}
+
+	privateKey, privateKeyResolved, err := resolve(ctx, ds.Spec.TLSConfig.ClientCertificate.PrivateKey)
+	if err == nil && privateKeyResolved {
+		if _, err = crypto.ParsePrivateKeyBytes(privateKey); err != nil {
+			err = fmt.Errorf("%s: %w", contentSource(ds.Spec.TLSConfig.ClientCertificate.PrivateKey), err)
+		}
+	}
+
+	if err != nil {
+		return nil, ContentError{EventReason: "InvalidClientCertificate", ConditionReason: "PrivateKeyInvalid", err: fmt.Errorf("invalid client private key: %w", err)}
+	}
+	// The client certificate must be a coherent pair signed by the Certificate Authority,
+	// otherwise the failure would be noticed by the Tenant Control Planes only, upon connection.
+	if certificateResolved && privateKeyResolved {
+		if err = crypto.VerifyCertificatePrivateKey(certificate, privateKey); err != nil {
+			return nil, ContentError{EventReason: "InvalidClientCertificate", ConditionReason: "PrivateKeyMismatch", err: fmt.Errorf("invalid client certificate pair: %w", err)}
+		}
+	}
+
+	if certificateResolved && caResolved {
+		if err = crypto.VerifyCertificateChain(certificate, ca); err != nil {
+			return nil, ContentError{EventReason: "InvalidClientCertificate", ConditionReason: "ClientCertificateUntrusted", err: fmt.Errorf("invalid client certificate: %w", err)}
+		}
+	}
+
+	if !caResolved {
+		unresolved = append(unresolved, "Certificate Authority certificate")
+	}
+
+	if !certificateResolved {
+		unresolved = append(unresolved, "client certificate")
+	}
+
+	if !privateKeyResolved {
+		unresolved = append(unresolved, "client private key")
+	}
+
+	return unresolved, nil
+}
+
+// contentSource describes where the content of the given reference is retrieved from, reported along with the parsing errors.
+func contentSource(ref kamajiv1alpha1.ContentRef) string {
+	switch {
+	case len(ref.Content) > 0:
+		return "bare content"
+	case len(ref.FilePath) > 0:
+		return fmt.Sprintf("file %s", ref.FilePath)
+	case ref.SecretRef != nil:
+		return fmt.Sprintf("secret %s/%s, key %s", ref.SecretRef.Namespace, ref.SecretRef.Name, ref.SecretRef.KeyPath)
+	default:
+		return "no content"
+	}
+}
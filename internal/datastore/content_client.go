@@ -0,0 +1,48 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// contentClient memoizes the Secrets retrieved by the underlying client, resolving the content references
+// pointing to the same Secret, such as the certificate and the private key ones, with a single read.
+type contentClient struct {
+	client.Client
+	secrets map[client.ObjectKey]*corev1.Secret
+}
+
+// NewContentClient returns a client reading each Secret once: it's meant to be used for the lifetime of a single
+// reconciliation, thus the rotated Secrets are retrieved again by the next one. It's not safe for concurrent use.
+func NewContentClient(c client.Client) client.Client {
+	return &contentClient{
+		Client:  c,
+		secrets: make(map[client.ObjectKey]*corev1.Secret),
+	}
+}
+
+func (c *contentClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+
+	if cached, found := c.secrets[key]; found {
+		cached.DeepCopyInto(secret)
+
+		return nil
+	}
+
+	if err := c.Client.Get(ctx, key, secret, opts...); err != nil {
+		return err
+	}
+
+	c.secrets[key] = secret.DeepCopy()
+
+	return nil
+}
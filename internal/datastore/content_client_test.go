@@ -0,0 +1,85 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestContentClient(t *testing.T) {
+	ctx := context.Background()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kamaji-system", Name: "client"},
+		Data:       map[string][]byte{"tls.crt": []byte("current")},
+	}
+
+	var reads int
+
+	c := interceptor.NewClient(fake.NewClientBuilder().WithObjects(secret).Build(), interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			reads++
+
+			return c.Get(ctx, key, obj, opts...)
+		},
+	})
+
+	get := func(t *testing.T, contentClient client.Client, name string) *corev1.Secret {
+		t.Helper()
+
+		got := &corev1.Secret{}
+		if err := contentClient.Get(ctx, client.ObjectKey{Namespace: "kamaji-system", Name: name}, got); err != nil {
+			t.Fatal(err)
+		}
+
+		return got
+	}
+
+	reconciliation := NewContentClient(c)
+
+	first := get(t, reconciliation, "client")
+	// The returned Secrets are copies, the memoized ones can't be altered by the callers.
+	first.Data["tls.crt"] = []byte("altered")
+
+	if got := get(t, reconciliation, "client"); string(got.Data["tls.crt"]) != "current" {
+		t.Fatalf("expected the memoized content, got %q", got.Data["tls.crt"])
+	}
+
+	if reads != 1 {
+		t.Fatalf("expected a single read of the Secret, got %d", reads)
+	}
+	// Rotating the Secret: the memo is scoped to a single reconciliation.
+	rotated := secret.DeepCopy()
+	rotated.Data["tls.crt"] = []byte("rotated")
+
+	if err := c.Update(ctx, rotated); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := get(t, reconciliation, "client"); string(got.Data["tls.crt"]) != "current" {
+		t.Fatalf("expected the content memoized by the current reconciliation, got %q", got.Data["tls.crt"])
+	}
+
+	if got := get(t, NewContentClient(c), "client"); string(got.Data["tls.crt"]) != "rotated" {
+		t.Fatalf("expected the rotated content upon the next reconciliation, got %q", got.Data["tls.crt"])
+	}
+	// The missing Secrets are not memoized, their creation is picked up.
+	if err := reconciliation.Get(ctx, client.ObjectKey{Namespace: "kamaji-system", Name: "next"}, &corev1.Secret{}); !k8serrors.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	if err := c.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "kamaji-system", Name: "next"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	get(t, reconciliation, "next")
+}
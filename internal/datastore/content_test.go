@@ -0,0 +1,117 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestContentSource(t *testing.T) {
+	secretRef := &kamajiv1alpha1.SecretReference{
+		SecretReference: corev1.SecretReference{Namespace: "kamaji-system", Name: "ca"},
+		KeyPath:         "tls.crt",
+	}
+
+	for _, tc := range []struct {
+		name string
+		ref  kamajiv1alpha1.ContentRef
+		want string
+	}{
+		{name: "inline", ref: kamajiv1alpha1.ContentRef{Content: []byte("content")}, want: "bare content"},
+		{name: "inline over Secret", ref: kamajiv1alpha1.ContentRef{Content: []byte("content"), SecretRef: secretRef}, want: "bare content"},
+		{name: "file", ref: kamajiv1alpha1.ContentRef{FilePath: "/etc/kamaji/ca.crt"}, want: "file /etc/kamaji/ca.crt"},
+		{name: "Secret", ref: kamajiv1alpha1.ContentRef{SecretRef: secretRef}, want: "secret kamaji-system/ca, key tls.crt"},
+		{name: "none", ref: kamajiv1alpha1.ContentRef{}, want: "no content"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := contentSource(tc.ref); got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateContentError(t *testing.T) {
+	root := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(root, "ca.crt"), []byte("malformed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(previous string) {
+		kamajiv1alpha1.ContentFileRoot = previous
+	}(kamajiv1alpha1.ContentFileRoot)
+
+	kamajiv1alpha1.ContentFileRoot = root
+
+	c := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kamaji-system", Name: "ca"},
+		Data:       map[string][]byte{"tls.crt": []byte("malformed")},
+	}).Build()
+
+	for _, tc := range []struct {
+		name        string
+		ref         kamajiv1alpha1.ContentRef
+		wantMessage string
+	}{
+		{
+			name:        "inline",
+			ref:         kamajiv1alpha1.ContentRef{Content: []byte("malformed")},
+			wantMessage: "invalid Certificate Authority certificate: bare content: ",
+		},
+		{
+			name:        "file",
+			ref:         kamajiv1alpha1.ContentRef{FilePath: filepath.Join(root, "ca.crt")},
+			wantMessage: "invalid Certificate Authority certificate: file " + filepath.Join(root, "ca.crt") + ": ",
+		},
+		{
+			name: "Secret",
+			ref: kamajiv1alpha1.ContentRef{SecretRef: &kamajiv1alpha1.SecretReference{
+				SecretReference: corev1.SecretReference{Namespace: "kamaji-system", Name: "ca"},
+				KeyPath:         "tls.crt",
+			}},
+			wantMessage: "invalid Certificate Authority certificate: secret kamaji-system/ca, key tls.crt: ",
+		},
+		{
+			name:        "missing file",
+			ref:         kamajiv1alpha1.ContentRef{FilePath: filepath.Join(root, "missing.crt")},
+			wantMessage: "invalid Certificate Authority certificate: cannot read file " + filepath.Join(root, "missing.crt"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ds := kamajiv1alpha1.DataStore{}
+			ds.Spec.TLSConfig.CertificateAuthority.Certificate = tc.ref
+
+			_, err := ValidateContent(context.Background(), ClientContentResolver(c), ds)
+
+			var contentErr ContentError
+			if !errors.As(err, &contentErr) {
+				t.Fatalf("expected a content error, got %v", err)
+			}
+
+			if contentErr.ConditionReason != "CertificateAuthorityInvalid" || contentErr.EventReason != "InvalidCertificateAuthority" {
+				t.Fatalf("unexpected reasons, got %s and %s", contentErr.ConditionReason, contentErr.EventReason)
+			}
+
+			if !strings.HasPrefix(contentErr.Error(), tc.wantMessage) {
+				t.Fatalf("expected the message to start with %q, got %q", tc.wantMessage, contentErr.Error())
+			}
+
+			if errors.Unwrap(contentErr) == nil {
+				t.Fatal("expected the content error to wrap the cause")
+			}
+		})
+	}
+}
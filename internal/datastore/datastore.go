@@ -15,6 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
 )
 
 type ConnectionEndpoint struct {
@@ -38,15 +39,10 @@ type ConnectionConfig struct {
 func NewConnectionConfig(ctx context.Context, client client.Client, ds kamajiv1alpha1.DataStore) (*ConnectionConfig, error) {
 	ca, err := ds.Spec.TLSConfig.CertificateAuthority.Certificate.GetContent(ctx, client)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, "cannot retrieve the Certificate Authority certificate")
 	}
 
-	crt, err := ds.Spec.TLSConfig.ClientCertificate.Certificate.GetContent(ctx, client)
-	if err != nil {
-		return nil, err
-	}
-
-	key, err := ds.Spec.TLSConfig.ClientCertificate.PrivateKey.GetContent(ctx, client)
+	crt, key, err := ClientCertificate(ctx, client, ds)
 	if err != nil {
 		return nil, err
 	}
@@ -65,13 +61,13 @@ func NewConnectionConfig(ctx context.Context, client client.Client, ds kamajiv1a
 	if auth := ds.Spec.BasicAuth; auth != nil {
 		u, err := auth.Username.GetContent(ctx, client)
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrap(err, "cannot retrieve the basic-auth username")
 		}
 		user = string(u)
 
 		p, err := auth.Password.GetContent(ctx, client)
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrap(err, "cannot retrieve the basic-auth password")
 		}
 		password = string(p)
 	}
@@ -102,10 +98,55 @@ func NewConnectionConfig(ctx context.Context, client client.Client, ds kamajiv1a
 		TLSConfig: &tls.Config{
 			RootCAs:      rootCAs,
 			Certificates: []tls.Certificate{certificate},
+			// An empty value lets the drivers verify the server certificate against the endpoint host.
+			ServerName: ds.Spec.TLSConfig.ServerName,
 		},
 	}, nil
 }
 
+// ClientCertificate returns the certificate and private key to present to the data store:
+// the next client certificate is preferred when valid, falling back to the current one otherwise,
+// since it's still accepted by the data store during the rotation.
+func ClientCertificate(ctx context.Context, client client.Client, ds kamajiv1alpha1.DataStore) (crt []byte, key []byte, err error) {
+	if next := ds.Spec.TLSConfig.ClientCertificate.Next; next != nil {
+		if crt, key, err = ValidateNextClientCertificate(ctx, client, *next); err == nil {
+			return crt, key, nil
+		}
+	}
+
+	if crt, err = ds.Spec.TLSConfig.ClientCertificate.Certificate.GetContent(ctx, client); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot retrieve the client certificate")
+	}
+
+	if key, err = ds.Spec.TLSConfig.ClientCertificate.PrivateKey.GetContent(ctx, client); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot retrieve the client private key")
+	}
+
+	return crt, key, nil
+}
+
+// ValidateNextClientCertificate retrieves the next client certificate content, ensuring it's a valid key pair.
+func ValidateNextClientCertificate(ctx context.Context, client client.Client, next kamajiv1alpha1.ClientCertificateKeyPair) (crt []byte, key []byte, err error) {
+	if crt, err = next.Certificate.GetContent(ctx, client); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot retrieve the next client certificate")
+	}
+
+	if key, err = next.PrivateKey.GetContent(ctx, client); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot retrieve the next client private key")
+	}
+
+	valid, err := crypto.IsValidCertificateKeyPairBytes(crt, key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot validate the next client certificate")
+	}
+
+	if !valid {
+		return nil, nil, fmt.Errorf("the next client certificate is expired, or it doesn't match the private key")
+	}
+
+	return crt, key, nil
+}
+
 func (config ConnectionConfig) getDataSourceNameUserPassword() string {
 	if config.User == "" {
 		return ""
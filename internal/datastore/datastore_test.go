@@ -0,0 +1,85 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestNewConnectionConfigServerName(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kamaji"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(0, 0, 7),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crt, keyPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	for _, tc := range []struct {
+		name       string
+		serverName string
+	}{
+		{name: "endpoint host", serverName: ""},
+		{name: "custom server name", serverName: "etcd.example.com"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ds := kamajiv1alpha1.DataStore{
+				Spec: kamajiv1alpha1.DataStoreSpec{
+					Driver:    kamajiv1alpha1.EtcdDriver,
+					Endpoints: []string{"10.0.0.1:2379"},
+					TLSConfig: kamajiv1alpha1.TLSConfig{
+						CertificateAuthority: kamajiv1alpha1.CertKeyPair{Certificate: kamajiv1alpha1.ContentRef{Content: crt}},
+						ClientCertificate: kamajiv1alpha1.ClientCertificate{
+							Certificate: kamajiv1alpha1.ContentRef{Content: crt},
+							PrivateKey:  kamajiv1alpha1.ContentRef{Content: keyPEM},
+						},
+						ServerName: tc.serverName,
+					},
+				},
+			}
+
+			config, err := NewConnectionConfig(context.Background(), fake.NewClientBuilder().Build(), ds)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if config.TLSConfig.ServerName != tc.serverName {
+				t.Fatalf("expected the server name %q, got %q", tc.serverName, config.TLSConfig.ServerName)
+			}
+
+			if len(config.Endpoints) != 1 || config.Endpoints[0].Host != "10.0.0.1" || config.Endpoints[0].Port != 2379 {
+				t.Fatalf("expected the endpoint 10.0.0.1:2379, got %+v", config.Endpoints)
+			}
+		})
+	}
+}
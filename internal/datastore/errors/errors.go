@@ -48,3 +48,15 @@ func NewCheckConnectionError(err error) error {
 func NewCreateDBError(err error) error {
 	return errors.Wrap(err, "cannot create database")
 }
+
+func NewGetKeyValuesError(err error) error {
+	return errors.Wrap(err, "cannot retrieve key values")
+}
+
+func NewPutKeyValuesError(err error) error {
+	return errors.Wrap(err, "cannot store key values")
+}
+
+func NewCheckKineSchemaError(err error) error {
+	return errors.Wrap(err, "cannot check the kine schema")
+}
@@ -6,6 +6,8 @@ package datastore
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	goerrors "github.com/pkg/errors"
 	"go.etcd.io/etcd/api/v3/authpb"
@@ -21,6 +23,8 @@ const (
 	// If rangeEnd is ‘\0’, the range is all keys greater than or equal to the key argument
 	// source: https://etcd.io/docs/v3.5/learning/api/
 	rangeEnd = "\\0"
+	// etcdMaxTxnOps is the default maximum number of operations in a single etcd transaction, set by the --max-txn-ops flag.
+	etcdMaxTxnOps = 128
 )
 
 func NewETCDConnection(config ConnectionConfig) (Connection, error) {
@@ -194,3 +198,65 @@ func (e *EtcdClient) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantContr
 
 	return nil
 }
+
+func (e *EtcdClient) GetKeyValues(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane) (map[string][]byte, error) {
+	prefix := e.buildKey(tcp.Status.Storage.Setup.Schema)
+
+	response, err := e.Client.Get(ctx, prefix, etcdclient.WithPrefix())
+	if err != nil {
+		return nil, errors.NewGetKeyValuesError(err)
+	}
+
+	kvs := make(map[string][]byte, len(response.Kvs))
+
+	for _, kv := range response.Kvs {
+		kvs[strings.TrimPrefix(string(kv.Key), prefix)] = kv.Value
+	}
+
+	return kvs, nil
+}
+
+// PutKeyValues replaces the key-space of the Tenant Control Plane in transactions of etcdMaxTxnOps operations at most,
+// since etcd rejects the larger ones: the import is not atomic, a failure leaves a partial key-space that is removed
+// upon the next attempt, since the migration is retried from scratch.
+func (e *EtcdClient) PutKeyValues(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, kvs map[string][]byte) error {
+	for _, ops := range etcdPutKeyValuesOps(e.buildKey(tcp.Status.Storage.Setup.Schema), kvs) {
+		response, err := e.Client.Txn(ctx).Then(ops...).Commit()
+		if err != nil {
+			return errors.NewPutKeyValuesError(err)
+		}
+
+		if !response.Succeeded {
+			return errors.NewPutKeyValuesError(fmt.Errorf("the transaction has not been applied"))
+		}
+	}
+
+	return nil
+}
+
+// etcdPutKeyValuesOps returns the batches of operations replacing the given key-space, sorted by key:
+// the first batch removes any leftover from the previous attempts, the key-space must contain only the migrated objects.
+func etcdPutKeyValuesOps(prefix string, kvs map[string][]byte) [][]etcdclient.Op {
+	keys := make([]string, 0, len(kvs))
+	for key := range kvs {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	batches := [][]etcdclient.Op{{etcdclient.OpDelete(prefix, etcdclient.WithPrefix())}}
+
+	for _, key := range keys {
+		if last := batches[len(batches)-1]; len(last) == etcdMaxTxnOps {
+			batches = append(batches, make([]etcdclient.Op, 0, etcdMaxTxnOps))
+		}
+
+		batches[len(batches)-1] = append(batches[len(batches)-1], etcdclient.OpPut(prefix+key, string(kvs[key])))
+	}
+
+	return batches
+}
+
+func (e *EtcdClient) CheckKineSchema(context.Context, kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
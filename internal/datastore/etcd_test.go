@@ -0,0 +1,58 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEtcdPutKeyValuesOps(t *testing.T) {
+	for _, count := range []int{0, 1, etcdMaxTxnOps - 1, etcdMaxTxnOps, 3*etcdMaxTxnOps + 1} {
+		t.Run(fmt.Sprintf("%d keys", count), func(t *testing.T) {
+			kvs := make(map[string][]byte, count)
+			for i := 0; i < count; i++ {
+				kvs[fmt.Sprintf("pods/default/%04d", i)] = []byte(fmt.Sprintf("value-%d", i))
+			}
+
+			batches := etcdPutKeyValuesOps("/default_tcp/", kvs)
+
+			if first := batches[0][0]; !first.IsDelete() || string(first.KeyBytes()) != "/default_tcp/" {
+				t.Fatalf("expected the first operation to remove the key-space, got %s", first.KeyBytes())
+			}
+
+			var last string
+
+			puts := 0
+
+			for _, batch := range batches {
+				if len(batch) > etcdMaxTxnOps {
+					t.Fatalf("expected at most %d operations per transaction, got %d", etcdMaxTxnOps, len(batch))
+				}
+
+				for _, op := range batch {
+					if !op.IsPut() {
+						continue
+					}
+
+					key := string(op.KeyBytes())
+					if key <= last {
+						t.Fatalf("expected the keys to be sorted, got %s after %s", key, last)
+					}
+
+					if value := kvs[key[len("/default_tcp/"):]]; string(op.ValueBytes()) != string(value) {
+						t.Fatalf("unexpected value for the key %s, got %q", key, op.ValueBytes())
+					}
+
+					last = key
+					puts++
+				}
+			}
+
+			if puts != count {
+				t.Fatalf("expected %d put operations, got %d", count, puts)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+const (
+	// kineRegistryPrefix is the kube-apiserver default storage prefix, used when backed by kine:
+	// this is not customized since each Tenant Control Plane has its own database.
+	kineRegistryPrefix = "/registry/"
+	// kineLatestRevisionsStatement returns the latest revision of each non-deleted key of the Kubernetes registry.
+	kineLatestRevisionsStatement = "SELECT kv.name, kv.value FROM %s AS kv JOIN (SELECT MAX(id) AS id FROM %s GROUP BY name) AS mkv ON mkv.id = kv.id WHERE kv.deleted = 0 AND kv.name LIKE '/registry/%%'"
+	// kineInsertStatement stores a key as a creation event, letting kine assign the revision.
+	kineInsertStatement = "INSERT INTO %s(name, created, deleted, create_revision, prev_revision, lease, value, old_value) VALUES(?, 1, 0, 0, 0, 0, ?, NULL)"
+)
+
+// kineSchemaColumns are the columns of the kine table expected by the Kine releases supported by Kamaji:
+// Kine is not tracking a schema version, the table created by an incompatible release is detected by its columns.
+var kineSchemaColumns = []string{"id", "name", "created", "deleted", "create_revision", "prev_revision", "lease", "value", "old_value"}
+
+// KineSchemaMismatchError is returned when the kine table of a Tenant Control Plane has not been created
+// by a Kine release supported by Kamaji.
+type KineSchemaMismatchError struct {
+	Schema         string
+	MissingColumns []string
+}
+
+func (k KineSchemaMismatchError) Error() string {
+	return fmt.Sprintf("the kine table of the %s schema is missing the %s columns, it has been created by an incompatible Kine release", k.Schema, strings.Join(k.MissingColumns, ", "))
+}
+
+// checkKineSchemaColumns ensures the given columns of the kine table are the expected ones.
+func checkKineSchemaColumns(schema string, columns []string) error {
+	found := make(map[string]struct{}, len(columns))
+	for _, column := range columns {
+		found[strings.ToLower(column)] = struct{}{}
+	}
+
+	var missing []string
+
+	for _, column := range kineSchemaColumns {
+		if _, ok := found[column]; !ok {
+			missing = append(missing, column)
+		}
+	}
+
+	if len(missing) > 0 {
+		return KineSchemaMismatchError{Schema: schema, MissingColumns: missing}
+	}
+
+	return nil
+}
+
+// kineDriversMinimumVersion is the capability matrix of the Kine drivers,
+// reporting the oldest Kine release supported by Kamaji for each of them.
+var kineDriversMinimumVersion = map[kamajiv1alpha1.Driver]semver.Version{
+	kamajiv1alpha1.KineMySQLDriver:      semver.MustParse("0.9.0"),
+	kamajiv1alpha1.KinePostgreSQLDriver: semver.MustParse("0.9.0"),
+}
+
+// KineImageIncompatibleError is returned when the Kine image is too old to support the DataStore driver.
+type KineImageIncompatibleError struct {
+	Image          string
+	Driver         kamajiv1alpha1.Driver
+	MinimumVersion string
+}
+
+func (k KineImageIncompatibleError) Error() string {
+	return fmt.Sprintf("the Kine image %s doesn't support the %s driver, requiring at least the version %s", k.Image, k.Driver, k.MinimumVersion)
+}
+
+// CheckKineImage ensures the given Kine image supports the DataStore driver according to the capability matrix:
+// images without a semantic version tag, such as the ones referred by digest, cannot be checked and are accepted.
+func CheckKineImage(image string, driver kamajiv1alpha1.Driver) error {
+	minimum, ok := kineDriversMinimumVersion[driver]
+	if !ok {
+		return nil
+	}
+
+	version, ok := kineImageVersion(image)
+	if !ok {
+		return nil
+	}
+
+	if version.LT(minimum) {
+		return KineImageIncompatibleError{Image: image, Driver: driver, MinimumVersion: "v" + minimum.String()}
+	}
+
+	return nil
+}
+
+// kineImageVersion returns the version of the given Kine image tag, ignoring the platform suffix, as in v0.9.2-amd64.
+func kineImageVersion(image string) (semver.Version, bool) {
+	if strings.Contains(image, "@") {
+		return semver.Version{}, false
+	}
+
+	index := strings.LastIndex(image, ":")
+	if index < 0 || strings.Contains(image[index:], "/") {
+		return semver.Version{}, false
+	}
+
+	version, err := semver.ParseTolerant(image[index+1:])
+	if err != nil {
+		return semver.Version{}, false
+	}
+
+	version.Pre, version.Build = nil, nil
+
+	return version, true
+}
+
+// kineRelativeKey translates a kine key to the key-space shared by all the drivers,
+// returning false if the key doesn't belong to the Kubernetes registry.
+func kineRelativeKey(name string) (string, bool) {
+	if !strings.HasPrefix(name, kineRegistryPrefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(name, kineRegistryPrefix), true
+}
@@ -0,0 +1,29 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"testing"
+)
+
+func TestKineRelativeKey(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		want  string
+		found bool
+	}{
+		{name: "/registry/pods/default/nginx", want: "pods/default/nginx", found: true},
+		{name: "/registry/", want: "", found: true},
+		{name: "/registry", found: false},
+		{name: "compact_rev_key", found: false},
+		{name: "/other/registry/pods", found: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, found := kineRelativeKey(tc.name)
+			if got != tc.want || found != tc.found {
+				t.Fatalf("expected (%q, %t), got (%q, %t)", tc.want, tc.found, got, found)
+			}
+		})
+	}
+}
@@ -33,6 +33,9 @@ const (
 	mysqlDropDBStatement           = "DROP DATABASE IF EXISTS `%s`"
 	mysqlDropUserStatement         = "DROP USER IF EXISTS `%s`"
 	mysqlRevokePrivilegesStatement = "REVOKE ALL PRIVILEGES ON `%s`.* FROM `%s`"
+	mysqlKineTableStatement        = "CREATE TABLE IF NOT EXISTS %s (id BIGINT UNSIGNED AUTO_INCREMENT, name VARCHAR(630) CHARACTER SET ascii, created INTEGER, deleted INTEGER, create_revision BIGINT UNSIGNED, prev_revision BIGINT UNSIGNED, lease INTEGER, value MEDIUMBLOB, old_value MEDIUMBLOB, PRIMARY KEY (id))"
+	mysqlDeleteKineStatement       = "DELETE FROM %s"
+	mysqlKineColumnsStatement      = "SELECT COLUMN_NAME FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = 'kine'"
 )
 
 type MySQLConnection struct {
@@ -291,3 +294,104 @@ func (c *MySQLConnection) mutate(ctx context.Context, nonFilledStatement string,
 func (c *MySQLConnection) checkEmptyQueryResult(err error) bool {
 	return err.Error() == sqlErrorNoRows
 }
+
+func (c *MySQLConnection) kineTable(tcp kamajiv1alpha1.TenantControlPlane) string {
+	return fmt.Sprintf("`%s`.kine", tcp.Status.Storage.Setup.Schema)
+}
+
+func (c *MySQLConnection) GetKeyValues(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane) (map[string][]byte, error) {
+	table := c.kineTable(tcp)
+
+	rows, err := c.db.QueryContext(ctx, fmt.Sprintf(kineLatestRevisionsStatement, table, table))
+	if err != nil {
+		return nil, errors.NewGetKeyValuesError(err)
+	}
+	defer rows.Close()
+
+	kvs := make(map[string][]byte)
+
+	for rows.Next() {
+		var name string
+		var value []byte
+
+		if err = rows.Scan(&name, &value); err != nil {
+			return nil, errors.NewGetKeyValuesError(err)
+		}
+
+		if key, ok := kineRelativeKey(name); ok {
+			kvs[key] = value
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.NewGetKeyValuesError(err)
+	}
+
+	return kvs, nil
+}
+
+func (c *MySQLConnection) PutKeyValues(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, kvs map[string][]byte) error {
+	if err := c.CreateDB(ctx, tcp.Status.Storage.Setup.Schema); err != nil {
+		return err
+	}
+
+	table := c.kineTable(tcp)
+	// DDL statements are causing an implicit commit, the table must be created prior the transaction.
+	if err := c.mutate(ctx, mysqlKineTableStatement, table); err != nil {
+		return errors.NewPutKeyValuesError(err)
+	}
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.NewPutKeyValuesError(err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	// Removing any leftover from previous attempts, the table must contain only the migrated objects.
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf(mysqlDeleteKineStatement, table)); err != nil {
+		return errors.NewPutKeyValuesError(err)
+	}
+
+	for key, value := range kvs {
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(kineInsertStatement, table), kineRegistryPrefix+key, value); err != nil {
+			return errors.NewPutKeyValuesError(err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.NewPutKeyValuesError(err)
+	}
+
+	return nil
+}
+
+func (c *MySQLConnection) CheckKineSchema(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane) error {
+	rows, err := c.db.QueryContext(ctx, mysqlKineColumnsStatement, tcp.Status.Storage.Setup.Schema)
+	if err != nil {
+		return errors.NewCheckKineSchemaError(err)
+	}
+	defer rows.Close()
+
+	var columns []string
+
+	for rows.Next() {
+		var column string
+
+		if err = rows.Scan(&column); err != nil {
+			return errors.NewCheckKineSchemaError(err)
+		}
+
+		columns = append(columns, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return errors.NewCheckKineSchemaError(err)
+	}
+	// The kine table is created by Kine upon its first start.
+	if len(columns) == 0 {
+		return nil
+	}
+
+	return checkKineSchemaColumns(tcp.Status.Storage.Setup.Schema, columns)
+}
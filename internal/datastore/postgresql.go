@@ -24,6 +24,7 @@ const (
 	postgresqlShowOwnershipStatement      = "SELECT 't' FROM pg_catalog.pg_database AS d WHERE d.datname = ? AND pg_catalog.pg_get_userbyid(d.datdba) = ?"
 	postgresqlShowTableOwnershipStatement = "SELECT 't' from pg_tables where tableowner = ? AND tablename = ?"
 	postgresqlKineTableExistsStatement    = "SELECT 't' FROM pg_tables WHERE schemaname = ? AND tablename  = ?"
+	postgresqlKineColumnsStatement        = "SELECT column_name FROM information_schema.columns WHERE table_schema = 'public' AND table_name = 'kine'"
 	postgresqlGrantPrivilegesStatement    = "GRANT ALL PRIVILEGES ON DATABASE %s TO %s"
 	postgresqlChangeOwnerStatement        = "ALTER DATABASE %s OWNER TO %s"
 	postgresqlRevokePrivilegesStatement   = "REVOKE ALL PRIVILEGES ON DATABASE %s FROM %s"
@@ -31,6 +32,26 @@ const (
 	postgresqlDropDBStatement             = "DROP DATABASE %s WITH (FORCE)"
 )
 
+// postgresqlKineSchemaStatements creates the kine table, along with its indexes, if not yet existing.
+var postgresqlKineSchemaStatements = []string{
+	`CREATE TABLE IF NOT EXISTS kine (
+		id SERIAL PRIMARY KEY,
+		name VARCHAR(630),
+		created INTEGER,
+		deleted INTEGER,
+		create_revision INTEGER,
+		prev_revision INTEGER,
+		lease INTEGER,
+		value bytea,
+		old_value bytea
+	)`,
+	`CREATE INDEX IF NOT EXISTS kine_name_index ON kine (name)`,
+	`CREATE INDEX IF NOT EXISTS kine_name_id_index ON kine (name,id)`,
+	`CREATE INDEX IF NOT EXISTS kine_id_deleted_index ON kine (id,deleted)`,
+	`CREATE INDEX IF NOT EXISTS kine_prev_revision_index ON kine (prev_revision)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS kine_name_prev_revision_uindex ON kine (name, prev_revision)`,
+}
+
 type PostgreSQLConnection struct {
 	db               *pg.DB
 	connection       ConnectionEndpoint
@@ -52,25 +73,7 @@ func (r *PostgreSQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.T
 	targetConn := target.(*PostgreSQLConnection).switchDatabaseFn(tcp.Status.Storage.Setup.Schema) //nolint:forcetypeassert
 
 	err := targetConn.RunInTransaction(ctx, func(tx *pg.Tx) error {
-		for _, stm := range []string{
-			`CREATE TABLE IF NOT EXISTS kine (
-				id SERIAL PRIMARY KEY,
-				name VARCHAR(630),
-				created INTEGER,
-				deleted INTEGER,
-				create_revision INTEGER,
-				prev_revision INTEGER,
-				lease INTEGER,
-				value bytea,
-				old_value bytea
-			)`,
-			`TRUNCATE TABLE kine`,
-			`CREATE INDEX IF NOT EXISTS kine_name_index ON kine (name)`,
-			`CREATE INDEX IF NOT EXISTS kine_name_id_index ON kine (name,id)`,
-			`CREATE INDEX IF NOT EXISTS kine_id_deleted_index ON kine (id,deleted)`,
-			`CREATE INDEX IF NOT EXISTS kine_prev_revision_index ON kine (prev_revision)`,
-			`CREATE UNIQUE INDEX IF NOT EXISTS kine_name_prev_revision_uindex ON kine (name, prev_revision)`,
-		} {
+		for _, stm := range append(postgresqlKineSchemaStatements, `TRUNCATE TABLE kine`) {
 			if _, err := tx.ExecContext(ctx, stm); err != nil {
 				return fmt.Errorf("unable to perform schema creation: %w", err)
 			}
@@ -276,3 +279,89 @@ func (r *PostgreSQLConnection) kineTableExists(ctx context.Context, db *pg.DB) (
 
 	return tableExists == "t", nil
 }
+
+func (r *PostgreSQLConnection) GetKeyValues(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane) (map[string][]byte, error) {
+	dbConn := r.switchDatabaseFn(tcp.Status.Storage.Setup.Schema)
+	defer dbConn.Close()
+
+	var rows []struct {
+		Name  string
+		Value []byte
+	}
+
+	if _, err := dbConn.QueryContext(ctx, &rows, fmt.Sprintf(kineLatestRevisionsStatement, "kine", "kine")); err != nil {
+		return nil, errors.NewGetKeyValuesError(err)
+	}
+
+	kvs := make(map[string][]byte, len(rows))
+
+	for _, row := range rows {
+		if key, ok := kineRelativeKey(row.Name); ok {
+			kvs[key] = row.Value
+		}
+	}
+
+	return kvs, nil
+}
+
+func (r *PostgreSQLConnection) PutKeyValues(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, kvs map[string][]byte) error {
+	if ok, _ := r.DBExists(ctx, tcp.Status.Storage.Setup.Schema); !ok {
+		if err := r.CreateDB(ctx, tcp.Status.Storage.Setup.Schema); err != nil {
+			return err
+		}
+	}
+
+	dbConn := r.switchDatabaseFn(tcp.Status.Storage.Setup.Schema)
+	defer dbConn.Close()
+
+	err := dbConn.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		// Removing any leftover from previous attempts, the table must contain only the migrated objects.
+		for _, stm := range append(postgresqlKineSchemaStatements, `TRUNCATE TABLE kine`) {
+			if _, err := tx.ExecContext(ctx, stm); err != nil {
+				return fmt.Errorf("unable to perform schema creation: %w", err)
+			}
+		}
+
+		for key, value := range kvs {
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf(kineInsertStatement, "kine"), kineRegistryPrefix+key, value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.NewPutKeyValuesError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLConnection) CheckKineSchema(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane) error {
+	exists, err := r.DBExists(ctx, tcp.Status.Storage.Setup.Schema)
+	if err != nil || !exists {
+		return err
+	}
+
+	dbConn := r.switchDatabaseFn(tcp.Status.Storage.Setup.Schema)
+	defer dbConn.Close()
+
+	var rows []struct {
+		ColumnName string
+	}
+
+	if _, err = dbConn.QueryContext(ctx, &rows, postgresqlKineColumnsStatement); err != nil {
+		return errors.NewCheckKineSchemaError(err)
+	}
+	// The kine table is created by Kine upon its first start.
+	if len(rows) == 0 {
+		return nil
+	}
+
+	columns := make([]string, 0, len(rows))
+	for _, row := range rows {
+		columns = append(columns, row.ColumnName)
+	}
+
+	return checkKineSchemaColumns(tcp.Status.Storage.Setup.Schema, columns)
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+)
+
+// TLSValidationMode defines how strictly the DataStore TLS configuration is validated upon reconciliation.
+type TLSValidationMode string
+
+const (
+	// TLSValidationModeNone only ensures the client certificates are valid key pairs, when used.
+	TLSValidationModeNone TLSValidationMode = "None"
+	// TLSValidationModeStrict verifies the client certificates chain up to the DataStore Certificate Authority,
+	// along with their client authentication usages.
+	TLSValidationModeStrict TLSValidationMode = "Strict"
+)
+
+func (m TLSValidationMode) Validate() error {
+	switch m {
+	case TLSValidationModeNone, TLSValidationModeStrict:
+		return nil
+	default:
+		return fmt.Errorf("unsupported DataStore TLS validation mode %s, must be one of None, or Strict", m)
+	}
+}
+
+// TLSValidation verifies the client certificates of a DataStore according to the given mode.
+type TLSValidation struct {
+	Mode TLSValidationMode
+	// MaxChainDepth is the maximum number of certificates of the client certificate chains, including the root:
+	// a zero value doesn't limit it.
+	MaxChainDepth int
+}
+
+// Verify verifies the current, and the next, client certificates of the given DataStore.
+func (v TLSValidation) Verify(ctx context.Context, client client.Client, ds kamajiv1alpha1.DataStore) error {
+	if v.Mode != TLSValidationModeStrict {
+		return nil
+	}
+
+	ca, err := ds.Spec.TLSConfig.CertificateAuthority.Certificate.GetContent(ctx, client)
+	if err != nil {
+		return errors.Wrap(err, "cannot retrieve the Certificate Authority")
+	}
+
+	crt, err := ds.Spec.TLSConfig.ClientCertificate.Certificate.GetContent(ctx, client)
+	if err != nil {
+		return errors.Wrap(err, "cannot retrieve the client certificate")
+	}
+
+	if err = crypto.VerifyClientCertificateChain(crt, ca, v.MaxChainDepth); err != nil {
+		return err
+	}
+
+	if next := ds.Spec.TLSConfig.ClientCertificate.Next; next != nil {
+		if crt, err = next.Certificate.GetContent(ctx, client); err != nil {
+			return errors.Wrap(err, "cannot retrieve the next client certificate")
+		}
+
+		if err = crypto.VerifyClientCertificateChain(crt, ca, v.MaxChainDepth); err != nil {
+			return errors.Wrap(err, "the next client certificate is not valid")
+		}
+	}
+
+	return nil
+}
@@ -9,15 +9,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
+	certutil "k8s.io/client-go/util/cert"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/phases/certs"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
 
 	cryptoKamaji "github.com/clastix/kamaji/internal/crypto"
 )
 
-func GenerateCACertificatePrivateKeyPair(baseName string, config *Configuration) (*CertificatePrivateKeyPair, error) {
-	defer deleteCertificateDirectory(config.InitConfiguration.CertificatesDir)
+func GenerateCACertificatePrivateKeyPair(baseName string, config *Configuration) (_ *CertificatePrivateKeyPair, err error) {
+	defer cleanupCertificateDirectory(config.InitConfiguration.CertificatesDir, &err)
 
 	kubeadmCert, err := getKubeadmCert(baseName)
 	if err != nil {
@@ -41,8 +44,8 @@ func GenerateCACertificatePrivateKeyPair(baseName string, config *Configuration)
 	return certificatePrivateKeyPair, err
 }
 
-func GenerateCertificatePrivateKeyPair(baseName string, config *Configuration, ca CertificatePrivateKeyPair) (*CertificatePrivateKeyPair, error) {
-	defer deleteCertificateDirectory(config.InitConfiguration.CertificatesDir)
+func GenerateCertificatePrivateKeyPair(baseName string, config *Configuration, ca CertificatePrivateKeyPair) (_ *CertificatePrivateKeyPair, err error) {
+	defer cleanupCertificateDirectory(config.InitConfiguration.CertificatesDir, &err)
 
 	certificate, _ := cryptoKamaji.ParseCertificateBytes(ca.Certificate)
 	signer, _ := cryptoKamaji.ParsePrivateKeyBytes(ca.PrivateKey)
@@ -86,10 +89,10 @@ func getKubeadmCert(baseName string) (*certs.KubeadmCert, error) {
 	}
 }
 
-func GeneratePublicKeyPrivateKeyPair(baseName string, config *Configuration) (*PublicKeyPrivateKeyPair, error) {
-	defer deleteCertificateDirectory(config.InitConfiguration.CertificatesDir)
+func GeneratePublicKeyPrivateKeyPair(baseName string, config *Configuration) (_ *PublicKeyPrivateKeyPair, err error) {
+	defer cleanupCertificateDirectory(config.InitConfiguration.CertificatesDir, &err)
 
-	if err := initPhaseCertsSA(config); err != nil {
+	if err = initPhaseCertsSA(config); err != nil {
 		return nil, err
 	}
 
@@ -106,16 +109,63 @@ func GeneratePublicKeyPrivateKeyPair(baseName string, config *Configuration) (*P
 	return publicKeyPrivateKeyPair, err
 }
 
+// initPhaseCertsSA generates the Service Account key pair with the configured key algorithm,
+// rather than with the kubeadm one.
 func initPhaseCertsSA(config *Configuration) error {
-	return certs.CreateServiceAccountKeyAndPublicKeyFiles(config.InitConfiguration.CertificatesDir, config.InitConfiguration.EncryptionAlgorithmType())
+	key, err := generatePrivateKey(config.KeyAlgorithm)
+	if err != nil {
+		return err
+	}
+
+	if err = pkiutil.WriteKey(config.InitConfiguration.CertificatesDir, kubeadmconstants.ServiceAccountKeyBaseName, key); err != nil {
+		return err
+	}
+
+	return pkiutil.WritePublicKey(config.InitConfiguration.CertificatesDir, kubeadmconstants.ServiceAccountKeyBaseName, key.Public())
 }
 
 func initPhaseFromCA(kubeadmCert *certs.KubeadmCert, config *Configuration, certificate *x509.Certificate, signer crypto.Signer) error {
-	return kubeadmCert.CreateFromCA(&config.InitConfiguration, certificate, signer)
+	certConfig, err := kubeadmCert.GetConfig(&config.InitConfiguration)
+	if err != nil {
+		return err
+	}
+
+	key, err := generatePrivateKey(config.KeyAlgorithm)
+	if err != nil {
+		return err
+	}
+
+	cert, err := pkiutil.NewSignedCert(certConfig, key, certificate, signer, false)
+	if err != nil {
+		return err
+	}
+
+	return pkiutil.WriteCertAndKey(config.InitConfiguration.CertificatesDir, kubeadmCert.BaseName, cert, key)
 }
 
 func initPhaseAsCA(kubeadmCert *certs.KubeadmCert, config *Configuration) (*x509.Certificate, crypto.Signer, error) {
-	return kubeadmCert.CreateAsCA(&config.InitConfiguration)
+	certConfig, err := kubeadmCert.GetConfig(&config.InitConfiguration)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := generatePrivateKey(config.KeyAlgorithm)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Backdating the CA certificate as kubeadm does, allowing small time jumps.
+	certConfig.Config.NotBefore = time.Now().Add(-kubeadmconstants.CertificateBackdate)
+
+	cert, err := certutil.NewSelfSignedCACert(certConfig.Config, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = pkiutil.WriteCertAndKey(config.InitConfiguration.CertificatesDir, kubeadmCert.BaseName, cert, key); err != nil {
+		return nil, nil, err
+	}
+
+	return cert, key, nil
 }
 
 func readCertificateFiles(name string, directory string, extensions ...string) ([][]byte, error) {
@@ -134,6 +184,16 @@ func readCertificateFiles(name string, directory string, extensions ...string) (
 	return result, nil
 }
 
+// cleanupCertificateDirectory removes the certificate directory once the generation succeeded:
+// upon failure, the removal is left to the reconciliation, which can retain it for debugging purposes.
+func cleanupCertificateDirectory(certificateDirectory string, err *error) {
+	if *err != nil {
+		return
+	}
+
+	deleteCertificateDirectory(certificateDirectory)
+}
+
 func deleteCertificateDirectory(certificateDirectory string) {
 	if err := os.RemoveAll(certificateDirectory); err != nil {
 		// TODO(prometherion): we should log rather than printing to stdout
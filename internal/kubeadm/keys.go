@@ -0,0 +1,72 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeadm
+
+import (
+	"crypto"
+	cryptorand "crypto/rand"
+	"crypto/x509"
+	"strings"
+
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+
+	cryptoKamaji "github.com/clastix/kamaji/internal/crypto"
+)
+
+// EncryptionAlgorithm returns the kubeadm encryption algorithm type matching the given key algorithm:
+// kubeadm supports a single key size per algorithm, thus the private keys are generated by Kamaji,
+// and the RSA 2048 default is omitted.
+func EncryptionAlgorithm(algorithm string) kubeadmapi.EncryptionAlgorithmType {
+	switch {
+	case len(algorithm) == 0, algorithm == cryptoKamaji.KeyAlgorithmRSA2048:
+		return ""
+	case strings.HasPrefix(algorithm, "ecdsa-"):
+		return kubeadmapi.EncryptionAlgorithmECDSA
+	default:
+		return kubeadmapi.EncryptionAlgorithmRSA
+	}
+}
+
+// generatePrivateKey returns a private key generated with the given algorithm, defaulting to RSA 2048.
+func generatePrivateKey(algorithm string) (crypto.Signer, error) {
+	if len(algorithm) == 0 {
+		algorithm = cryptoKamaji.KeyAlgorithmRSA2048
+	}
+
+	return cryptoKamaji.GeneratePrivateKey(algorithm)
+}
+
+// resignWithPrivateKey returns a copy of the given certificate bound to a private key generated with the given algorithm,
+// signed by the given Certificate Authority: it's used for the certificates generated by kubeadm with no way to provide the key.
+func resignWithPrivateKey(certificate *x509.Certificate, algorithm string, caCertificate *x509.Certificate, caKey crypto.Signer) (*x509.Certificate, crypto.Signer, error) {
+	key, err := generatePrivateKey(algorithm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          certificate.SerialNumber,
+		Subject:               certificate.Subject,
+		DNSNames:              certificate.DNSNames,
+		IPAddresses:           certificate.IPAddresses,
+		NotBefore:             certificate.NotBefore,
+		NotAfter:              certificate.NotAfter,
+		KeyUsage:              certificate.KeyUsage,
+		ExtKeyUsage:           certificate.ExtKeyUsage,
+		BasicConstraintsValid: certificate.BasicConstraintsValid,
+		IsCA:                  certificate.IsCA,
+	}
+
+	der, err := x509.CreateCertificate(cryptorand.Reader, template, caCertificate, key.Public(), caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resigned, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resigned, key, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeadm
+
+import (
+	"crypto/x509"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+
+	"github.com/clastix/kamaji/internal/crypto"
+)
+
+func TestEncryptionAlgorithm(t *testing.T) {
+	for algorithm, want := range map[string]kubeadmapi.EncryptionAlgorithmType{
+		"":                           "",
+		crypto.KeyAlgorithmRSA2048:   "",
+		crypto.KeyAlgorithmRSA4096:   kubeadmapi.EncryptionAlgorithmRSA,
+		crypto.KeyAlgorithmECDSAP256: kubeadmapi.EncryptionAlgorithmECDSA,
+		crypto.KeyAlgorithmECDSAP384: kubeadmapi.EncryptionAlgorithmECDSA,
+	} {
+		if got := EncryptionAlgorithm(algorithm); got != want {
+			t.Fatalf("expected the kubeadm algorithm %q for %q, got %q", want, algorithm, got)
+		}
+	}
+}
+
+func TestKeyAlgorithm(t *testing.T) {
+	// The key algorithm is provided explicitly, leaving the process-wide kubeadm generation untouched.
+	if reflect.ValueOf(pkiutil.NewPrivateKey).Pointer() != reflect.ValueOf(pkiutil.GeneratePrivateKey).Pointer() {
+		t.Fatal("expected the kubeadm private key generation to be left untouched")
+	}
+
+	config, err := CreateKubeadmInitConfiguration(Parameters{
+		TenantControlPlaneName:        "tcp",
+		TenantControlPlaneNamespace:   "default",
+		TenantControlPlaneAddress:     "10.0.0.1",
+		TenantControlPlanePort:        6443,
+		TenantControlPlaneEndpoint:    "10.0.0.1:6443",
+		TenantControlPlanePodCIDR:     "10.244.0.0/16",
+		TenantControlPlaneServiceCIDR: "10.96.0.0/16",
+		TenantControlPlaneVersion:     "v1.29.1",
+		ETCDs:                         []string{"https://etcd:2379"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.KeyAlgorithm = crypto.KeyAlgorithmECDSAP384
+	config.InitConfiguration.EncryptionAlgorithm = EncryptionAlgorithm(config.KeyAlgorithm)
+
+	expectAlgorithm := func(t *testing.T, name string, key []byte) {
+		t.Helper()
+
+		algorithm, err := crypto.PrivateKeyAlgorithm(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if algorithm != config.KeyAlgorithm {
+			t.Fatalf("expected the %s private key algorithm %s, got %s", name, config.KeyAlgorithm, algorithm)
+		}
+	}
+
+	config.InitConfiguration.CertificatesDir = filepath.Join(t.TempDir(), "ca")
+
+	ca, err := GenerateCACertificatePrivateKeyPair(kubeadmconstants.CACertAndKeyBaseName, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectAlgorithm(t, "CA", ca.PrivateKey)
+
+	config.InitConfiguration.CertificatesDir = filepath.Join(t.TempDir(), "apiserver")
+
+	apiServer, err := GenerateCertificatePrivateKeyPair(kubeadmconstants.APIServerCertAndKeyBaseName, config, *ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectAlgorithm(t, "API Server", apiServer.PrivateKey)
+
+	if err = crypto.VerifyCertificateHostnames(apiServer.Certificate, apiServer.PrivateKey, "tcp.default.svc", "10.0.0.1"); err != nil {
+		t.Fatal(err)
+	}
+
+	config.InitConfiguration.CertificatesDir = filepath.Join(t.TempDir(), "sa")
+
+	sa, err := GeneratePublicKeyPrivateKeyPair(kubeadmconstants.ServiceAccountKeyBaseName, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectAlgorithm(t, "Service Account", sa.PrivateKey)
+
+	config.InitConfiguration.CertificatesDir = filepath.Join(t.TempDir(), "kubeconfig")
+
+	content, err := CreateKubeconfig(kubeadmconstants.AdminKubeConfigFileName, *ca, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kubeconfig, err := clientcmd.Load(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, authInfo := range kubeconfig.AuthInfos {
+		expectAlgorithm(t, name, authInfo.ClientKeyData)
+
+		if ok, err := crypto.VerifyCertificate(authInfo.ClientCertificateData, ca.Certificate, x509.ExtKeyUsageClientAuth); err != nil || !ok {
+			t.Fatalf("expected the %s client certificate to be signed by the CA, got %v", name, err)
+		}
+	}
+}
@@ -4,12 +4,17 @@
 package kubeadm
 
 import (
+	stdcrypto "crypto"
+	"crypto/x509"
 	"os"
 	"path"
 	"path/filepath"
 
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/keyutil"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/phases/kubeconfig"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
 
 	"github.com/clastix/kamaji/internal/crypto"
 	"github.com/clastix/kamaji/internal/utilities"
@@ -30,20 +35,109 @@ func buildCertificateDirectoryWithCA(ca CertificatePrivateKeyPair, directory str
 	return os.WriteFile(keyPath, ca.PrivateKey, os.FileMode(0o600))
 }
 
-func CreateKubeconfig(kubeconfigName string, ca CertificatePrivateKeyPair, config *Configuration) ([]byte, error) {
-	if err := buildCertificateDirectoryWithCA(ca, config.InitConfiguration.CertificatesDir); err != nil {
+func CreateKubeconfig(kubeconfigName string, ca CertificatePrivateKeyPair, config *Configuration) (_ []byte, err error) {
+	defer cleanupCertificateDirectory(config.InitConfiguration.CertificatesDir, &err)
+
+	if err = buildCertificateDirectoryWithCA(ca, config.InitConfiguration.CertificatesDir); err != nil {
 		return nil, err
 	}
 
-	defer deleteCertificateDirectory(config.InitConfiguration.CertificatesDir)
-
-	if err := kubeconfig.CreateKubeConfigFile(kubeconfigName, config.InitConfiguration.CertificatesDir, &config.InitConfiguration); err != nil {
+	if err = kubeconfig.CreateKubeConfigFile(kubeconfigName, config.InitConfiguration.CertificatesDir, &config.InitConfiguration); err != nil {
 		return nil, err
 	}
 
 	path := filepath.Join(config.InitConfiguration.CertificatesDir, kubeconfigName)
 
-	return os.ReadFile(path)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return setKubeconfigKeyAlgorithm(content, ca, config.KeyAlgorithm)
+}
+
+// setKubeconfigKeyAlgorithm replaces the client certificates of the given kubeconfig generated by kubeadm
+// with a private key not matching the given algorithm, since kubeadm supports a single key size per algorithm.
+func setKubeconfigKeyAlgorithm(kubeconfig []byte, ca CertificatePrivateKeyPair, algorithm string) ([]byte, error) {
+	if len(algorithm) == 0 {
+		algorithm = crypto.KeyAlgorithmRSA2048
+	}
+
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var caCertificate *x509.Certificate
+
+	var caKey stdcrypto.Signer
+
+	for _, authInfo := range config.AuthInfos {
+		if len(authInfo.ClientKeyData) == 0 {
+			continue
+		}
+
+		if current, _ := crypto.PrivateKeyAlgorithm(authInfo.ClientKeyData); current == algorithm {
+			continue
+		}
+
+		if caCertificate == nil {
+			if caCertificate, err = crypto.ParseCertificateBytes(ca.Certificate); err != nil {
+				return nil, err
+			}
+
+			if caKey, err = crypto.ParsePrivateKeyBytes(ca.PrivateKey); err != nil {
+				return nil, err
+			}
+		}
+
+		certificate, err := crypto.ParseCertificateBytes(authInfo.ClientCertificateData)
+		if err != nil {
+			return nil, err
+		}
+
+		certificate, key, err := resignWithPrivateKey(certificate, algorithm, caCertificate, caKey)
+		if err != nil {
+			return nil, err
+		}
+
+		keyData, err := keyutil.MarshalPrivateKeyToPEM(key)
+		if err != nil {
+			return nil, err
+		}
+
+		authInfo.ClientCertificateData, authInfo.ClientKeyData = pkiutil.EncodeCertPEM(certificate), keyData
+	}
+
+	return clientcmd.Write(*config)
+}
+
+// SetKubeconfigServer replaces the API Server address of the clusters of the given kubeconfig.
+func SetKubeconfigServer(kubeconfig []byte, server string) ([]byte, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cluster := range config.Clusters {
+		cluster.Server = server
+	}
+
+	return clientcmd.Write(*config)
+}
+
+// SetKubeconfigCertificateAuthority replaces the certificate authority data of the clusters of the given kubeconfig.
+func SetKubeconfigCertificateAuthority(kubeconfig []byte, ca []byte) ([]byte, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cluster := range config.Clusters {
+		cluster.CertificateAuthorityData = ca
+	}
+
+	return clientcmd.Write(*config)
 }
 
 func IsKubeconfigValid(bytes []byte) bool {
@@ -15,6 +15,10 @@ type Configuration struct {
 	InitConfiguration kubeadmapi.InitConfiguration
 	Kubeconfig        clientcmdapiv1.Config
 	Parameters        Parameters
+	// KeyAlgorithm is the algorithm of the generated private keys, such as rsa-4096: since kubeadm supports a single key size
+	// per algorithm, the keys are generated by Kamaji. It's not stored along with the kubeadm configuration,
+	// an empty value defaults to RSA 2048.
+	KeyAlgorithm string
 }
 
 func (c *Configuration) Checksum() string {
@@ -0,0 +1,187 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const APFBootstrapResourceName = "apf-bootstrap"
+
+// APFBootstrapAllowedKinds are the API Priority and Fairness objects kinds which can be seeded in the Tenant Cluster,
+// regardless of the flowcontrol.apiserver.k8s.io API version served by the Tenant Control Plane.
+var APFBootstrapAllowedKinds = []schema.GroupKind{
+	{Group: "flowcontrol.apiserver.k8s.io", Kind: "FlowSchema"},
+	{Group: "flowcontrol.apiserver.k8s.io", Kind: "PriorityLevelConfiguration"},
+}
+
+// ValidateAPFBootstrapManifests decodes the given API Priority and Fairness objects manifests, ensuring the kinds are allowed.
+func ValidateAPFBootstrapManifests(manifests string) error {
+	_, err := decodeAPFBootstrapManifests(manifests)
+
+	return err
+}
+
+func decodeAPFBootstrapManifests(manifests string) ([]*unstructured.Unstructured, error) {
+	objects, err := decodeManifests(manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range objects {
+		if !isAllowedKind(APFBootstrapAllowedKinds, obj.GroupVersionKind().GroupKind()) {
+			return nil, fmt.Errorf("the kind %s of the object %s is not allowed, must be one of FlowSchema, or PriorityLevelConfiguration", obj.GroupVersionKind().GroupKind().String(), obj.GetName())
+		}
+	}
+
+	return objects, nil
+}
+
+// APFBootstrap seeds the Tenant Cluster with the provided API Priority and Fairness objects.
+type APFBootstrap struct {
+	Client client.Client
+
+	checksum string
+	objects  []kamajiv1alpha1.AddonObjectReference
+}
+
+func (a *APFBootstrap) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+func (a *APFBootstrap) spec(tcp *kamajiv1alpha1.TenantControlPlane) *kamajiv1alpha1.APFBootstrapSpec {
+	if apiServer := tcp.Spec.ControlPlane.APIServer; apiServer != nil && apiServer.APF != nil {
+		return apiServer.APF.Bootstrap
+	}
+
+	return nil
+}
+
+func (a *APFBootstrap) ShouldCleanup(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return a.spec(tcp) == nil
+}
+
+func (a *APFBootstrap) CleanUp(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", a.GetName())
+
+	if tcp.Status.APFBootstrap == nil {
+		return false, nil
+	}
+
+	tenantClient, err := utilities.GetTenantClient(ctx, a.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return false, err
+	}
+
+	if err = pruneObjects(ctx, tenantClient, tcp.Status.APFBootstrap.Objects, nil); err != nil {
+		logger.Error(err, "cannot delete API Priority and Fairness objects")
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (a *APFBootstrap) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "resource", a.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, a.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	spec := a.spec(tcp)
+
+	manifests, err := getManifests(ctx, a.Client, tcp, spec.Manifests, spec.ConfigMapRef)
+	if err != nil {
+		logger.Error(err, "cannot retrieve API Priority and Fairness manifests")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	objects, err := decodeAPFBootstrapManifests(manifests)
+	if err != nil {
+		logger.Error(err, "manifest decoding failed")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	var applied []kamajiv1alpha1.AddonObjectReference
+	if status := tcp.Status.APFBootstrap; status != nil {
+		applied = status.Objects
+	}
+
+	a.checksum = utilities.CalculateMapChecksum(map[string]string{"manifests": manifests})
+	a.objects = make([]kamajiv1alpha1.AddonObjectReference, 0, len(objects))
+	// Objects are applied on each reconciliation, reverting any drift from the desired manifests.
+	for _, obj := range objects {
+		if err = applyObject(ctx, tenantClient, tcp, a.GetName(), obj); err != nil {
+			logger.Error(err, "cannot apply API Priority and Fairness object", "kind", obj.GetKind(), "name", obj.GetName())
+
+			return controllerutil.OperationResultNone, err
+		}
+
+		a.objects = append(a.objects, objectReference(obj))
+	}
+
+	if err = pruneObjects(ctx, tenantClient, applied, a.objects); err != nil {
+		logger.Error(err, "cannot prune API Priority and Fairness objects")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	if status := tcp.Status.APFBootstrap; status == nil || status.Checksum != a.checksum {
+		return controllerutil.OperationResultUpdated, nil
+	}
+
+	return controllerutil.OperationResultNone, nil
+}
+
+func (a *APFBootstrap) GetName() string {
+	return APFBootstrapResourceName
+}
+
+func (a *APFBootstrap) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	status := tcp.Status.APFBootstrap
+
+	switch {
+	case a.spec(tcp) == nil:
+		return status != nil
+	case status == nil:
+		return true
+	default:
+		return status.Checksum != a.checksum || !equalObjectReferences(status.Objects, a.objects)
+	}
+}
+
+func (a *APFBootstrap) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if a.spec(tcp) == nil {
+		tcp.Status.APFBootstrap = nil
+
+		return nil
+	}
+
+	tcp.Status.APFBootstrap = &kamajiv1alpha1.APFBootstrapStatus{
+		Checksum:   a.checksum,
+		Objects:    a.objects,
+		LastUpdate: metav1.Now(),
+	}
+
+	return nil
+}
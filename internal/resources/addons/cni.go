@@ -0,0 +1,160 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const CNIResourceName = "cni"
+
+type CNI struct {
+	Client client.Client
+
+	checksum string
+	objects  []kamajiv1alpha1.AddonObjectReference
+	ready    bool
+}
+
+func (c *CNI) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+func (c *CNI) ShouldCleanup(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return tcp.Spec.Addons.CNI == nil
+}
+
+func (c *CNI) CleanUp(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "addon", c.GetName())
+
+	if len(tcp.Status.Addons.CNI.Objects) == 0 && !tcp.Status.Addons.CNI.Enabled {
+		return false, nil
+	}
+
+	tenantClient, err := utilities.GetTenantClient(ctx, c.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return false, err
+	}
+
+	if err = pruneObjects(ctx, tenantClient, tcp.Status.Addons.CNI.Objects, nil); err != nil {
+		logger.Error(err, "cannot delete CNI objects")
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *CNI) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "addon", c.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, c.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	manifests, err := getManifests(ctx, c.Client, tcp, tcp.Spec.Addons.CNI.Manifests, tcp.Spec.Addons.CNI.ConfigMapRef)
+	if err != nil {
+		logger.Error(err, "cannot retrieve CNI manifests")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	objects, err := decodeManifests(manifests)
+	if err != nil {
+		logger.Error(err, "manifest decoding failed")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	c.checksum = utilities.CalculateMapChecksum(map[string]string{"manifests": manifests})
+	c.objects = make([]kamajiv1alpha1.AddonObjectReference, 0, len(objects))
+	// Objects are applied on each reconciliation, reverting any drift from the desired manifests.
+	for _, obj := range objects {
+		if err = applyObject(ctx, tenantClient, tcp, c.GetName(), obj); err != nil {
+			logger.Error(err, "cannot apply CNI object", "kind", obj.GetKind(), "namespace", obj.GetNamespace(), "name", obj.GetName())
+
+			return controllerutil.OperationResultNone, err
+		}
+
+		c.objects = append(c.objects, objectReference(obj))
+	}
+
+	if err = pruneObjects(ctx, tenantClient, tcp.Status.Addons.CNI.Objects, c.objects); err != nil {
+		logger.Error(err, "cannot prune CNI objects")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	if c.ready, err = c.isReady(ctx, tenantClient); err != nil {
+		logger.Error(err, "cannot check CNI readiness")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	if c.checksum != tcp.Status.Addons.CNI.Checksum {
+		return controllerutil.OperationResultUpdated, nil
+	}
+
+	return controllerutil.OperationResultNone, nil
+}
+
+func (c *CNI) GetName() string {
+	return CNIResourceName
+}
+
+func (c *CNI) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	status := tcp.Status.Addons.CNI
+
+	return status.Enabled != (tcp.Spec.Addons.CNI != nil) ||
+		status.Checksum != c.checksum ||
+		status.Ready != c.ready ||
+		!equalObjectReferences(status.Objects, c.objects)
+}
+
+func (c *CNI) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	tcp.Status.Addons.CNI.Enabled = tcp.Spec.Addons.CNI != nil
+	tcp.Status.Addons.CNI.Checksum = c.checksum
+	tcp.Status.Addons.CNI.Objects = c.objects
+	tcp.Status.Addons.CNI.Ready = c.ready
+	tcp.Status.Addons.CNI.LastUpdate = metav1.Now()
+
+	return nil
+}
+
+// isReady returns true when all the applied DaemonSets have the desired pods scheduled, updated, and available.
+func (c *CNI) isReady(ctx context.Context, tenantClient client.Client) (bool, error) {
+	for _, ref := range c.objects {
+		if ref.Kind != "DaemonSet" || ref.APIVersion != appsv1.SchemeGroupVersion.String() {
+			continue
+		}
+
+		ds := &appsv1.DaemonSet{}
+		if err := tenantClient.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, ds); err != nil {
+			return false, err
+		}
+
+		if ds.Status.ObservedGeneration < ds.GetGeneration() ||
+			ds.Status.UpdatedNumberScheduled != ds.Status.DesiredNumberScheduled ||
+			ds.Status.NumberAvailable != ds.Status.DesiredNumberScheduled {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
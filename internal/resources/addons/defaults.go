@@ -0,0 +1,162 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const DefaultsResourceName = "defaults"
+
+// DefaultsAllowedKinds are the policy objects kinds which can be seeded in the Tenant Cluster.
+var DefaultsAllowedKinds = []schema.GroupKind{
+	schedulingv1.SchemeGroupVersion.WithKind("PriorityClass").GroupKind(),
+	corev1.SchemeGroupVersion.WithKind("LimitRange").GroupKind(),
+	corev1.SchemeGroupVersion.WithKind("ResourceQuota").GroupKind(),
+}
+
+// ValidateDefaultsManifests decodes the given policy objects manifests, ensuring the kinds are allowed.
+func ValidateDefaultsManifests(manifests string) error {
+	_, err := decodeDefaultsManifests(manifests)
+
+	return err
+}
+
+func decodeDefaultsManifests(manifests string) ([]*unstructured.Unstructured, error) {
+	objects, err := decodeManifests(manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range objects {
+		if !isAllowedKind(DefaultsAllowedKinds, obj.GroupVersionKind().GroupKind()) {
+			return nil, fmt.Errorf("the kind %s of the object %s is not allowed, must be one of PriorityClass, LimitRange, or ResourceQuota", obj.GroupVersionKind().GroupKind().String(), obj.GetName())
+		}
+	}
+
+	return objects, nil
+}
+
+// Defaults seeds the Tenant Cluster with the provided policy objects.
+type Defaults struct {
+	Client client.Client
+
+	checksum string
+	objects  []kamajiv1alpha1.AddonObjectReference
+}
+
+func (d *Defaults) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+func (d *Defaults) ShouldCleanup(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return tcp.Spec.Addons.Defaults == nil
+}
+
+func (d *Defaults) CleanUp(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "addon", d.GetName())
+
+	if len(tcp.Status.Addons.Defaults.Objects) == 0 && !tcp.Status.Addons.Defaults.Enabled {
+		return false, nil
+	}
+
+	tenantClient, err := utilities.GetTenantClient(ctx, d.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return false, err
+	}
+
+	if err = pruneObjects(ctx, tenantClient, tcp.Status.Addons.Defaults.Objects, nil); err != nil {
+		logger.Error(err, "cannot delete policy objects")
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (d *Defaults) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "addon", d.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, d.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	manifests, err := getManifests(ctx, d.Client, tcp, tcp.Spec.Addons.Defaults.Manifests, tcp.Spec.Addons.Defaults.ConfigMapRef)
+	if err != nil {
+		logger.Error(err, "cannot retrieve policy objects manifests")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	objects, err := decodeDefaultsManifests(manifests)
+	if err != nil {
+		logger.Error(err, "manifest decoding failed")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	d.checksum = utilities.CalculateMapChecksum(map[string]string{"manifests": manifests})
+	d.objects = make([]kamajiv1alpha1.AddonObjectReference, 0, len(objects))
+	// Objects are applied on each reconciliation, reverting any drift from the desired manifests.
+	for _, obj := range objects {
+		if err = applyObject(ctx, tenantClient, tcp, d.GetName(), obj); err != nil {
+			logger.Error(err, "cannot apply policy object", "kind", obj.GetKind(), "namespace", obj.GetNamespace(), "name", obj.GetName())
+
+			return controllerutil.OperationResultNone, err
+		}
+
+		d.objects = append(d.objects, objectReference(obj))
+	}
+
+	if err = pruneObjects(ctx, tenantClient, tcp.Status.Addons.Defaults.Objects, d.objects); err != nil {
+		logger.Error(err, "cannot prune policy objects")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	if d.checksum != tcp.Status.Addons.Defaults.Checksum {
+		return controllerutil.OperationResultUpdated, nil
+	}
+
+	return controllerutil.OperationResultNone, nil
+}
+
+func (d *Defaults) GetName() string {
+	return DefaultsResourceName
+}
+
+func (d *Defaults) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	status := tcp.Status.Addons.Defaults
+
+	return status.Enabled != (tcp.Spec.Addons.Defaults != nil) ||
+		status.Checksum != d.checksum ||
+		!equalObjectReferences(status.Objects, d.objects)
+}
+
+func (d *Defaults) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	tcp.Status.Addons.Defaults.Enabled = tcp.Spec.Addons.Defaults != nil
+	tcp.Status.Addons.Defaults.Checksum = d.checksum
+	tcp.Status.Addons.Defaults.Objects = d.objects
+	tcp.Status.Addons.Defaults.LastUpdate = metav1.Now()
+
+	return nil
+}
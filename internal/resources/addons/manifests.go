@@ -0,0 +1,167 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// manifestsFieldOwner is the field manager used to apply the user-provided manifests.
+const manifestsFieldOwner = "kamaji"
+
+// getManifests returns the given inline manifests, or the ones retrieved from the referenced ConfigMap:
+// in the latter case, the keys are sorted to provide a stable ordering.
+func getManifests(ctx context.Context, c client.Client, tcp *kamajiv1alpha1.TenantControlPlane, manifests string, configMapRef *corev1.LocalObjectReference) (string, error) {
+	if configMapRef == nil {
+		return manifests, nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: tcp.GetNamespace(), Name: configMapRef.Name}, cm); err != nil {
+		return "", errors.Wrap(err, "cannot retrieve the manifests ConfigMap")
+	}
+
+	keys := make([]string, 0, len(cm.Data))
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	documents := make([]string, 0, len(keys))
+	for _, key := range keys {
+		documents = append(documents, cm.Data[key])
+	}
+
+	return strings.Join(documents, "\n---\n"), nil
+}
+
+// decodeManifests decodes the given multi-document YAML manifests.
+func decodeManifests(manifests string) ([]*unstructured.Unstructured, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewBufferString(manifests), 4096)
+
+	var objects []*unstructured.Unstructured
+
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, errors.Wrap(err, "unable to decode manifests")
+		}
+		// Skipping empty documents, such as the ones with comments only.
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		if len(obj.GetKind()) == 0 || len(obj.GetName()) == 0 {
+			return nil, fmt.Errorf("manifests contain an object with no kind or name")
+		}
+
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+func isAllowedKind(allowed []schema.GroupKind, gk schema.GroupKind) bool {
+	for _, kind := range allowed {
+		if kind == gk {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyObject applies the given object to the Tenant Cluster using Server-Side Apply,
+// labelling it with the Kamaji labels of the given resource.
+func applyObject(ctx context.Context, tenantClient client.Client, tcp *kamajiv1alpha1.TenantControlPlane, resourceName string, obj *unstructured.Unstructured) error {
+	namespaced, err := tenantClient.IsObjectNamespaced(obj)
+	if err != nil {
+		return err
+	}
+
+	if namespaced && len(obj.GetNamespace()) == 0 {
+		obj.SetNamespace(metav1.NamespaceDefault)
+	}
+
+	obj.SetLabels(utilities.MergeMaps(obj.GetLabels(), utilities.KamajiLabels(tcp.GetName(), resourceName)))
+
+	return tenantClient.Patch(ctx, obj, client.Apply, client.FieldOwner(manifestsFieldOwner), client.ForceOwnership)
+}
+
+// pruneObjects deletes the previously applied objects which are no more desired.
+func pruneObjects(ctx context.Context, tenantClient client.Client, applied, desired []kamajiv1alpha1.AddonObjectReference) error {
+	for _, ref := range applied {
+		if containsObjectReference(desired, ref) {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(ref.APIVersion)
+		obj.SetKind(ref.Kind)
+		obj.SetNamespace(ref.Namespace)
+		obj.SetName(ref.Name)
+
+		if err := tenantClient.Delete(ctx, obj); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func objectReference(obj *unstructured.Unstructured) kamajiv1alpha1.AddonObjectReference {
+	return kamajiv1alpha1.AddonObjectReference{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+}
+
+func containsObjectReference(refs []kamajiv1alpha1.AddonObjectReference, ref kamajiv1alpha1.AddonObjectReference) bool {
+	for _, r := range refs {
+		if r == ref {
+			return true
+		}
+	}
+
+	return false
+}
+
+func equalObjectReferences(a, b []kamajiv1alpha1.AddonObjectReference) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,159 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const NetworkPoliciesResourceName = "network-policies"
+
+// NetworkPoliciesAllowedKinds are the objects kinds which can be seeded in the Tenant Cluster by the NetworkPolicies addon.
+var NetworkPoliciesAllowedKinds = []schema.GroupKind{
+	networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy").GroupKind(),
+}
+
+// ValidateNetworkPoliciesManifests decodes the given NetworkPolicies manifests, ensuring the kinds are allowed.
+func ValidateNetworkPoliciesManifests(manifests string) error {
+	_, err := decodeNetworkPoliciesManifests(manifests)
+
+	return err
+}
+
+func decodeNetworkPoliciesManifests(manifests string) ([]*unstructured.Unstructured, error) {
+	objects, err := decodeManifests(manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range objects {
+		if !isAllowedKind(NetworkPoliciesAllowedKinds, obj.GroupVersionKind().GroupKind()) {
+			return nil, fmt.Errorf("the kind %s of the object %s is not allowed, must be NetworkPolicy", obj.GroupVersionKind().GroupKind().String(), obj.GetName())
+		}
+	}
+
+	return objects, nil
+}
+
+// NetworkPolicies seeds the Tenant Cluster with the provided NetworkPolicies.
+type NetworkPolicies struct {
+	Client client.Client
+
+	checksum string
+	objects  []kamajiv1alpha1.AddonObjectReference
+}
+
+func (n *NetworkPolicies) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+func (n *NetworkPolicies) ShouldCleanup(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return tcp.Spec.Addons.NetworkPolicies == nil
+}
+
+func (n *NetworkPolicies) CleanUp(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "addon", n.GetName())
+
+	if len(tcp.Status.Addons.NetworkPolicies.Objects) == 0 && !tcp.Status.Addons.NetworkPolicies.Enabled {
+		return false, nil
+	}
+
+	tenantClient, err := utilities.GetTenantClient(ctx, n.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return false, err
+	}
+
+	if err = pruneObjects(ctx, tenantClient, tcp.Status.Addons.NetworkPolicies.Objects, nil); err != nil {
+		logger.Error(err, "cannot delete NetworkPolicies")
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (n *NetworkPolicies) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "addon", n.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, n.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	manifests, err := getManifests(ctx, n.Client, tcp, tcp.Spec.Addons.NetworkPolicies.Manifests, tcp.Spec.Addons.NetworkPolicies.ConfigMapRef)
+	if err != nil {
+		logger.Error(err, "cannot retrieve NetworkPolicies manifests")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	objects, err := decodeNetworkPoliciesManifests(manifests)
+	if err != nil {
+		logger.Error(err, "manifest decoding failed")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	n.checksum = utilities.CalculateMapChecksum(map[string]string{"manifests": manifests})
+	n.objects = make([]kamajiv1alpha1.AddonObjectReference, 0, len(objects))
+	// Objects are applied on each reconciliation, reverting any drift from the desired manifests.
+	for _, obj := range objects {
+		if err = applyObject(ctx, tenantClient, tcp, n.GetName(), obj); err != nil {
+			logger.Error(err, "cannot apply NetworkPolicy", "namespace", obj.GetNamespace(), "name", obj.GetName())
+
+			return controllerutil.OperationResultNone, err
+		}
+
+		n.objects = append(n.objects, objectReference(obj))
+	}
+
+	if err = pruneObjects(ctx, tenantClient, tcp.Status.Addons.NetworkPolicies.Objects, n.objects); err != nil {
+		logger.Error(err, "cannot prune NetworkPolicies")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	if n.checksum != tcp.Status.Addons.NetworkPolicies.Checksum {
+		return controllerutil.OperationResultUpdated, nil
+	}
+
+	return controllerutil.OperationResultNone, nil
+}
+
+func (n *NetworkPolicies) GetName() string {
+	return NetworkPoliciesResourceName
+}
+
+func (n *NetworkPolicies) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	status := tcp.Status.Addons.NetworkPolicies
+
+	return status.Enabled != (tcp.Spec.Addons.NetworkPolicies != nil) ||
+		status.Checksum != n.checksum ||
+		!equalObjectReferences(status.Objects, n.objects)
+}
+
+func (n *NetworkPolicies) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	tcp.Status.Addons.NetworkPolicies.Enabled = tcp.Spec.Addons.NetworkPolicies != nil
+	tcp.Status.Addons.NetworkPolicies.Checksum = n.checksum
+	tcp.Status.Addons.NetworkPolicies.Objects = n.objects
+	tcp.Status.Addons.NetworkPolicies.LastUpdate = metav1.Now()
+
+	return nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/pkg/errors"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const WebhooksResourceName = "webhooks"
+
+// WebhooksAllowedKinds are the webhook configurations kinds which can be seeded in the Tenant Cluster.
+var WebhooksAllowedKinds = []schema.GroupKind{
+	admissionregistrationv1.SchemeGroupVersion.WithKind("ValidatingWebhookConfiguration").GroupKind(),
+	admissionregistrationv1.SchemeGroupVersion.WithKind("MutatingWebhookConfiguration").GroupKind(),
+}
+
+// ValidateWebhooksManifests decodes the given webhook configurations manifests, ensuring the kinds are allowed.
+func ValidateWebhooksManifests(manifests string) error {
+	_, err := decodeWebhooksManifests(manifests)
+
+	return err
+}
+
+func decodeWebhooksManifests(manifests string) ([]*unstructured.Unstructured, error) {
+	objects, err := decodeManifests(manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range objects {
+		if !isAllowedKind(WebhooksAllowedKinds, obj.GroupVersionKind().GroupKind()) {
+			return nil, fmt.Errorf("the kind %s of the object %s is not allowed, must be one of ValidatingWebhookConfiguration, or MutatingWebhookConfiguration", obj.GroupVersionKind().GroupKind().String(), obj.GetName())
+		}
+	}
+
+	return objects, nil
+}
+
+// Webhooks seeds the Tenant Cluster with the provided webhook configurations,
+// injecting the Tenant Control Plane CA in the webhooks with no CA bundle.
+type Webhooks struct {
+	Client client.Client
+
+	checksum string
+	objects  []kamajiv1alpha1.AddonObjectReference
+}
+
+func (w *Webhooks) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+func (w *Webhooks) ShouldCleanup(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return tcp.Spec.Addons.Webhooks == nil
+}
+
+func (w *Webhooks) CleanUp(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "addon", w.GetName())
+
+	if len(tcp.Status.Addons.Webhooks.Objects) == 0 && !tcp.Status.Addons.Webhooks.Enabled {
+		return false, nil
+	}
+
+	tenantClient, err := utilities.GetTenantClient(ctx, w.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return false, err
+	}
+
+	if err = pruneObjects(ctx, tenantClient, tcp.Status.Addons.Webhooks.Objects, nil); err != nil {
+		logger.Error(err, "cannot delete webhook configurations")
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (w *Webhooks) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "addon", w.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, w.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	manifests, err := getManifests(ctx, w.Client, tcp, tcp.Spec.Addons.Webhooks.Manifests, tcp.Spec.Addons.Webhooks.ConfigMapRef)
+	if err != nil {
+		logger.Error(err, "cannot retrieve webhook configurations manifests")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	objects, err := decodeWebhooksManifests(manifests)
+	if err != nil {
+		logger.Error(err, "manifest decoding failed")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	caBundle, err := w.caBundle(ctx, tcp)
+	if err != nil {
+		logger.Error(err, "cannot retrieve the Tenant Control Plane CA")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	w.checksum = utilities.CalculateMapChecksum(map[string]string{"manifests": manifests, "ca": string(caBundle)})
+	w.objects = make([]kamajiv1alpha1.AddonObjectReference, 0, len(objects))
+	// Objects are applied on each reconciliation, reverting any drift from the desired manifests.
+	for _, obj := range objects {
+		if err = injectCABundle(obj, caBundle); err != nil {
+			logger.Error(err, "cannot inject the CA bundle", "kind", obj.GetKind(), "name", obj.GetName())
+
+			return controllerutil.OperationResultNone, err
+		}
+
+		if err = applyObject(ctx, tenantClient, tcp, w.GetName(), obj); err != nil {
+			logger.Error(err, "cannot apply webhook configuration", "kind", obj.GetKind(), "name", obj.GetName())
+
+			return controllerutil.OperationResultNone, err
+		}
+
+		w.objects = append(w.objects, objectReference(obj))
+	}
+
+	if err = pruneObjects(ctx, tenantClient, tcp.Status.Addons.Webhooks.Objects, w.objects); err != nil {
+		logger.Error(err, "cannot prune webhook configurations")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	if w.checksum != tcp.Status.Addons.Webhooks.Checksum {
+		return controllerutil.OperationResultUpdated, nil
+	}
+
+	return controllerutil.OperationResultNone, nil
+}
+
+func (w *Webhooks) GetName() string {
+	return WebhooksResourceName
+}
+
+func (w *Webhooks) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	status := tcp.Status.Addons.Webhooks
+
+	return status.Enabled != (tcp.Spec.Addons.Webhooks != nil) ||
+		status.Checksum != w.checksum ||
+		!equalObjectReferences(status.Objects, w.objects)
+}
+
+func (w *Webhooks) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	tcp.Status.Addons.Webhooks.Enabled = tcp.Spec.Addons.Webhooks != nil
+	tcp.Status.Addons.Webhooks.Checksum = w.checksum
+	tcp.Status.Addons.Webhooks.Objects = w.objects
+	tcp.Status.Addons.Webhooks.LastUpdate = metav1.Now()
+
+	return nil
+}
+
+// caBundle returns the Tenant Control Plane CA certificate.
+func (w *Webhooks) caBundle(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := w.Client.Get(ctx, types.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.Status.Certificates.CA.SecretName}, secret); err != nil {
+		return nil, err
+	}
+
+	ca, ok := secret.Data[kubeadmconstants.CACertName]
+	if !ok {
+		return nil, fmt.Errorf("the CA Secret is missing the %s key", kubeadmconstants.CACertName)
+	}
+
+	return ca, nil
+}
+
+// injectCABundle sets the given CA bundle to the webhooks with no CA bundle provided by the manifests.
+func injectCABundle(obj *unstructured.Unstructured, caBundle []byte) error {
+	webhooks, found, err := unstructured.NestedSlice(obj.Object, "webhooks")
+	if err != nil {
+		return errors.Wrap(err, "cannot decode the webhooks")
+	}
+
+	if !found {
+		return nil
+	}
+
+	for i, item := range webhooks {
+		webhook, ok := item.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("the webhook at index %d is not an object", i)
+		}
+
+		if value, _, _ := unstructured.NestedString(webhook, "clientConfig", "caBundle"); len(value) > 0 {
+			continue
+		}
+
+		if err = unstructured.SetNestedField(webhook, base64.StdEncoding.EncodeToString(caBundle), "clientConfig", "caBundle"); err != nil {
+			return errors.Wrap(err, "cannot set the webhook CA bundle")
+		}
+
+		webhooks[i] = webhook
+	}
+
+	return unstructured.SetNestedSlice(obj.Object, webhooks, "webhooks")
+}
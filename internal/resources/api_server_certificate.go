@@ -121,7 +121,10 @@ func (r *APIServerCertificate) mutate(ctx context.Context, tenantControlPlane *k
 				logger.Info(fmt.Sprintf("%s certificate-private_key pair is not valid: %s", kubeadmconstants.APIServerCertAndKeyBaseName, err.Error()))
 			}
 
-			if isCAValid && isCertValid {
+			// The certificate must be regenerated upon key algorithm change.
+			isKeyAlgorithmValid := hasDesiredKeyAlgorithm(tenantControlPlane, r.resource.Data[kubeadmconstants.APIServerKeyName])
+
+			if isCAValid && isCertValid && isKeyAlgorithmValid {
 				return nil
 			}
 		}
@@ -121,7 +121,10 @@ func (r *APIServerKubeletClientCertificate) mutate(ctx context.Context, tenantCo
 				logger.Info(fmt.Sprintf("%s certificate-private_key pair is not valid: %s", kubeadmconstants.APIServerKubeletClientCertAndKeyBaseName, err.Error()))
 			}
 
-			if isValid && isCAValid {
+			// The certificate must be regenerated upon key algorithm change.
+			isKeyAlgorithmValid := hasDesiredKeyAlgorithm(tenantControlPlane, r.resource.Data[kubeadmconstants.APIServerKubeletClientKeyName])
+
+			if isValid && isCAValid && isKeyAlgorithmValid {
 				return nil
 			}
 		}
@@ -0,0 +1,138 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// APIServerSNICertificates collects the kube-apiserver SNI certificates in a single Secret mounted by the Deployment,
+// the certificates and private keys are resolved from their bare content or external Secret references.
+type APIServerSNICertificates struct {
+	resource *corev1.Secret
+	Client   client.Client
+}
+
+func (r *APIServerSNICertificates) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if tenantControlPlane.Status.Certificates.APIServerSNI == nil {
+		return true
+	}
+
+	return tenantControlPlane.Status.Certificates.APIServerSNI.Checksum != utilities.GetObjectChecksum(r.resource)
+}
+
+func (r *APIServerSNICertificates) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return len(r.getSNICertificates(tenantControlPlane)) == 0 && tenantControlPlane.Status.Certificates.APIServerSNI != nil
+}
+
+func (r *APIServerSNICertificates) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil && !k8serrors.IsNotFound(err) {
+		logger.Error(err, "cannot delete the requested resource")
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *APIServerSNICertificates) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(r.GetName(), tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *APIServerSNICertificates) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if len(r.getSNICertificates(tenantControlPlane)) == 0 {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+}
+
+func (r *APIServerSNICertificates) GetName() string {
+	return "api-server-sni-certificates"
+}
+
+func (r *APIServerSNICertificates) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if len(r.getSNICertificates(tenantControlPlane)) == 0 {
+		tenantControlPlane.Status.Certificates.APIServerSNI = nil
+
+		return nil
+	}
+
+	tenantControlPlane.Status.Certificates.APIServerSNI = &kamajiv1alpha1.CertificatePrivateKeyPairStatus{
+		SecretName: r.resource.GetName(),
+		LastUpdate: metav1.Now(),
+		Checksum:   utilities.GetObjectChecksum(r.resource),
+	}
+
+	return nil
+}
+
+func (r *APIServerSNICertificates) getSNICertificates(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) []kamajiv1alpha1.SNICertificate {
+	if tenantControlPlane.Spec.ControlPlane.APIServer == nil {
+		return nil
+	}
+
+	return tenantControlPlane.Spec.ControlPlane.APIServer.SNICerts
+}
+
+func (r *APIServerSNICertificates) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		logger := log.FromContext(ctx, "resource", r.GetName())
+
+		sniCerts := r.getSNICertificates(tenantControlPlane)
+		data := make(map[string][]byte, 2*len(sniCerts))
+
+		for index, sniCert := range sniCerts {
+			certificate, err := sniCert.Certificate.GetContent(ctx, r.Client)
+			if err != nil {
+				logger.Error(err, "cannot retrieve SNI certificate", "index", index)
+
+				return err
+			}
+
+			privateKey, err := sniCert.PrivateKey.GetContent(ctx, r.Client)
+			if err != nil {
+				logger.Error(err, "cannot retrieve SNI private key", "index", index)
+
+				return err
+			}
+
+			if err = crypto.VerifyCertificateHostnames(certificate, privateKey, sniCert.Hostnames...); err != nil {
+				return fmt.Errorf("SNI certificate at index %d is not valid: %w", index, err)
+			}
+
+			certName, keyName := utilities.SNICertificateKeyNames(index)
+			data[certName], data[keyName] = certificate, privateKey
+		}
+
+		r.resource.SetLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()))
+		r.resource.Data = data
+
+		utilities.SetObjectChecksum(r.resource, r.resource.Data)
+
+		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package automation
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// ClusterRoleBindingResource binds the automation ServiceAccount to the requested ClusterRoles,
+// each ClusterRole is bound using a dedicated ClusterRoleBinding.
+type ClusterRoleBindingResource struct {
+	Client client.Client
+
+	resources    []*rbacv1.ClusterRoleBinding
+	tenantClient client.Client
+}
+
+func (r *ClusterRoleBindingResource) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *ClusterRoleBindingResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.ControlPlane.Automation == nil && tenantControlPlane.Status.Automation != nil
+}
+
+func (r *ClusterRoleBindingResource) CleanUp(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return r.pruneBindings(ctx, tenantControlPlane, sets.New[string]())
+}
+
+func (r *ClusterRoleBindingResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (err error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	r.resources = nil
+
+	if automation := tenantControlPlane.Spec.ControlPlane.Automation; automation != nil {
+		for _, role := range automation.ClusterRoles {
+			r.resources = append(r.resources, &rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterRoleBindingPrefix + role,
+				},
+			})
+		}
+	}
+
+	if r.tenantClient, err = utilities.GetTenantClient(ctx, r.Client, tenantControlPlane); err != nil {
+		logger.Error(err, "cannot generate tenant client")
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *ClusterRoleBindingResource) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	automation := tcp.Spec.ControlPlane.Automation
+	if automation == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	result, desired := controllerutil.OperationResultNone, sets.New[string]()
+
+	for i, role := range automation.ClusterRoles {
+		// Ensuring the referenced ClusterRole exists in the Tenant Control Plane:
+		// binding a missing role would result in a silently useless token.
+		if err := r.tenantClient.Get(ctx, k8stypes.NamespacedName{Name: role}, &rbacv1.ClusterRole{}); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return controllerutil.OperationResultNone, fmt.Errorf("the ClusterRole %s referenced by the automation ServiceAccount does not exist", role)
+			}
+
+			return controllerutil.OperationResultNone, err
+		}
+
+		res, err := controllerutil.CreateOrUpdate(ctx, r.tenantClient, r.resources[i], r.mutate(tcp, r.resources[i], role))
+		if err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+
+		if res != controllerutil.OperationResultNone {
+			result = controllerutil.OperationResultUpdated
+		}
+
+		desired.Insert(r.resources[i].GetName())
+	}
+
+	pruned, err := r.pruneBindings(ctx, tcp, desired)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	if pruned {
+		result = controllerutil.OperationResultUpdated
+	}
+
+	return result, nil
+}
+
+func (r *ClusterRoleBindingResource) GetName() string {
+	return "automation-clusterrolebinding"
+}
+
+func (r *ClusterRoleBindingResource) UpdateTenantControlPlaneStatus(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+// pruneBindings deletes the ClusterRoleBindings managed for the automation ServiceAccount
+// which are not part of the desired set, returning true if any has been removed.
+func (r *ClusterRoleBindingResource) pruneBindings(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, desired sets.Set[string]) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	bindings := &rbacv1.ClusterRoleBindingList{}
+	if err := r.tenantClient.List(ctx, bindings, client.MatchingLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()))); err != nil {
+		logger.Error(err, "cannot list the managed ClusterRoleBindings")
+
+		return false, err
+	}
+
+	var pruned bool
+
+	for i := range bindings.Items {
+		if desired.Has(bindings.Items[i].GetName()) {
+			continue
+		}
+
+		if err := r.tenantClient.Delete(ctx, &bindings.Items[i]); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			logger.Error(err, "cannot delete the requested resource")
+
+			return false, err
+		}
+
+		pruned = true
+	}
+
+	return pruned, nil
+}
+
+func (r *ClusterRoleBindingResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, binding *rbacv1.ClusterRoleBinding, role string) controllerutil.MutateFn {
+	return func() error {
+		binding.SetLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()))
+
+		binding.RoleRef = rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     role,
+		}
+
+		binding.Subjects = []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      tenantControlPlane.Spec.ControlPlane.Automation.ServiceAccountName,
+				Namespace: ServiceAccountNamespace,
+			},
+		}
+
+		return nil
+	}
+}
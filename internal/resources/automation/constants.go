@@ -0,0 +1,21 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package automation
+
+import (
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+const (
+	ServiceAccountNamespace = core.NamespaceSystem
+
+	clusterRoleBindingPrefix      = "kamaji:automation:"
+	tokenExpirationAnnotation     = "kamaji.clastix.io/token-expiration"
+	tokenServiceAccountAnnotation = "kamaji.clastix.io/token-serviceaccount"
+	tokenSecretCertificateAuthKey = "ca.crt"
+	tokenSecretKey                = "token"
+	tokenSecretNamespaceKey       = "namespace"
+	tokenSecretServiceAccountKey  = "serviceAccount"
+	tokenSecretSuffix             = "automation-token"
+)
@@ -0,0 +1,134 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package automation
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+type ServiceAccountResource struct {
+	Client client.Client
+
+	resource     *corev1.ServiceAccount
+	tenantClient client.Client
+}
+
+func (r *ServiceAccountResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if tenantControlPlane.Status.Automation == nil {
+		return true
+	}
+
+	return tenantControlPlane.Status.Automation.ServiceAccount.Name != r.resource.GetName() || tenantControlPlane.Status.Automation.ServiceAccount.Namespace != r.resource.GetNamespace()
+}
+
+func (r *ServiceAccountResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.ControlPlane.Automation == nil && tenantControlPlane.Status.Automation != nil && len(tenantControlPlane.Status.Automation.ServiceAccount.Name) > 0
+}
+
+func (r *ServiceAccountResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.tenantClient.Delete(ctx, r.resource); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		logger.Error(err, "cannot delete the requested resource")
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *ServiceAccountResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (err error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	r.resource = &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ServiceAccountNamespace,
+		},
+	}
+
+	switch {
+	case tenantControlPlane.Spec.ControlPlane.Automation != nil:
+		r.resource.SetName(tenantControlPlane.Spec.ControlPlane.Automation.ServiceAccountName)
+	case tenantControlPlane.Status.Automation != nil:
+		r.resource.SetName(tenantControlPlane.Status.Automation.ServiceAccount.Name)
+	}
+
+	if r.tenantClient, err = utilities.GetTenantClient(ctx, r.Client, tenantControlPlane); err != nil {
+		logger.Error(err, "cannot generate tenant client")
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *ServiceAccountResource) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if tcp.Spec.ControlPlane.Automation == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+	// The ServiceAccount has been renamed: the previous one must be removed
+	// to avoid leaving behind a dangling identity in the Tenant Control Plane.
+	if status := tcp.Status.Automation; status != nil && len(status.ServiceAccount.Name) > 0 && status.ServiceAccount.Name != r.resource.GetName() {
+		previous := &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      status.ServiceAccount.Name,
+				Namespace: status.ServiceAccount.Namespace,
+			},
+		}
+
+		if err := r.tenantClient.Delete(ctx, previous); err != nil && !k8serrors.IsNotFound(err) {
+			return controllerutil.OperationResultNone, err
+		}
+	}
+
+	return controllerutil.CreateOrUpdate(ctx, r.tenantClient, r.resource, r.mutate(tcp))
+}
+
+func (r *ServiceAccountResource) GetName() string {
+	return "automation-sa"
+}
+
+func (r *ServiceAccountResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if tenantControlPlane.Spec.ControlPlane.Automation != nil {
+		if tenantControlPlane.Status.Automation == nil {
+			tenantControlPlane.Status.Automation = &kamajiv1alpha1.AutomationStatus{}
+		}
+
+		tenantControlPlane.Status.Automation.ServiceAccount = kamajiv1alpha1.ExternalKubernetesObjectStatus{
+			Name:       r.resource.GetName(),
+			Namespace:  r.resource.GetNamespace(),
+			LastUpdate: metav1.Now(),
+		}
+
+		return nil
+	}
+
+	if tenantControlPlane.Status.Automation != nil {
+		tenantControlPlane.Status.Automation.ServiceAccount = kamajiv1alpha1.ExternalKubernetesObjectStatus{}
+	}
+
+	return nil
+}
+
+func (r *ServiceAccountResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		r.resource.SetLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()))
+
+		return nil
+	}
+}
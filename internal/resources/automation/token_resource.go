@@ -0,0 +1,201 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package automation
+
+import (
+	"context"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pointer "k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// TokenResource issues a bound token for the automation ServiceAccount using the TokenRequest API,
+// storing it in a Secret of the Tenant Control Plane Namespace of the management cluster.
+type TokenResource struct {
+	Client client.Client
+
+	resource     *corev1.Secret
+	tenantClient client.Client
+}
+
+func (r *TokenResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if tenantControlPlane.Status.Automation == nil {
+		return true
+	}
+
+	return tenantControlPlane.Status.Automation.SecretName != r.resource.GetName() ||
+		tenantControlPlane.Status.Automation.ExpirationTimestamp.UTC().Format(time.RFC3339) != r.resource.GetAnnotations()[tokenExpirationAnnotation]
+}
+
+func (r *TokenResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.ControlPlane.Automation == nil && tenantControlPlane.Status.Automation != nil
+}
+
+func (r *TokenResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil && !k8serrors.IsNotFound(err) {
+		logger.Error(err, "cannot delete the requested resource")
+
+		return false, err
+	}
+	// Returning true even if the Secret has been already deleted:
+	// the automation status must be removed to mark the clean-up as completed.
+	return true, nil
+}
+
+func (r *TokenResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (err error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	r.resource = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(tokenSecretSuffix, tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	if tenantControlPlane.Spec.ControlPlane.Automation == nil {
+		return nil
+	}
+
+	if r.tenantClient, err = utilities.GetTenantClient(ctx, r.Client, tenantControlPlane); err != nil {
+		logger.Error(err, "cannot generate tenant client")
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *TokenResource) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if tcp.Spec.ControlPlane.Automation == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return controllerutil.CreateOrUpdate(ctx, r.Client, r.resource, r.mutate(ctx, tcp))
+}
+
+func (r *TokenResource) GetName() string {
+	return "automation-token"
+}
+
+func (r *TokenResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if tenantControlPlane.Spec.ControlPlane.Automation == nil {
+		tenantControlPlane.Status.Automation = nil
+
+		return nil
+	}
+
+	if tenantControlPlane.Status.Automation == nil {
+		tenantControlPlane.Status.Automation = &kamajiv1alpha1.AutomationStatus{}
+	}
+
+	expiration, err := time.Parse(time.RFC3339, r.resource.GetAnnotations()[tokenExpirationAnnotation])
+	if err != nil {
+		return err
+	}
+
+	tenantControlPlane.Status.Automation.SecretName = r.resource.GetName()
+	tenantControlPlane.Status.Automation.ExpirationTimestamp = metav1.NewTime(expiration)
+	tenantControlPlane.Status.Automation.LastUpdate = metav1.Now()
+
+	return nil
+}
+
+// RotationTime returns the time when the issued token must be rotated,
+// corresponding to two-thirds of its lifetime.
+func RotationTime(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, expiration time.Time) time.Time {
+	return expiration.Add(-tenantControlPlane.Spec.ControlPlane.Automation.TokenTTL.Duration / 3)
+}
+
+func (r *TokenResource) shouldRotate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	annotations := r.resource.GetAnnotations()
+
+	if annotations[tokenServiceAccountAnnotation] != tenantControlPlane.Spec.ControlPlane.Automation.ServiceAccountName {
+		return true
+	}
+
+	if len(r.resource.Data[tokenSecretKey]) == 0 {
+		return true
+	}
+
+	expiration, err := time.Parse(time.RFC3339, annotations[tokenExpirationAnnotation])
+	if err != nil {
+		return true
+	}
+
+	return time.Now().After(RotationTime(tenantControlPlane, expiration))
+}
+
+func (r *TokenResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		logger := log.FromContext(ctx, "resource", r.GetName())
+
+		r.resource.SetLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()))
+
+		if err := ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme()); err != nil {
+			logger.Error(err, "cannot set controller reference", "resource", r.GetName())
+
+			return err
+		}
+
+		if !r.shouldRotate(tenantControlPlane) {
+			return nil
+		}
+
+		automation := tenantControlPlane.Spec.ControlPlane.Automation
+
+		sa := &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      automation.ServiceAccountName,
+				Namespace: ServiceAccountNamespace,
+			},
+		}
+
+		tokenRequest := &authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{
+				ExpirationSeconds: pointer.To(int64(automation.TokenTTL.Seconds())),
+			},
+		}
+
+		if err := r.tenantClient.SubResource("token").Create(ctx, sa, tokenRequest); err != nil {
+			logger.Error(err, "cannot issue the token for the automation ServiceAccount")
+
+			return err
+		}
+
+		kubeconfig, err := utilities.GetTenantKubeconfig(ctx, r.Client, tenantControlPlane)
+		if err != nil {
+			logger.Error(err, "cannot retrieve the Tenant Control Plane kubeconfig")
+
+			return err
+		}
+
+		r.resource.SetAnnotations(utilities.MergeMaps(r.resource.GetAnnotations(), map[string]string{
+			tokenExpirationAnnotation:     tokenRequest.Status.ExpirationTimestamp.UTC().Format(time.RFC3339),
+			tokenServiceAccountAnnotation: automation.ServiceAccountName,
+		}))
+
+		r.resource.Type = corev1.SecretTypeOpaque
+		r.resource.Data = map[string][]byte{
+			tokenSecretKey:                []byte(tokenRequest.Status.Token),
+			tokenSecretCertificateAuthKey: kubeconfig.Clusters[0].Cluster.CertificateAuthorityData,
+			tokenSecretServiceAccountKey:  []byte(automation.ServiceAccountName),
+			tokenSecretNamespaceKey:       []byte(ServiceAccountNamespace),
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// ClusterCABundle collects the Tenant Control Plane CA, and the additional Certificate Authorities, in a single Secret:
+// the bundle is used as the kube-controller-manager root CA file, and as the certificate authority of the kubeconfig files.
+type ClusterCABundle struct {
+	resource *corev1.Secret
+	Client   client.Client
+}
+
+func (r *ClusterCABundle) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	status := tenantControlPlane.Status.Certificates.ClusterCABundle
+
+	if r.getClusterCABundleRef(tenantControlPlane) == nil {
+		return status != nil
+	}
+
+	return status == nil || status.Checksum != utilities.GetObjectChecksum(r.resource)
+}
+
+func (r *ClusterCABundle) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return r.getClusterCABundleRef(tenantControlPlane) == nil && tenantControlPlane.Status.Certificates.ClusterCABundle != nil
+}
+
+func (r *ClusterCABundle) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil && !k8serrors.IsNotFound(err) {
+		logger.Error(err, "cannot delete the requested resource")
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *ClusterCABundle) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(r.GetName(), tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *ClusterCABundle) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if r.getClusterCABundleRef(tenantControlPlane) == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+}
+
+func (r *ClusterCABundle) GetName() string {
+	return "cluster-ca-bundle"
+}
+
+func (r *ClusterCABundle) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if r.getClusterCABundleRef(tenantControlPlane) == nil {
+		tenantControlPlane.Status.Certificates.ClusterCABundle = nil
+
+		return nil
+	}
+
+	tenantControlPlane.Status.Certificates.ClusterCABundle = &kamajiv1alpha1.CertificatePrivateKeyPairStatus{
+		SecretName: r.resource.GetName(),
+		LastUpdate: metav1.Now(),
+		Checksum:   utilities.GetObjectChecksum(r.resource),
+	}
+
+	return nil
+}
+
+func (r *ClusterCABundle) getClusterCABundleRef(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) *kamajiv1alpha1.ContentRef {
+	if tenantControlPlane.Spec.ControlPlane.Certificates == nil {
+		return nil
+	}
+
+	return tenantControlPlane.Spec.ControlPlane.Certificates.ClusterCABundleRef
+}
+
+func (r *ClusterCABundle) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		logger := log.FromContext(ctx, "resource", r.GetName())
+
+		bundle, err := r.getClusterCABundleRef(tenantControlPlane).GetContent(ctx, r.Client)
+		if err != nil {
+			logger.Error(err, "cannot retrieve the cluster CA bundle")
+
+			return err
+		}
+
+		if err = crypto.VerifyCABundle(bundle); err != nil {
+			return fmt.Errorf("the cluster CA bundle is not valid: %w", err)
+		}
+
+		var caSecret corev1.Secret
+		if err = r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.Certificates.CA.SecretName}, &caSecret); err != nil {
+			logger.Error(err, "cannot retrieve the CA")
+
+			return err
+		}
+		// The Tenant Control Plane CA comes first, since it's the one issuing the API Server serving certificate.
+		ca := bytes.TrimSpace(caSecret.Data[kubeadmconstants.CACertName])
+
+		r.resource.SetLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()))
+		r.resource.Data = map[string][]byte{
+			constants.ClusterCABundleName: bytes.Join([][]byte{ca, bundle}, []byte("\n")),
+		}
+
+		utilities.SetObjectChecksum(r.resource, r.resource.Data)
+
+		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
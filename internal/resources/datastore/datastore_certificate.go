@@ -18,6 +18,7 @@ import (
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/datastore"
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
@@ -132,20 +133,13 @@ func (r *Certificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1al
 			var crtBytes, keyBytes []byte
 			// For the SQL drivers we just need to copy the certificate, since the basic authentication is used
 			// to connect to the desired schema and database.
-			if crtBytes, err = r.DataStore.Spec.TLSConfig.ClientCertificate.Certificate.GetContent(ctx, r.Client); err != nil {
-				logger.Error(err, "unable to retrieve certificate content")
+			if crtBytes, keyBytes, err = datastore.ClientCertificate(ctx, r.Client, r.DataStore); err != nil {
+				logger.Error(err, "unable to retrieve client certificate content")
 
 				return err
 			}
 
-			crt = bytes.NewBuffer(crtBytes)
-
-			if keyBytes, err = r.DataStore.Spec.TLSConfig.ClientCertificate.PrivateKey.GetContent(ctx, r.Client); err != nil {
-				logger.Error(err, "unable to retrieve private key content")
-
-				return err
-			}
-			key = bytes.NewBuffer(keyBytes)
+			crt, key = bytes.NewBuffer(crtBytes), bytes.NewBuffer(keyBytes)
 		default:
 			return fmt.Errorf("unrecognized driver for Certificate generation")
 		}
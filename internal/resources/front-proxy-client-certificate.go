@@ -121,7 +121,10 @@ func (r *FrontProxyClientCertificate) mutate(ctx context.Context, tenantControlP
 				logger.Info(fmt.Sprintf("%s certificate-private_key pair is not valid: %s", kubeadmconstants.FrontProxyClientCertAndKeyBaseName, err.Error()))
 			}
 
-			if isValid && isCAValid {
+			// The certificate must be regenerated upon key algorithm change.
+			isKeyAlgorithmValid := hasDesiredKeyAlgorithm(tenantControlPlane, r.resource.Data[kubeadmconstants.FrontProxyClientKeyName])
+
+			if isValid && isCAValid && isKeyAlgorithmValid {
 				return nil
 			}
 		}
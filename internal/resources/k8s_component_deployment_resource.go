@@ -0,0 +1,131 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// KubernetesComponentDeploymentResource manages the Deployment of a Control Plane component
+// running isolated from the API Server, such as the controller-manager, or the scheduler.
+type KubernetesComponentDeploymentResource struct {
+	resource  *appsv1.Deployment
+	Client    client.Client
+	DataStore kamajiv1alpha1.DataStore
+	Component builder.IsolatedComponent
+}
+
+func (r *KubernetesComponentDeploymentResource) status(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) **kamajiv1alpha1.KubernetesDeploymentStatus {
+	if r.Component == builder.ControllerManagerComponent {
+		return &tenantControlPlane.Status.Kubernetes.ControllerManagerDeployment
+	}
+
+	return &tenantControlPlane.Status.Kubernetes.SchedulerDeployment
+}
+
+func (r *KubernetesComponentDeploymentResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	status := *r.status(tenantControlPlane)
+
+	if r.ShouldCleanup(tenantControlPlane) {
+		return status != nil
+	}
+
+	return status == nil || status.DeploymentStatus.String() != r.resource.Status.String()
+}
+
+func (r *KubernetesComponentDeploymentResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !tenantControlPlane.Spec.ControlPlane.Deployment.ComponentIsolation
+}
+
+func (r *KubernetesComponentDeploymentResource) CleanUp(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot cleanup resource")
+
+			return false, err
+		}
+		// The status must be cleaned up, even if the Deployment has been already deleted.
+		return *r.status(tenantControlPlane) != nil, nil
+	}
+
+	return true, nil
+}
+
+func (r *KubernetesComponentDeploymentResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      builder.IsolatedComponentDeploymentName(*tenantControlPlane, r.Component),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *KubernetesComponentDeploymentResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, func() error {
+		(builder.Deployment{
+			Client:    r.Client,
+			DataStore: r.DataStore,
+		}).BuildIsolatedComponent(ctx, r.resource, *tenantControlPlane, r.Component)
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	})
+}
+
+func (r *KubernetesComponentDeploymentResource) GetName() string {
+	return fmt.Sprintf("%s-deployment", r.Component)
+}
+
+func (r *KubernetesComponentDeploymentResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	status := r.status(tenantControlPlane)
+
+	if r.ShouldCleanup(tenantControlPlane) {
+		*status = nil
+
+		return nil
+	}
+
+	*status = &kamajiv1alpha1.KubernetesDeploymentStatus{
+		DeploymentStatus: r.resource.Status,
+		Selector:         metav1.FormatLabelSelector(r.resource.Spec.Selector),
+		Name:             r.resource.GetName(),
+		Namespace:        r.resource.GetNamespace(),
+		LastUpdate:       metav1.Now(),
+	}
+
+	return nil
+}
+
+// isolatedComponentsProgressing returns true if any of the isolated components Deployments is not available.
+func isolatedComponentsProgressing(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if !tenantControlPlane.Spec.ControlPlane.Deployment.ComponentIsolation {
+		return false
+	}
+
+	for _, status := range []*kamajiv1alpha1.KubernetesDeploymentStatus{
+		tenantControlPlane.Status.Kubernetes.ControllerManagerDeployment,
+		tenantControlPlane.Status.Kubernetes.SchedulerDeployment,
+	} {
+		if status == nil || status.ObservedGeneration == 0 || status.UnavailableReplicas > 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -22,6 +23,10 @@ type KubernetesDeploymentResource struct {
 	DataStore          kamajiv1alpha1.DataStore
 	Name               string
 	KineContainerImage string
+	KinePullPolicy     corev1.PullPolicy
+	KineResources      corev1.ResourceRequirements
+
+	changeReason *kamajiv1alpha1.DeploymentChangeReason
 }
 
 func (r *KubernetesDeploymentResource) isStatusEqual(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
@@ -29,7 +34,29 @@ func (r *KubernetesDeploymentResource) isStatusEqual(tenantControlPlane *kamajiv
 }
 
 func (r *KubernetesDeploymentResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return !r.isStatusEqual(tenantControlPlane) || tenantControlPlane.Spec.Kubernetes.Version != tenantControlPlane.Status.Kubernetes.Version.Version
+	return !r.isStatusEqual(tenantControlPlane) || tenantControlPlane.Spec.Kubernetes.Version != tenantControlPlane.Status.Kubernetes.Version.Version || r.changeReason != nil ||
+		r.isIsolatedComponentsReadinessChanged(tenantControlPlane) || r.isSuspensionChanged(tenantControlPlane)
+}
+
+// isSuspensionChanged returns true when the suspension of the Tenant Control Plane is not reflected by the Kubernetes version status.
+func (r *KubernetesDeploymentResource) isSuspensionChanged(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	status := tenantControlPlane.Status.Kubernetes.Version.Status
+	suspended := status != nil && *status == kamajiv1alpha1.VersionSuspended
+
+	return suspended != (tenantControlPlane.IsSuspended() && !tenantControlPlane.IsQuarantined())
+}
+
+// isIsolatedComponentsReadinessChanged returns true when the readiness of the isolated components Deployments,
+// which are handled before the API Server one, is not reflected by the Kubernetes version status.
+func (r *KubernetesDeploymentResource) isIsolatedComponentsReadinessChanged(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if !tenantControlPlane.Spec.ControlPlane.Deployment.ComponentIsolation {
+		return false
+	}
+
+	status := tenantControlPlane.Status.Kubernetes.Version.Status
+	ready := status != nil && *status == kamajiv1alpha1.VersionReady
+
+	return ready && isolatedComponentsProgressing(tenantControlPlane) || !ready && !r.isProgressingUpgrade(tenantControlPlane)
 }
 
 func (r *KubernetesDeploymentResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -55,11 +82,19 @@ func (r *KubernetesDeploymentResource) Define(_ context.Context, tenantControlPl
 
 func (r *KubernetesDeploymentResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
 	return func() error {
+		previous := r.resource.Spec.Template.DeepCopy()
+
 		(builder.Deployment{
 			Client:             r.Client,
 			DataStore:          r.DataStore,
 			KineContainerImage: r.KineContainerImage,
+			KinePullPolicy:     r.KinePullPolicy,
+			KineResources:      r.KineResources,
 		}).Build(ctx, r.resource, *tenantControlPlane)
+		// Tracking the causes of the rollout, unless the Deployment is being created.
+		if len(r.resource.GetResourceVersion()) > 0 {
+			r.changeReason = deploymentChangeReason(*previous, r.resource.Spec.Template)
+		}
 
 		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
 	}
@@ -75,33 +110,47 @@ func (r *KubernetesDeploymentResource) GetName() string {
 
 func (r *KubernetesDeploymentResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
 	switch {
-	case !r.isProgressingUpgrade():
+	case tenantControlPlane.IsQuarantined():
+		tenantControlPlane.Status.Kubernetes.Version.Status = &kamajiv1alpha1.VersionNotReady
+	case tenantControlPlane.IsSuspended():
+		tenantControlPlane.Status.Kubernetes.Version.Status = &kamajiv1alpha1.VersionSuspended
+	case !r.isProgressingUpgrade(tenantControlPlane):
 		tenantControlPlane.Status.Kubernetes.Version.Status = &kamajiv1alpha1.VersionReady
 		tenantControlPlane.Status.Kubernetes.Version.Version = tenantControlPlane.Spec.Kubernetes.Version
 	case r.isUpgrading(tenantControlPlane):
 		tenantControlPlane.Status.Kubernetes.Version.Status = &kamajiv1alpha1.VersionUpgrading
 	case r.isProvisioning(tenantControlPlane):
 		tenantControlPlane.Status.Kubernetes.Version.Status = &kamajiv1alpha1.VersionProvisioning
-	case r.isNotReady():
+	case r.isNotReady(), isolatedComponentsProgressing(tenantControlPlane):
 		tenantControlPlane.Status.Kubernetes.Version.Status = &kamajiv1alpha1.VersionNotReady
 	}
 
+	changeReason := tenantControlPlane.Status.Kubernetes.Deployment.LastChangeReason
+	if r.changeReason != nil {
+		changeReason = r.changeReason
+	}
+
 	tenantControlPlane.Status.Kubernetes.Deployment = kamajiv1alpha1.KubernetesDeploymentStatus{
 		DeploymentStatus: r.resource.Status,
 		Selector:         metav1.FormatLabelSelector(r.resource.Spec.Selector),
 		Name:             r.resource.GetName(),
 		Namespace:        r.resource.GetNamespace(),
 		LastUpdate:       metav1.Now(),
+		LastChangeReason: changeReason,
 	}
 
 	return nil
 }
 
-func (r *KubernetesDeploymentResource) isProgressingUpgrade() bool {
+func (r *KubernetesDeploymentResource) isProgressingUpgrade(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
 	if r.resource.ObjectMeta.GetGeneration() != r.resource.Status.ObservedGeneration {
 		return true
 	}
 
+	if isolatedComponentsProgressing(tenantControlPlane) {
+		return true
+	}
+
 	if r.resource.Status.UnavailableReplicas > 0 {
 		return true
 	}
@@ -112,7 +161,7 @@ func (r *KubernetesDeploymentResource) isProgressingUpgrade() bool {
 func (r *KubernetesDeploymentResource) isUpgrading(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
 	return len(tenantControlPlane.Status.Kubernetes.Version.Version) > 0 &&
 		tenantControlPlane.Spec.Kubernetes.Version != tenantControlPlane.Status.Kubernetes.Version.Version &&
-		r.isProgressingUpgrade()
+		r.isProgressingUpgrade(tenantControlPlane)
 }
 
 func (r *KubernetesDeploymentResource) isProvisioning(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
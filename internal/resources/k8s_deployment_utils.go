@@ -0,0 +1,156 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	deploymentChangeCertificateRotation = "CertificateRotation"
+	deploymentChangeKubeconfigRotation  = "KubeconfigRotation"
+	deploymentChangeDataStore           = "DataStoreChange"
+	deploymentChangeFlags               = "FlagsChange"
+	deploymentChangeVersion             = "VersionChange"
+	deploymentChangeResources           = "ResourcesChange"
+	deploymentChangeSpec                = "SpecChange"
+
+	// componentLabelPrefix is the prefix of the Pod template labels tracking the mounted Secrets content.
+	componentLabelPrefix = "component.kamaji.clastix.io/"
+	// maxChangedArgs limits the reported arguments changes, keeping the status size under control.
+	maxChangedArgs = 20
+)
+
+var sensitiveFlagRegexp = regexp.MustCompile(`(?i)(password|secret|token)`)
+
+// deploymentChangeReason compares the Pod templates before and after the Deployment build,
+// returning the summary of the changes triggering a rollout, or nil if the template is unchanged.
+func deploymentChangeReason(previous, current corev1.PodTemplateSpec) *kamajiv1alpha1.DeploymentChangeReason {
+	if apiequality.Semantic.DeepEqual(previous, current) {
+		return nil
+	}
+
+	reasons := sets.New[string]()
+
+	for _, key := range sets.List(sets.KeySet(previous.Labels).Union(sets.KeySet(current.Labels))) {
+		if previous.Labels[key] == current.Labels[key] {
+			continue
+		}
+
+		switch component := strings.TrimPrefix(key, componentLabelPrefix); {
+		case component == key:
+			reasons.Insert(deploymentChangeSpec)
+		case component == "datastore":
+			reasons.Insert(deploymentChangeDataStore)
+		case strings.HasSuffix(component, "-kubeconfig"):
+			reasons.Insert(deploymentChangeKubeconfigRotation)
+		default:
+			reasons.Insert(deploymentChangeCertificateRotation)
+		}
+	}
+
+	if !apiequality.Semantic.DeepEqual(previous.Annotations, current.Annotations) {
+		reasons.Insert(deploymentChangeSpec)
+	}
+
+	var changedArgs []string
+
+	for _, containers := range [][2][]corev1.Container{
+		{previous.Spec.InitContainers, current.Spec.InitContainers},
+		{previous.Spec.Containers, current.Spec.Containers},
+	} {
+		if len(containers[0]) != len(containers[1]) {
+			reasons.Insert(deploymentChangeSpec)
+		}
+
+		for _, container := range containers[1] {
+			found, index := utilities.HasNamedContainer(containers[0], container.Name)
+			if !found {
+				reasons.Insert(deploymentChangeSpec)
+
+				continue
+			}
+
+			prev := containers[0][index].DeepCopy()
+
+			if prev.Image != container.Image {
+				reasons.Insert(deploymentChangeVersion)
+			}
+
+			if !apiequality.Semantic.DeepEqual(prev.Args, container.Args) {
+				reasons.Insert(deploymentChangeFlags)
+
+				changedArgs = append(changedArgs, containerChangedArgs(container.Name, prev.Args, container.Args)...)
+			}
+
+			if !apiequality.Semantic.DeepEqual(prev.Resources, container.Resources) {
+				reasons.Insert(deploymentChangeResources)
+			}
+			// Any other difference in the container is reported as a generic Pod template change.
+			prev.Image, prev.Args, prev.Resources = container.Image, container.Args, container.Resources
+
+			if !apiequality.Semantic.DeepEqual(*prev, container) {
+				reasons.Insert(deploymentChangeSpec)
+			}
+		}
+	}
+
+	previousSpec, currentSpec := previous.Spec.DeepCopy(), current.Spec.DeepCopy()
+	previousSpec.InitContainers, previousSpec.Containers = currentSpec.InitContainers, currentSpec.Containers
+
+	if !apiequality.Semantic.DeepEqual(previousSpec, currentSpec) {
+		reasons.Insert(deploymentChangeSpec)
+	}
+
+	if len(changedArgs) > maxChangedArgs {
+		changedArgs = append(changedArgs[:maxChangedArgs], fmt.Sprintf("and %d more", len(changedArgs)-maxChangedArgs))
+	}
+
+	return &kamajiv1alpha1.DeploymentChangeReason{
+		Reasons:     sets.List(reasons),
+		ChangedArgs: changedArgs,
+		LastUpdate:  metav1.Now(),
+	}
+}
+
+// containerChangedArgs returns the added, removed, and updated flags of the given container.
+func containerChangedArgs(container string, previous, current []string) (changes []string) {
+	prev, curr := utilities.ArgsFromSliceToMap(previous), utilities.ArgsFromSliceToMap(current)
+
+	for _, flag := range sets.List(sets.KeySet(prev).Union(sets.KeySet(curr))) {
+		prevValue, prevOk := prev[flag]
+		currValue, currOk := curr[flag]
+
+		if prevOk == currOk && prevValue == currValue {
+			continue
+		}
+
+		changes = append(changes, fmt.Sprintf("%s: %s %s -> %s", container, flag, redactArgValue(flag, prevValue, prevOk), redactArgValue(flag, currValue, currOk)))
+	}
+
+	return changes
+}
+
+func redactArgValue(flag, value string, ok bool) string {
+	switch {
+	case !ok:
+		return "<unset>"
+	case sensitiveFlagRegexp.MatchString(flag):
+		return "<redacted>"
+	case len(value) == 0:
+		return "<empty>"
+	default:
+		return value
+	}
+}
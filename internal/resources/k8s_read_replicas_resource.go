@@ -0,0 +1,202 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// KubernetesReadReplicasDeploymentResource manages the Deployment of the kube-apiserver read replicas.
+type KubernetesReadReplicasDeploymentResource struct {
+	resource           *appsv1.Deployment
+	Client             client.Client
+	DataStore          kamajiv1alpha1.DataStore
+	KineContainerImage string
+	KinePullPolicy     corev1.PullPolicy
+	KineResources      corev1.ResourceRequirements
+}
+
+func (r *KubernetesReadReplicasDeploymentResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	status := tenantControlPlane.Status.Kubernetes.ReadReplicasDeployment
+
+	if r.ShouldCleanup(tenantControlPlane) {
+		return status != nil
+	}
+
+	return status == nil || status.DeploymentStatus.String() != r.resource.Status.String()
+}
+
+func (r *KubernetesReadReplicasDeploymentResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.ControlPlane.ReadReplicas == nil
+}
+
+func (r *KubernetesReadReplicasDeploymentResource) CleanUp(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot cleanup resource")
+
+			return false, err
+		}
+		// The status must be cleaned up, even if the Deployment has been already deleted.
+		return tenantControlPlane.Status.Kubernetes.ReadReplicasDeployment != nil, nil
+	}
+
+	return true, nil
+}
+
+func (r *KubernetesReadReplicasDeploymentResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      builder.ReadReplicasName(*tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *KubernetesReadReplicasDeploymentResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, func() error {
+		(builder.Deployment{
+			Client:             r.Client,
+			DataStore:          r.DataStore,
+			KineContainerImage: r.KineContainerImage,
+			KinePullPolicy:     r.KinePullPolicy,
+			KineResources:      r.KineResources,
+		}).BuildReadReplicas(ctx, r.resource, *tenantControlPlane)
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	})
+}
+
+func (r *KubernetesReadReplicasDeploymentResource) GetName() string {
+	return "read-replicas-deployment"
+}
+
+func (r *KubernetesReadReplicasDeploymentResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if r.ShouldCleanup(tenantControlPlane) {
+		tenantControlPlane.Status.Kubernetes.ReadReplicasDeployment = nil
+
+		return nil
+	}
+
+	tenantControlPlane.Status.Kubernetes.ReadReplicasDeployment = &kamajiv1alpha1.KubernetesDeploymentStatus{
+		DeploymentStatus: r.resource.Status,
+		Selector:         metav1.FormatLabelSelector(r.resource.Spec.Selector),
+		Name:             r.resource.GetName(),
+		Namespace:        r.resource.GetNamespace(),
+		LastUpdate:       metav1.Now(),
+	}
+
+	return nil
+}
+
+// KubernetesReadReplicasServiceResource manages the Service exposing the kube-apiserver read replicas.
+type KubernetesReadReplicasServiceResource struct {
+	resource *corev1.Service
+	Client   client.Client
+}
+
+func (r *KubernetesReadReplicasServiceResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	status := tenantControlPlane.Status.Kubernetes.ReadReplicasService
+
+	if r.ShouldCleanup(tenantControlPlane) {
+		return status != nil
+	}
+
+	return status == nil || status.ServiceStatus.String() != r.resource.Status.String()
+}
+
+func (r *KubernetesReadReplicasServiceResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.ControlPlane.ReadReplicas == nil
+}
+
+func (r *KubernetesReadReplicasServiceResource) CleanUp(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot cleanup resource")
+
+			return false, err
+		}
+		// The status must be cleaned up, even if the Service has been already deleted.
+		return tenantControlPlane.Status.Kubernetes.ReadReplicasService != nil, nil
+	}
+
+	return true, nil
+}
+
+func (r *KubernetesReadReplicasServiceResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      builder.ReadReplicasName(*tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *KubernetesReadReplicasServiceResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, func() error {
+		r.resource.SetLabels(utilities.MergeMaps(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()), tenantControlPlane.Spec.ControlPlane.Service.AdditionalMetadata.Labels))
+		r.resource.SetAnnotations(serviceAnnotations(r.resource.GetAnnotations(), tenantControlPlane.Spec.ControlPlane.Service.AdditionalMetadata.Annotations))
+
+		r.resource.Spec.Selector = builder.ReadReplicasSelector(*tenantControlPlane)
+
+		if len(r.resource.Spec.Ports) == 0 {
+			r.resource.Spec.Ports = make([]corev1.ServicePort, 1)
+		}
+
+		r.resource.Spec.Ports[0].Name = "kube-apiserver"
+		r.resource.Spec.Ports[0].Protocol = corev1.ProtocolTCP
+		r.resource.Spec.Ports[0].Port = tenantControlPlane.Spec.NetworkProfile.Port
+		r.resource.Spec.Ports[0].TargetPort = intstr.FromInt(int(tenantControlPlane.Spec.NetworkProfile.Port))
+		// The Node port, if any, is assigned by the management cluster since the Tenant Control Plane one is already taken.
+		r.resource.Spec.Type = corev1.ServiceType(tenantControlPlane.Spec.ControlPlane.ReadReplicas.ServiceType)
+		if len(r.resource.Spec.Type) == 0 || r.resource.Spec.Type == corev1.ServiceTypeClusterIP {
+			r.resource.Spec.Type = corev1.ServiceTypeClusterIP
+			r.resource.Spec.Ports[0].NodePort = 0
+		}
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	})
+}
+
+func (r *KubernetesReadReplicasServiceResource) GetName() string {
+	return "read-replicas-service"
+}
+
+func (r *KubernetesReadReplicasServiceResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if r.ShouldCleanup(tenantControlPlane) {
+		tenantControlPlane.Status.Kubernetes.ReadReplicasService = nil
+
+		return nil
+	}
+
+	tenantControlPlane.Status.Kubernetes.ReadReplicasService = &kamajiv1alpha1.KubernetesServiceStatus{
+		ServiceStatus: r.resource.Status,
+		Name:          r.resource.GetName(),
+		Namespace:     r.resource.GetNamespace(),
+		Port:          r.resource.Spec.Ports[0].Port,
+	}
+
+	return nil
+}
@@ -6,8 +6,12 @@ package resources
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -15,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
@@ -23,12 +28,21 @@ import (
 type KubernetesServiceResource struct {
 	resource *corev1.Service
 	Client   client.Client
+	// drift describes the drift of the Kamaji-managed fields detected upon the last update, if any.
+	drift string
+}
+
+// Drift returns the drift of the Kamaji-managed fields of the Service detected upon the last update, if any.
+func (r *KubernetesServiceResource) Drift() string {
+	return r.drift
 }
 
 func (r *KubernetesServiceResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return tenantControlPlane.Status.Kubernetes.Service.Name != r.resource.GetName() ||
+	return r.isDriftConditionChanged(tenantControlPlane) ||
+		tenantControlPlane.Status.Kubernetes.Service.Name != r.resource.GetName() ||
 		tenantControlPlane.Status.Kubernetes.Service.Namespace != r.resource.GetNamespace() ||
-		tenantControlPlane.Status.Kubernetes.Service.Port != r.resource.Spec.Ports[0].Port
+		tenantControlPlane.Status.Kubernetes.Service.Port != r.resource.Spec.Ports[0].Port ||
+		len(tenantControlPlane.Status.ControlPlaneEndpoint) == 0
 }
 
 func (r *KubernetesServiceResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -56,6 +70,24 @@ func (r *KubernetesServiceResource) UpdateTenantControlPlaneStatus(ctx context.C
 
 	tenantControlPlane.Status.ControlPlaneEndpoint = fmt.Sprintf("%s:%d", address, tenantControlPlane.Spec.NetworkProfile.Port)
 
+	if condition := r.driftCondition(tenantControlPlane); condition != nil {
+		meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, *condition)
+	} else {
+		meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.ServiceDriftCondition)
+	}
+
+	if r.resource.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, metav1.Condition{
+			Type:               kamajiv1alpha1.WaitingForLoadBalancerCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "Assigned",
+			Message:            "the LoadBalancer Service has been assigned an address",
+			ObservedGeneration: tenantControlPlane.GetGeneration(),
+		})
+	} else {
+		meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.WaitingForLoadBalancerCondition)
+	}
+
 	return nil
 }
 
@@ -80,11 +112,14 @@ func (r *KubernetesServiceResource) mutate(ctx context.Context, tenantControlPla
 	address, _ := tenantControlPlane.DeclaredControlPlaneAddress(ctx, r.Client)
 
 	return func() error {
+		r.drift = ""
+
+		current := r.resource.DeepCopy()
+
 		labels := utilities.MergeMaps(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()), tenantControlPlane.Spec.ControlPlane.Service.AdditionalMetadata.Labels)
 		r.resource.SetLabels(labels)
 
-		annotations := utilities.MergeMaps(r.resource.GetAnnotations(), tenantControlPlane.Spec.ControlPlane.Service.AdditionalMetadata.Annotations)
-		r.resource.SetAnnotations(annotations)
+		r.resource.SetAnnotations(serviceAnnotations(r.resource.GetAnnotations(), tenantControlPlane.Spec.ControlPlane.Service.AdditionalMetadata.Annotations))
 
 		r.resource.Spec.Selector = map[string]string{
 			"kamaji.clastix.io/name": tenantControlPlane.GetName(),
@@ -120,11 +155,137 @@ func (r *KubernetesServiceResource) mutate(ctx context.Context, tenantControlPla
 				r.resource.Spec.ExternalIPs = []string{address}
 			}
 		}
+		// The drift is checked only for the existing Services, rather than upon their creation.
+		if len(current.GetResourceVersion()) > 0 {
+			r.drift = serviceDrift(current, r.resource)
+
+			if len(r.drift) > 0 && !tenantControlPlane.IsServiceEnforced() {
+				r.resource.Spec.Selector = current.Spec.Selector
+				r.resource.Spec.Ports = current.Spec.Ports
+				r.resource.Spec.Type = current.Spec.Type
+			}
+		}
 
 		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
 	}
 }
 
+// isDriftConditionChanged returns true if the ServiceDrift condition doesn't reflect the last detected drift.
+func (r *KubernetesServiceResource) isDriftConditionChanged(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	current := meta.FindStatusCondition(tenantControlPlane.Status.Conditions, kamajiv1alpha1.ServiceDriftCondition)
+	desired := r.driftCondition(tenantControlPlane)
+
+	if current == nil || desired == nil {
+		return (current == nil) != (desired == nil)
+	}
+
+	return current.Status != desired.Status || current.Reason != desired.Reason || current.Message != desired.Message
+}
+
+// driftCondition returns the ServiceDrift condition according to the last detected drift: the condition is reported
+// as soon as a drift is detected, and it's kept as it is until a new drift is detected, or the preserved one is resolved.
+func (r *KubernetesServiceResource) driftCondition(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) *metav1.Condition {
+	current := meta.FindStatusCondition(tenantControlPlane.Status.Conditions, kamajiv1alpha1.ServiceDriftCondition)
+
+	switch {
+	case len(r.drift) > 0 && tenantControlPlane.IsServiceEnforced():
+		return &metav1.Condition{
+			Type:               kamajiv1alpha1.ServiceDriftCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "Reverted",
+			Message:            fmt.Sprintf("the Service drifted from the desired state, and has been reverted: %s", r.drift),
+			ObservedGeneration: tenantControlPlane.GetGeneration(),
+		}
+	case len(r.drift) > 0:
+		return &metav1.Condition{
+			Type:               kamajiv1alpha1.ServiceDriftCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Detected",
+			Message:            fmt.Sprintf("the Service drifted from the desired state, and the enforcement is disabled: %s", r.drift),
+			ObservedGeneration: tenantControlPlane.GetGeneration(),
+		}
+	case current != nil && current.Status == metav1.ConditionTrue:
+		return &metav1.Condition{
+			Type:               kamajiv1alpha1.ServiceDriftCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "Resolved",
+			Message:            "the Service is matching the desired state",
+			ObservedGeneration: tenantControlPlane.GetGeneration(),
+		}
+	default:
+		return current
+	}
+}
+
+// serviceDrift returns the Kamaji-managed fields of the current Service drifting from the desired one.
+func serviceDrift(current, desired *corev1.Service) string {
+	var drifts []string
+
+	if !reflect.DeepEqual(current.Spec.Selector, desired.Spec.Selector) {
+		drifts = append(drifts, "selector")
+	}
+
+	if current.Spec.Type != desired.Spec.Type {
+		drifts = append(drifts, "type")
+	}
+
+	if len(current.Spec.Ports) == 0 {
+		drifts = append(drifts, "ports")
+	} else {
+		currentPort, desiredPort := current.Spec.Ports[0], desired.Spec.Ports[0]
+		// The Node port is assigned by the management cluster, unless it's explicitly set.
+		if desiredPort.NodePort == 0 {
+			currentPort.NodePort = 0
+		}
+
+		if currentPort.Name != desiredPort.Name || currentPort.Protocol != desiredPort.Protocol || currentPort.Port != desiredPort.Port ||
+			currentPort.TargetPort != desiredPort.TargetPort || currentPort.NodePort != desiredPort.NodePort {
+			drifts = append(drifts, "ports")
+		}
+	}
+
+	return strings.Join(drifts, ", ")
+}
+
 func (r *KubernetesServiceResource) GetName() string {
 	return "service"
 }
+
+// serviceAnnotations merges the desired annotations with the current ones, such as the ones set by the cloud providers,
+// dropping the previously applied annotations which are no more desired: the Kamaji-managed ones win on conflict.
+func serviceAnnotations(current, desired map[string]string) map[string]string {
+	annotations := make(map[string]string, len(current)+len(desired))
+
+	for key, value := range current {
+		annotations[key] = value
+	}
+
+	if managed, ok := annotations[constants.ManagedAnnotations]; ok && len(managed) > 0 {
+		for _, key := range strings.Split(managed, ",") {
+			delete(annotations, key)
+		}
+	}
+
+	keys := make([]string, 0, len(desired))
+
+	for key, value := range desired {
+		if key == constants.ManagedAnnotations {
+			continue
+		}
+
+		annotations[key] = value
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	if len(keys) == 0 {
+		delete(annotations, constants.ManagedAnnotations)
+
+		return annotations
+	}
+
+	annotations[constants.ManagedAnnotations] = strings.Join(keys, ",")
+
+	return annotations
+}
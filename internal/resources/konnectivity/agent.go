@@ -126,15 +126,15 @@ func (r *Agent) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 		))
 
 		r.resource.Spec.Template.Spec.PriorityClassName = "system-cluster-critical"
-		r.resource.Spec.Template.Spec.Tolerations = []corev1.Toleration{
+		r.resource.Spec.Template.Spec.Tolerations = append([]corev1.Toleration{
 			{
 				Key:      "CriticalAddonsOnly",
 				Operator: "Exists",
 			},
-		}
-		r.resource.Spec.Template.Spec.NodeSelector = map[string]string{
+		}, tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Tolerations...)
+		r.resource.Spec.Template.Spec.NodeSelector = utilities.MergeMaps(map[string]string{
 			"kubernetes.io/os": "linux",
-		}
+		}, tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.NodeSelector)
 		r.resource.Spec.Template.Spec.ServiceAccountName = AgentName
 		r.resource.Spec.Template.Spec.Volumes = []corev1.Volume{
 			{
@@ -108,6 +108,10 @@ func (r *KubeadmConfigResource) mutate(ctx context.Context, tenantControlPlane *
 		if err != nil {
 			return err
 		}
+		// The kubeadm configuration supports the algorithm types only, with no key size: the private keys are generated by Kamaji.
+		// RSA 2048 keys are the kubeadm default, thus the configuration is left untouched, preventing the regeneration
+		// of the certificates and kubeconfig files of the existing Tenant Control Planes.
+		config.InitConfiguration.EncryptionAlgorithm = kubeadm.EncryptionAlgorithm(string(tenantControlPlane.CertificatesKeyAlgorithm()))
 		if r.resource.Data, err = kubeadm.GetKubeadmInitConfigurationMap(*config); err != nil {
 			logger.Error(err, "cannot retrieve kubeadm init configuration")
 
@@ -140,7 +140,7 @@ func (r *KubeadmPhase) GetKubeadmFunction(ctx context.Context, tcp *kamajiv1alph
 				return nil, err
 			}
 
-			defer func() { _ = os.Remove(tmp) }()
+			defer func() { _ = os.RemoveAll(tmp) }()
 
 			var caSecret corev1.Secret
 
@@ -161,7 +161,7 @@ func (r *KubeadmPhase) GetKubeadmFunction(ctx context.Context, tcp *kamajiv1alph
 					return nil, err
 				}
 
-				_ = os.WriteFile(fmt.Sprintf("%s/%s", tmp, i), kubeconfigValue, os.ModePerm)
+				_ = os.WriteFile(fmt.Sprintf("%s/%s", tmp, i), kubeconfigValue, os.FileMode(0o600))
 			}
 
 			if _, err = kubeconfig.EnsureAdminClusterRoleBinding(tmp, nil); err != nil {
@@ -60,6 +60,12 @@ func (k *KubernetesUpgrade) CreateOrUpdate(ctx context.Context, tenantControlPla
 
 		return controllerutil.OperationResultNone, nil
 	}
+	// The Tenant Control Plane API Server is not running, the upgrade is performed once resumed
+	if tenantControlPlane.IsSuspended() {
+		k.inProgress = false
+
+		return controllerutil.OperationResultNone, nil
+	}
 	// An upgrade is in progress, let it go
 	if status := tenantControlPlane.Status.Kubernetes.Version.Status; status != nil && *status == kamajiv1alpha1.VersionUpgrading {
 		return controllerutil.OperationResultNone, nil
@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
 	"github.com/clastix/kamaji/internal/kubeadm"
 	"github.com/clastix/kamaji/internal/utilities"
 )
@@ -166,3 +167,11 @@ func KubeadmPhaseCreate(ctx context.Context, r KubeadmPhaseResource, logger logr
 
 	return controllerutil.OperationResultUpdated, nil
 }
+
+// hasDesiredKeyAlgorithm returns true if the given private key has been generated using
+// the key algorithm required by the Tenant Control Plane.
+func hasDesiredKeyAlgorithm(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, privateKey []byte) bool {
+	algorithm, err := crypto.PrivateKeyAlgorithm(privateKey)
+
+	return err == nil && algorithm == string(tenantControlPlane.CertificatesKeyAlgorithm())
+}
@@ -153,7 +153,23 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 			return err
 		}
 
+		clusterCABundle, err := r.getClusterCABundle(ctx, tenantControlPlane)
+		if err != nil {
+			logger.Error(err, "cannot retrieve the cluster CA bundle")
+
+			return err
+		}
+
 		checksum := r.checksum(caCertificatesSecret, config.Checksum())
+		// The kubeconfig files must be regenerated upon a cluster CA bundle change, to distribute it.
+		if len(clusterCABundle) > 0 {
+			checksum = utilities.CalculateMapChecksum(map[string][]byte{"checksum": []byte(checksum), "cluster-ca-bundle": clusterCABundle})
+		}
+		// The isolated components reach the API Server through the Service, rather than the localhost.
+		server := r.isolatedComponentServer(tenantControlPlane)
+		if len(server) > 0 {
+			checksum = utilities.CalculateMapChecksum(map[string]string{"checksum": checksum, "server": server})
+		}
 
 		status, err := r.getKubeconfigStatus(tenantControlPlane)
 		if err != nil {
@@ -206,6 +222,20 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 				return kcErr
 			}
 
+			if len(server) > 0 {
+				if kubeconfig, kcErr = kubeadm.SetKubeconfigServer(kubeconfig, server); kcErr != nil {
+					logger.Error(kcErr, "cannot set the API Server address of the kubeconfig")
+
+					return kcErr
+				}
+			}
+
+			if kubeconfig, kcErr = r.setClusterCABundle(kubeconfig, clusterCABundle); kcErr != nil {
+				logger.Error(kcErr, "cannot set the cluster CA bundle of the kubeconfig")
+
+				return kcErr
+			}
+
 			r.resource.Data[r.KubeConfigFileName] = kubeconfig
 			// Adding a kubeconfig useful for the local connections:
 			// especially for the admin.conf and super-admin.conf, these would use the public IP address.
@@ -222,6 +252,12 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 					return kcErr
 				}
 
+				if kubeconfig, kcErr = r.setClusterCABundle(kubeconfig, clusterCABundle); kcErr != nil {
+					logger.Error(kcErr, "cannot set the cluster CA bundle of the kubeconfig")
+
+					return kcErr
+				}
+
 				r.resource.Data[key] = kubeconfig
 			}
 		}
@@ -241,8 +277,47 @@ func (r *KubeconfigResource) customizeConfig(config *kubeadm.Configuration) erro
 	}
 }
 
+// isolatedComponentServer returns the API Server address to use for the kubeconfig of the isolated components,
+// or an empty string if the component is running along with the API Server.
+func (r *KubeconfigResource) isolatedComponentServer(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) string {
+	if !tenantControlPlane.Spec.ControlPlane.Deployment.ComponentIsolation {
+		return ""
+	}
+
+	switch r.KubeConfigFileName {
+	case kubeadmconstants.ControllerManagerKubeConfigFileName, kubeadmconstants.SchedulerKubeConfigFileName:
+		return fmt.Sprintf("https://%s.%s.svc:%d", tenantControlPlane.GetName(), tenantControlPlane.GetNamespace(), tenantControlPlane.Spec.NetworkProfile.Port)
+	default:
+		return ""
+	}
+}
+
 func (r *KubeconfigResource) localhostAsAdvertiseAddress(config *kubeadm.Configuration) error {
 	config.InitConfiguration.LocalAPIEndpoint.AdvertiseAddress = localhost
 
 	return nil
 }
+
+// getClusterCABundle returns the cluster CA bundle, once reconciled, used as certificate authority of the kubeconfig files.
+func (r *KubeconfigResource) getClusterCABundle(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) ([]byte, error) {
+	bundle := tenantControlPlane.Status.Certificates.ClusterCABundle
+	if bundle == nil {
+		return nil, nil
+	}
+
+	var secret corev1.Secret
+	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: bundle.SecretName}, &secret); err != nil {
+		return nil, err
+	}
+
+	return secret.Data[constants.ClusterCABundleName], nil
+}
+
+// setClusterCABundle replaces the certificate authority of the given kubeconfig with the cluster CA bundle, if any.
+func (r *KubeconfigResource) setClusterCABundle(kubeconfig []byte, clusterCABundle []byte) ([]byte, error) {
+	if len(clusterCABundle) == 0 {
+		return kubeconfig, nil
+	}
+
+	return kubeadm.SetKubeconfigCertificateAuthority(kubeconfig, clusterCABundle)
+}
@@ -0,0 +1,21 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package monitoring
+
+import (
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+const (
+	ServiceAccountName      = "kamaji-metrics"
+	ServiceAccountNamespace = core.NamespaceSystem
+	MetricsPortName         = "metrics"
+
+	clusterRoleName               = "kamaji:metrics-reader"
+	metricsServiceSuffix          = "metrics"
+	tenantTokenSecretName         = "kamaji-metrics-token"
+	tokenSecretCertificateAuthKey = "ca.crt"
+	tokenSecretKey                = "token"
+	tokenSecretSuffix             = "metrics-token"
+)
@@ -0,0 +1,136 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package monitoring
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// MetricsServiceResource exposes the kube-apiserver metrics with a Service dedicated to the scraping traffic.
+type MetricsServiceResource struct {
+	Client client.Client
+
+	resource *corev1.Service
+}
+
+func (r *MetricsServiceResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if !isEnabled(tenantControlPlane) {
+		return false
+	}
+
+	if tenantControlPlane.Status.Monitoring == nil {
+		return true
+	}
+
+	status := tenantControlPlane.Status.Monitoring.MetricsService
+
+	return status.Name != r.resource.GetName() || status.Namespace != r.resource.GetNamespace() || status.Port != r.resource.Spec.Ports[0].Port
+}
+
+func (r *MetricsServiceResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !isEnabled(tenantControlPlane) && tenantControlPlane.Status.Monitoring != nil && len(tenantControlPlane.Status.Monitoring.MetricsService.Name) > 0
+}
+
+func (r *MetricsServiceResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil && !k8serrors.IsNotFound(err) {
+		logger.Error(err, "cannot delete the requested resource")
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *MetricsServiceResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(metricsServiceSuffix, tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *MetricsServiceResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !isEnabled(tenantControlPlane) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(tenantControlPlane))
+}
+
+func (r *MetricsServiceResource) GetName() string {
+	return "metrics-service"
+}
+
+func (r *MetricsServiceResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if isEnabled(tenantControlPlane) {
+		if tenantControlPlane.Status.Monitoring == nil {
+			tenantControlPlane.Status.Monitoring = &kamajiv1alpha1.MonitoringStatus{}
+		}
+
+		tenantControlPlane.Status.Monitoring.MetricsService = kamajiv1alpha1.KubernetesServiceStatus{
+			ServiceStatus: r.resource.Status,
+			Name:          r.resource.GetName(),
+			Namespace:     r.resource.GetNamespace(),
+			Port:          r.resource.Spec.Ports[0].Port,
+		}
+		tenantControlPlane.Status.Monitoring.LastUpdate = metav1.Now()
+
+		return nil
+	}
+
+	if tenantControlPlane.Status.Monitoring != nil {
+		tenantControlPlane.Status.Monitoring.MetricsService = kamajiv1alpha1.KubernetesServiceStatus{}
+		// Dropping the status once all the monitoring resources have been removed.
+		if len(tenantControlPlane.Status.Monitoring.TokenSecretName) == 0 {
+			tenantControlPlane.Status.Monitoring = nil
+		}
+	}
+
+	return nil
+}
+
+func (r *MetricsServiceResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		metadata := tenantControlPlane.Spec.ControlPlane.Monitoring.MetricsService.AdditionalMetadata
+
+		r.resource.SetLabels(utilities.MergeMaps(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()), metadata.Labels))
+		r.resource.SetAnnotations(utilities.MergeMaps(r.resource.GetAnnotations(), metadata.Annotations))
+
+		r.resource.Spec.Type = corev1.ServiceTypeClusterIP
+		r.resource.Spec.Selector = map[string]string{
+			"kamaji.clastix.io/name": tenantControlPlane.GetName(),
+		}
+
+		if len(r.resource.Spec.Ports) == 0 {
+			r.resource.Spec.Ports = make([]corev1.ServicePort, 1)
+		}
+
+		r.resource.Spec.Ports[0].Name = MetricsPortName
+		r.resource.Spec.Ports[0].Protocol = corev1.ProtocolTCP
+		r.resource.Spec.Ports[0].Port = tenantControlPlane.Spec.NetworkProfile.Port
+		r.resource.Spec.Ports[0].TargetPort = intstr.FromInt(int(tenantControlPlane.Spec.NetworkProfile.Port))
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
+
+func isEnabled(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.ControlPlane.Monitoring != nil && tenantControlPlane.Spec.ControlPlane.Monitoring.MetricsService != nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package monitoring
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/resources/utils"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// RBACResource creates the read-only ServiceAccount used to scrape the kube-apiserver metrics,
+// bound to a ClusterRole allowing only the metrics endpoint.
+type RBACResource struct {
+	Client client.Client
+
+	serviceAccount     *corev1.ServiceAccount
+	clusterRole        *rbacv1.ClusterRole
+	clusterRoleBinding *rbacv1.ClusterRoleBinding
+	tenantClient       client.Client
+}
+
+func (r *RBACResource) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *RBACResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !isEnabled(tenantControlPlane) && tenantControlPlane.Status.Monitoring != nil
+}
+
+func (r *RBACResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	var deleted bool
+
+	for _, obj := range []client.Object{r.clusterRoleBinding, r.clusterRole, r.serviceAccount} {
+		if err := r.tenantClient.Delete(ctx, obj); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			logger.Error(err, "cannot delete the requested resource")
+
+			return false, err
+		}
+
+		deleted = true
+	}
+
+	return deleted, nil
+}
+
+func (r *RBACResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (err error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	r.serviceAccount = &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ServiceAccountName,
+			Namespace: ServiceAccountNamespace,
+		},
+	}
+	r.clusterRole = &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterRoleName,
+		},
+	}
+	r.clusterRoleBinding = &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterRoleName,
+		},
+	}
+
+	if r.tenantClient, err = utilities.GetTenantClient(ctx, r.Client, tenantControlPlane); err != nil {
+		logger.Error(err, "cannot generate tenant client")
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *RBACResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !isEnabled(tenantControlPlane) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	labels := utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())
+
+	result := controllerutil.OperationResultNone
+
+	res, err := controllerutil.CreateOrUpdate(ctx, r.tenantClient, r.serviceAccount, func() error {
+		r.serviceAccount.SetLabels(labels)
+
+		return nil
+	})
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	result = utils.UpdateOperationResult(result, res)
+
+	res, err = controllerutil.CreateOrUpdate(ctx, r.tenantClient, r.clusterRole, func() error {
+		r.clusterRole.SetLabels(labels)
+		r.clusterRole.Rules = []rbacv1.PolicyRule{
+			{
+				NonResourceURLs: []string{"/metrics"},
+				Verbs:           []string{"get"},
+			},
+		}
+
+		return nil
+	})
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	result = utils.UpdateOperationResult(result, res)
+
+	res, err = controllerutil.CreateOrUpdate(ctx, r.tenantClient, r.clusterRoleBinding, func() error {
+		r.clusterRoleBinding.SetLabels(labels)
+		r.clusterRoleBinding.RoleRef = rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     r.clusterRole.GetName(),
+		}
+		r.clusterRoleBinding.Subjects = []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      r.serviceAccount.GetName(),
+				Namespace: r.serviceAccount.GetNamespace(),
+			},
+		}
+
+		return nil
+	})
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	return utils.UpdateOperationResult(result, res), nil
+}
+
+func (r *RBACResource) GetName() string {
+	return "metrics-rbac"
+}
+
+func (r *RBACResource) UpdateTenantControlPlaneStatus(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
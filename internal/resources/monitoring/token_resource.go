@@ -0,0 +1,158 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package monitoring
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// TokenResource issues the bearer token of the metrics ServiceAccount using a service account token Secret
+// in the Tenant Control Plane: the token is long-lived, and copied in the Tenant Control Plane namespace
+// to be consumed by the scraping tools running in the admin cluster.
+type TokenResource struct {
+	Client client.Client
+
+	tenantResource *corev1.Secret
+	resource       *corev1.Secret
+	tenantClient   client.Client
+	issued         bool
+}
+
+func (r *TokenResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if !isEnabled(tenantControlPlane) || !r.issued {
+		return false
+	}
+
+	return tenantControlPlane.Status.Monitoring == nil || tenantControlPlane.Status.Monitoring.TokenSecretName != r.resource.GetName()
+}
+
+func (r *TokenResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !isEnabled(tenantControlPlane) && tenantControlPlane.Status.Monitoring != nil && len(tenantControlPlane.Status.Monitoring.TokenSecretName) > 0
+}
+
+func (r *TokenResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.tenantClient.Delete(ctx, r.tenantResource); err != nil && !k8serrors.IsNotFound(err) {
+		logger.Error(err, "cannot delete the tenant token Secret")
+
+		return false, err
+	}
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil && !k8serrors.IsNotFound(err) {
+		logger.Error(err, "cannot delete the token Secret")
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *TokenResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (err error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	r.tenantResource = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tenantTokenSecretName,
+			Namespace: ServiceAccountNamespace,
+		},
+	}
+	r.resource = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(tokenSecretSuffix, tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	if r.tenantClient, err = utilities.GetTenantClient(ctx, r.Client, tenantControlPlane); err != nil {
+		logger.Error(err, "cannot generate tenant client")
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *TokenResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if !isEnabled(tenantControlPlane) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.tenantClient, r.tenantResource, func() error {
+		r.tenantResource.SetLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()))
+		r.tenantResource.SetAnnotations(utilities.MergeMaps(r.tenantResource.GetAnnotations(), map[string]string{
+			corev1.ServiceAccountNameKey: ServiceAccountName,
+		}))
+		r.tenantResource.Type = corev1.SecretTypeServiceAccountToken
+
+		return nil
+	}); err != nil {
+		logger.Error(err, "cannot create the tenant token Secret")
+
+		return controllerutil.OperationResultNone, err
+	}
+	// The token is populated asynchronously by the Tenant Control Plane token controller:
+	// the Secret update will trigger back the reconciliation.
+	if len(r.tenantResource.Data[corev1.ServiceAccountTokenKey]) == 0 {
+		logger.Info("token not yet issued, waiting for the tenant token controller")
+
+		return controllerutil.OperationResultNone, nil
+	}
+
+	r.issued = true
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, func() error {
+		r.resource.SetLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()))
+		r.resource.Type = corev1.SecretTypeOpaque
+		r.resource.Data = map[string][]byte{
+			tokenSecretKey:                r.tenantResource.Data[corev1.ServiceAccountTokenKey],
+			tokenSecretCertificateAuthKey: r.tenantResource.Data[corev1.ServiceAccountRootCAKey],
+		}
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	})
+}
+
+func (r *TokenResource) GetName() string {
+	return "metrics-token"
+}
+
+func (r *TokenResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if isEnabled(tenantControlPlane) {
+		if !r.issued {
+			return nil
+		}
+
+		if tenantControlPlane.Status.Monitoring == nil {
+			tenantControlPlane.Status.Monitoring = &kamajiv1alpha1.MonitoringStatus{}
+		}
+
+		tenantControlPlane.Status.Monitoring.TokenSecretName = r.resource.GetName()
+		tenantControlPlane.Status.Monitoring.LastUpdate = metav1.Now()
+
+		return nil
+	}
+
+	if tenantControlPlane.Status.Monitoring != nil {
+		tenantControlPlane.Status.Monitoring.TokenSecretName = ""
+		// Dropping the status once all the monitoring resources have been removed.
+		if len(tenantControlPlane.Status.Monitoring.MetricsService.Name) == 0 {
+			tenantControlPlane.Status.Monitoring = nil
+		}
+	}
+
+	return nil
+}
@@ -5,7 +5,10 @@ package resources
 
 import (
 	"context"
+	"fmt"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	clientset "k8s.io/client-go/kubernetes"
@@ -14,6 +17,7 @@ import (
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/tracing"
 )
 
 const (
@@ -62,7 +66,20 @@ func Handle(ctx context.Context, resource Resource, tenantControlPlane *kamajiv1
 	if err := resource.Define(ctx, tenantControlPlane); err != nil {
 		return "", err
 	}
+	// The resource name could be computed upon its definition.
+	ctx, span := tracing.Tracer().Start(ctx, fmt.Sprintf("resource/%s", resource.GetName()), trace.WithAttributes(
+		attribute.String("tenantcontrolplane.namespace", tenantControlPlane.GetNamespace()),
+		attribute.String("tenantcontrolplane.name", tenantControlPlane.GetName()),
+	))
 
+	result, err := handle(ctx, resource, tenantControlPlane)
+	span.SetAttributes(attribute.String("result", string(result)))
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func handle(ctx context.Context, resource Resource, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
 	if !resource.ShouldCleanup(tenantControlPlane) {
 		return createOrUpdate(ctx, resource, tenantControlPlane)
 	}
@@ -116,6 +133,8 @@ func getStoredKubeadmConfiguration(ctx context.Context, client client.Client, tm
 	if len(tmpDirectory) > 0 {
 		config.InitConfiguration.ClusterConfiguration.CertificatesDir = tmpDirectory
 	}
+	// The key algorithm is not stored along with the kubeadm configuration, since kubeadm doesn't support its sizes.
+	config.KeyAlgorithm = string(tenantControlPlane.CertificatesKeyAlgorithm())
 
 	return config, nil
 }
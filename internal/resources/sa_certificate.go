@@ -6,8 +6,10 @@ package resources
 import (
 	"context"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -16,21 +18,28 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/crypto"
 	"github.com/clastix/kamaji/internal/kubeadm"
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
+// defaultRotationOverlap is used when the rotation overlap is not specified, such as with the defaulting disabled.
+const defaultRotationOverlap = 24 * time.Hour
+
 type SACertificate struct {
 	resource     *corev1.Secret
 	Client       client.Client
 	Name         string
 	TmpDirectory string
+
+	rotation *kamajiv1alpha1.KeyRotationStatus
 }
 
 func (r *SACertificate) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
 	return tenantControlPlane.Status.Certificates.SA.SecretName != r.resource.GetName() ||
-		tenantControlPlane.Status.Certificates.SA.Checksum != utilities.GetObjectChecksum(r.resource)
+		tenantControlPlane.Status.Certificates.SA.Checksum != utilities.GetObjectChecksum(r.resource) ||
+		!apiequality.Semantic.DeepEqual(tenantControlPlane.Status.Certificates.SA.Rotation, r.rotation)
 }
 
 func (r *SACertificate) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -65,7 +74,16 @@ func (r *SACertificate) GetTmpDirectory() string {
 }
 
 func (r *SACertificate) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
-	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+	res, err := utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+	if err != nil {
+		return res, err
+	}
+	// The rotation phase transitions must be reflected in the status, even with no changes to the Secret.
+	if res == controllerutil.OperationResultNone && r.ShouldStatusBeUpdated(ctx, tenantControlPlane) {
+		return controllerutil.OperationResultUpdated, nil
+	}
+
+	return res, nil
 }
 
 func (r *SACertificate) GetName() string {
@@ -76,6 +94,7 @@ func (r *SACertificate) UpdateTenantControlPlaneStatus(_ context.Context, tenant
 	tenantControlPlane.Status.Certificates.SA.LastUpdate = metav1.Now()
 	tenantControlPlane.Status.Certificates.SA.SecretName = r.resource.GetName()
 	tenantControlPlane.Status.Certificates.SA.Checksum = utilities.GetObjectChecksum(r.resource)
+	tenantControlPlane.Status.Certificates.SA.Rotation = r.rotation
 
 	return nil
 }
@@ -84,13 +103,15 @@ func (r *SACertificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1
 	return func() error {
 		logger := log.FromContext(ctx, "resource", r.GetName())
 
+		r.rotation = tenantControlPlane.Status.Certificates.SA.Rotation.DeepCopy()
+
 		if checksum := tenantControlPlane.Status.Certificates.SA.Checksum; len(checksum) > 0 && checksum == utilities.GetObjectChecksum(r.resource) || len(r.resource.UID) > 0 {
 			isValid, err := crypto.CheckPublicAndPrivateKeyValidity(r.resource.Data[kubeadmconstants.ServiceAccountPublicKeyName], r.resource.Data[kubeadmconstants.ServiceAccountPrivateKeyName])
 			if err != nil {
 				logger.Info(fmt.Sprintf("%s public_key-private_key pair is not valid: %s", kubeadmconstants.ServiceAccountKeyBaseName, err.Error()))
 			}
 			if isValid {
-				return nil
+				return r.rotate(ctx, tenantControlPlane)
 			}
 		}
 
@@ -112,6 +133,10 @@ func (r *SACertificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1
 			kubeadmconstants.ServiceAccountPublicKeyName:  sa.PublicKey,
 			kubeadmconstants.ServiceAccountPrivateKeyName: sa.PrivateKey,
 		}
+		// A freshly generated key pair has no previous tokens to verify, thus the rotation is already completed.
+		if trigger := rotationTrigger(tenantControlPlane); len(trigger) > 0 {
+			r.rotation = &kamajiv1alpha1.KeyRotationStatus{Trigger: trigger, Phase: kamajiv1alpha1.KeyRotationPhaseCompleted, StartTime: metav1.Now()}
+		}
 
 		r.resource.SetLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()))
 
@@ -120,3 +145,75 @@ func (r *SACertificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1
 		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
 	}
 }
+
+// rotate generates a new signing key upon a rotation trigger change, keeping the previous public key
+// in the verification set until the overlap is expired.
+func (r *SACertificate) rotate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if trigger := rotationTrigger(tenantControlPlane); len(trigger) > 0 && (r.rotation == nil || r.rotation.Trigger != trigger) {
+		logger.Info("rotating the Service Account signing key", "trigger", trigger)
+
+		config, err := getStoredKubeadmConfiguration(ctx, r.Client, r.TmpDirectory, tenantControlPlane)
+		if err != nil {
+			logger.Error(err, "cannot retrieve kubadm configuration")
+
+			return err
+		}
+
+		sa, err := kubeadm.GeneratePublicKeyPrivateKeyPair(kubeadmconstants.ServiceAccountKeyBaseName, config)
+		if err != nil {
+			logger.Error(err, "cannot generate certificate and private key")
+
+			return err
+		}
+
+		previous := r.resource.Data[kubeadmconstants.ServiceAccountPublicKeyName]
+
+		r.resource.Data = map[string][]byte{
+			kubeadmconstants.ServiceAccountPublicKeyName:  sa.PublicKey,
+			kubeadmconstants.ServiceAccountPrivateKeyName: sa.PrivateKey,
+			constants.ServiceAccountVerificationKeysName:  append(append([]byte{}, sa.PublicKey...), previous...),
+		}
+		r.rotation = &kamajiv1alpha1.KeyRotationStatus{Trigger: trigger, Phase: kamajiv1alpha1.KeyRotationPhaseOverlapping, StartTime: metav1.Now()}
+
+		utilities.SetObjectChecksum(r.resource, r.resource.Data)
+
+		return nil
+	}
+
+	if remaining := ServiceAccountRotationRemainingOverlap(tenantControlPlane); r.rotation != nil && r.rotation.Phase == kamajiv1alpha1.KeyRotationPhaseOverlapping && remaining <= 0 {
+		logger.Info("Service Account signing key rotation overlap expired, removing the previous public key")
+
+		delete(r.resource.Data, constants.ServiceAccountVerificationKeysName)
+		r.rotation.Phase = kamajiv1alpha1.KeyRotationPhaseCompleted
+
+		utilities.SetObjectChecksum(r.resource, r.resource.Data)
+	}
+
+	return nil
+}
+
+func rotationTrigger(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) string {
+	if apiServer := tenantControlPlane.Spec.ControlPlane.APIServer; apiServer != nil && apiServer.ServiceAccount != nil {
+		return apiServer.ServiceAccount.RotateSigningKey
+	}
+
+	return ""
+}
+
+// ServiceAccountRotationRemainingOverlap returns the time left before the previous Service Account public key
+// is removed from the verification set, or zero if no rotation is overlapping.
+func ServiceAccountRotationRemainingOverlap(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) time.Duration {
+	rotation := tenantControlPlane.Status.Certificates.SA.Rotation
+	if rotation == nil || rotation.Phase != kamajiv1alpha1.KeyRotationPhaseOverlapping {
+		return 0
+	}
+
+	overlap := defaultRotationOverlap
+	if apiServer := tenantControlPlane.Spec.ControlPlane.APIServer; apiServer != nil && apiServer.ServiceAccount != nil && apiServer.ServiceAccount.RotationOverlap != nil {
+		overlap = apiServer.ServiceAccount.RotationOverlap.Duration
+	}
+
+	return time.Until(rotation.StartTime.Add(overlap))
+}
@@ -0,0 +1,150 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/kubeadm"
+)
+
+func TestSACertificateRotation(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := kubeadm.CreateKubeadmInitConfiguration(kubeadm.Parameters{
+		TenantControlPlaneName:        "tcp",
+		TenantControlPlaneNamespace:   "default",
+		TenantControlPlaneAddress:     "10.0.0.1",
+		TenantControlPlanePort:        6443,
+		TenantControlPlaneEndpoint:    "10.0.0.1:6443",
+		TenantControlPlanePodCIDR:     "10.244.0.0/16",
+		TenantControlPlaneServiceCIDR: "10.96.0.0/16",
+		TenantControlPlaneVersion:     "v1.29.1",
+		ETCDs:                         []string{"https://etcd:2379"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := kubeadm.GetKubeadmInitConfigurationMap(*config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tcp-kubeadmconfig"}, Data: data}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tcp", UID: "uid"}}
+	tcp.Status.KubeadmConfig.ConfigmapName = configMap.GetName()
+	tcp.Spec.ControlPlane.APIServer = &kamajiv1alpha1.APIServerSpec{
+		ServiceAccount: &kamajiv1alpha1.ServiceAccountSpec{
+			RotateSigningKey: "first",
+			RotationOverlap:  &metav1.Duration{Duration: time.Hour},
+		},
+	}
+
+	r := &SACertificate{
+		Client:       fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap).Build(),
+		TmpDirectory: t.TempDir(),
+	}
+
+	reconcileSA := func(t *testing.T) *corev1.Secret {
+		t.Helper()
+
+		if err := r.Define(ctx, tcp); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := r.CreateOrUpdate(ctx, tcp); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := r.UpdateTenantControlPlaneStatus(ctx, tcp); err != nil {
+			t.Fatal(err)
+		}
+
+		return r.resource.DeepCopy()
+	}
+
+	expectPhase := func(t *testing.T, trigger string, phase kamajiv1alpha1.KeyRotationPhase) {
+		t.Helper()
+
+		rotation := tcp.Status.Certificates.SA.Rotation
+		if rotation == nil || rotation.Trigger != trigger || rotation.Phase != phase {
+			t.Fatalf("expected the rotation %s in the %s phase, got %+v", trigger, phase, rotation)
+		}
+	}
+
+	// A freshly generated key pair has no previous tokens to verify.
+	fresh := reconcileSA(t)
+	expectPhase(t, "first", kamajiv1alpha1.KeyRotationPhaseCompleted)
+
+	if _, ok := fresh.Data[constants.ServiceAccountVerificationKeysName]; ok {
+		t.Fatal("expected no verification keys for a freshly generated key pair")
+	}
+
+	// Changing the trigger generates a new key pair, verifying the tokens with both the public keys.
+	tcp.Spec.ControlPlane.APIServer.ServiceAccount.RotateSigningKey = "second"
+
+	rotated := reconcileSA(t)
+	expectPhase(t, "second", kamajiv1alpha1.KeyRotationPhaseOverlapping)
+
+	previousKey, currentKey := fresh.Data[kubeadmconstants.ServiceAccountPublicKeyName], rotated.Data[kubeadmconstants.ServiceAccountPublicKeyName]
+	if bytes.Equal(previousKey, currentKey) {
+		t.Fatal("expected the signing key to be rotated")
+	}
+
+	if want := append(append([]byte{}, currentKey...), previousKey...); !bytes.Equal(rotated.Data[constants.ServiceAccountVerificationKeysName], want) {
+		t.Fatal("expected the verification keys to contain both the current, and the previous public keys")
+	}
+
+	if remaining := ServiceAccountRotationRemainingOverlap(tcp); remaining <= 0 || remaining > time.Hour {
+		t.Fatalf("expected the overlap to be pending, got %s", remaining)
+	}
+
+	// Within the overlap, the verification keys are retained.
+	if overlapping := reconcileSA(t); !bytes.Equal(overlapping.Data[constants.ServiceAccountVerificationKeysName], rotated.Data[constants.ServiceAccountVerificationKeysName]) {
+		t.Fatal("expected the verification keys to be retained within the overlap")
+	}
+
+	expectPhase(t, "second", kamajiv1alpha1.KeyRotationPhaseOverlapping)
+
+	// Once the overlap is expired, the previous public key is removed, retaining the current key pair.
+	tcp.Status.Certificates.SA.Rotation.StartTime = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+
+	if remaining := ServiceAccountRotationRemainingOverlap(tcp); remaining > 0 {
+		t.Fatalf("expected the overlap to be expired, got %s", remaining)
+	}
+
+	completed := reconcileSA(t)
+	expectPhase(t, "second", kamajiv1alpha1.KeyRotationPhaseCompleted)
+
+	if _, ok := completed.Data[constants.ServiceAccountVerificationKeysName]; ok {
+		t.Fatal("expected the verification keys to be removed once the overlap is expired")
+	}
+
+	if !bytes.Equal(completed.Data[kubeadmconstants.ServiceAccountPublicKeyName], currentKey) {
+		t.Fatal("expected the current signing key to be retained")
+	}
+}
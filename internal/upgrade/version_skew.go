@@ -0,0 +1,71 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	versionutil "k8s.io/apimachinery/pkg/util/version"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// VersionSkewError is returned when the version of a Control Plane component
+// doesn't comply with the Kubernetes version skew policy: https://kubernetes.io/releases/version-skew-policy/
+type VersionSkewError struct {
+	Component        string
+	Version          string
+	APIServerVersion string
+	Reason           string
+}
+
+func (v VersionSkewError) Error() string {
+	return fmt.Sprintf("%s version %s is %s the kube-apiserver version %s", v.Component, v.Version, v.Reason, v.APIServerVersion)
+}
+
+// CheckVersionSkew ensures the kube-controller-manager and kube-scheduler versions of the given Tenant Control Plane
+// are not newer than the kube-apiserver, and not older than one minor version.
+func CheckVersionSkew(tenantControlPlane kamajiv1alpha1.TenantControlPlane) error {
+	apiServer, err := versionutil.ParseSemantic(tenantControlPlane.Spec.Kubernetes.Version)
+	if err != nil {
+		return errors.Wrap(err, "unable to parse the kube-apiserver version")
+	}
+
+	components := []struct {
+		name    string
+		version string
+	}{
+		{name: "kube-controller-manager", version: tenantControlPlane.ControllerManagerVersion()},
+		{name: "kube-scheduler", version: tenantControlPlane.SchedulerVersion()},
+	}
+
+	for _, component := range components {
+		version, parseErr := versionutil.ParseSemantic(component.version)
+		if parseErr != nil {
+			return errors.Wrapf(parseErr, "unable to parse the %s version", component.name)
+		}
+
+		skewErr := VersionSkewError{
+			Component:        component.name,
+			Version:          component.version,
+			APIServerVersion: tenantControlPlane.Spec.Kubernetes.Version,
+		}
+
+		switch {
+		case version.Major() != apiServer.Major():
+			skewErr.Reason = "not matching the major version of"
+		case version.Minor() > apiServer.Minor():
+			skewErr.Reason = "newer than"
+		case apiServer.Minor()-version.Minor() > 1:
+			skewErr.Reason = "more than one minor version older than"
+		default:
+			continue
+		}
+
+		return skewErr
+	}
+
+	return nil
+}
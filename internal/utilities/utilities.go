@@ -111,3 +111,9 @@ func EncodeToJSON(o runtime.Object) ([]byte, error) {
 
 	return buf.Bytes(), nil
 }
+
+// SNICertificateKeyNames returns the Secret key names storing the certificate and private key of the
+// kube-apiserver SNI certificate at the given position.
+func SNICertificateKeyNames(index int) (certificate string, privateKey string) {
+	return fmt.Sprintf("sni-%d.crt", index), fmt.Sprintf("sni-%d.key", index)
+}
@@ -54,12 +54,34 @@ func (h handlersChainer) Handler(object runtime.Object, routeHandlers ...handler
 		}
 
 		var patches []jsonpatch.JsonPatchOperation
+		// The handlers warnings don't deny the request, they're collected and returned along with the response.
+		var warnings []string
+
+		isWarning := func(err error) bool {
+			var multiple handlers.AdmissionWarnings
+			if errors.As(err, &multiple) {
+				for _, warning := range multiple {
+					warnings = append(warnings, warning.Message)
+				}
+
+				return true
+			}
+
+			var warning handlers.AdmissionWarning
+			if !errors.As(err, &warning) {
+				return false
+			}
+
+			warnings = append(warnings, warning.Message)
+
+			return true
+		}
 
 		switch req.Operation {
 		case admissionv1.Create:
 			for _, routeHandler := range routeHandlers {
 				handlerPatches, err := fnInvoker(routeHandler.OnCreate)
-				if err != nil {
+				if err != nil && !isWarning(err) {
 					return admission.Denied(err.Error())
 				}
 
@@ -72,7 +94,7 @@ func (h handlersChainer) Handler(object runtime.Object, routeHandlers ...handler
 
 			for _, routeHandler := range routeHandlers {
 				handlerPatches, err := routeHandler.OnUpdate(decodedObj, oldDecodedObj)(ctx, req)
-				if err != nil {
+				if err != nil && !isWarning(err) {
 					return admission.Denied(err.Error())
 				}
 
@@ -81,7 +103,7 @@ func (h handlersChainer) Handler(object runtime.Object, routeHandlers ...handler
 		case admissionv1.Delete:
 			for _, routeHandler := range routeHandlers {
 				handlerPatches, err := fnInvoker(routeHandler.OnDelete)
-				if err != nil {
+				if err != nil && !isWarning(err) {
 					return admission.Denied(err.Error())
 				}
 
@@ -92,9 +114,9 @@ func (h handlersChainer) Handler(object runtime.Object, routeHandlers ...handler
 		}
 
 		if len(patches) > 0 {
-			return admission.Patched("patching required", patches...)
+			return admission.Patched("patching required", patches...).WithWarnings(warnings...)
 		}
 
-		return admission.Allowed(fmt.Sprintf("%s operation allowed", strings.ToLower(string(req.Operation))))
+		return admission.Allowed(fmt.Sprintf("%s operation allowed", strings.ToLower(string(req.Operation)))).WithWarnings(warnings...)
 	}
 }
@@ -6,6 +6,8 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/pkg/errors"
 	"gomodules.xyz/jsonpatch/v2"
@@ -22,6 +24,12 @@ import (
 
 type DataStoreValidation struct {
 	Client client.Client
+	// AllowedNamespaces restricts the namespaces of the Secrets referenced by the DataStores,
+	// such as the ones containing the credentials: when empty, any namespace is allowed.
+	AllowedNamespaces []string
+	// FileRoot is the directory the file paths referenced by the DataStores must be rooted at:
+	// when empty, the file paths are not allowed.
+	FileRoot string
 }
 
 func (d DataStoreValidation) OnCreate(object runtime.Object) AdmissionResponse {
@@ -62,6 +70,13 @@ func (d DataStoreValidation) OnUpdate(object runtime.Object, oldObj runtime.Obje
 }
 
 func (d DataStoreValidation) validate(ctx context.Context, ds kamajiv1alpha1.DataStore) error {
+	// The CRD enumeration is enforced by the API Server, the check guards the objects stored before its changes.
+	switch ds.Spec.Driver {
+	case kamajiv1alpha1.EtcdDriver, kamajiv1alpha1.KineMySQLDriver, kamajiv1alpha1.KinePostgreSQLDriver:
+	default:
+		return fmt.Errorf("the driver %q is not supported, must be one of %s, %s, or %s", ds.Spec.Driver, kamajiv1alpha1.EtcdDriver, kamajiv1alpha1.KineMySQLDriver, kamajiv1alpha1.KinePostgreSQLDriver)
+	}
+
 	if ds.Spec.BasicAuth != nil {
 		if err := d.validateBasicAuth(ctx, ds); err != nil {
 			return err
@@ -84,6 +99,12 @@ func (d DataStoreValidation) validateBasicAuth(ctx context.Context, ds kamajiv1a
 }
 
 func (d DataStoreValidation) validateTLSConfig(ctx context.Context, ds kamajiv1alpha1.DataStore) error {
+	// TLS cannot be disabled, neither for the SQL drivers, since Kine is configured with the CA and the client certificate:
+	// the missing CA is reported explicitly, rather than as an invalid content reference.
+	if ca := ds.Spec.TLSConfig.CertificateAuthority.Certificate; len(ca.Content) == 0 && ca.SecretRef == nil && len(ca.FilePath) == 0 {
+		return fmt.Errorf("the CA certificate is required, since the %s DataStores are connected using TLS only", ds.Spec.Driver)
+	}
+
 	if err := d.validateContentReference(ctx, ds.Spec.TLSConfig.CertificateAuthority.Certificate); err != nil {
 		return fmt.Errorf("CA certificate is not valid, %w", err)
 	}
@@ -108,6 +129,16 @@ func (d DataStoreValidation) validateTLSConfig(ctx context.Context, ds kamajiv1a
 		return fmt.Errorf("client private key is not valid, %w", err)
 	}
 
+	if next := ds.Spec.TLSConfig.ClientCertificate.Next; next != nil {
+		if err := d.validateContentReference(ctx, next.Certificate); err != nil {
+			return fmt.Errorf("next client certificate is not valid, %w", err)
+		}
+
+		if err := d.validateContentReference(ctx, next.PrivateKey); err != nil {
+			return fmt.Errorf("next client private key is not valid, %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -115,12 +146,19 @@ func (d DataStoreValidation) validateContentReference(ctx context.Context, ref k
 	switch {
 	case len(ref.Content) > 0:
 		return nil
+	case len(ref.FilePath) > 0 && ref.SecretRef != nil:
+		return fmt.Errorf("the file path and the Secret reference are mutually exclusive")
+	case len(ref.FilePath) > 0:
+		// The file is read from the Kamaji Pod filesystem upon reconciliation, resolving the symbolic links.
+		return kamajiv1alpha1.CheckContentFilePath(d.FileRoot, ref.FilePath)
 	case ref.SecretRef == nil:
-		return fmt.Errorf("the Secret reference is mandatory when bare content is not specified")
+		return fmt.Errorf("the Secret reference, or the file path, is mandatory when bare content is not specified")
 	case len(ref.SecretRef.SecretReference.Name) == 0:
 		return fmt.Errorf("the Secret reference name is mandatory")
 	case len(ref.SecretRef.SecretReference.Namespace) == 0:
 		return fmt.Errorf("the Secret reference namespace is mandatory")
+	case len(d.AllowedNamespaces) > 0 && !slices.Contains(d.AllowedNamespaces, ref.SecretRef.SecretReference.Namespace):
+		return fmt.Errorf("the Secret reference namespace %s is not allowed, must be one of %s", ref.SecretRef.SecretReference.Namespace, strings.Join(d.AllowedNamespaces, ", "))
 	}
 
 	if err := d.Client.Get(ctx, types.NamespacedName{Name: ref.SecretRef.SecretReference.Name, Namespace: ref.SecretRef.SecretReference.Namespace}, &corev1.Secret{}); err != nil {
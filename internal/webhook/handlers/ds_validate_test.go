@@ -0,0 +1,113 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestDataStoreValidationFilePath(t *testing.T) {
+	dataStore := func(path string) kamajiv1alpha1.DataStore {
+		return kamajiv1alpha1.DataStore{
+			Spec: kamajiv1alpha1.DataStoreSpec{
+				Driver: kamajiv1alpha1.KineMySQLDriver,
+				TLSConfig: kamajiv1alpha1.TLSConfig{
+					CertificateAuthority: kamajiv1alpha1.CertKeyPair{
+						Certificate: kamajiv1alpha1.ContentRef{FilePath: path},
+					},
+					ClientCertificate: kamajiv1alpha1.ClientCertificate{
+						Certificate: kamajiv1alpha1.ContentRef{Content: []byte("client-crt")},
+						PrivateKey:  kamajiv1alpha1.ContentRef{Content: []byte("client-key")},
+					},
+				},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		root    string
+		path    string
+		wantErr bool
+	}{
+		{name: "rooted", root: "/etc/kamaji/datastores", path: "/etc/kamaji/datastores/ca.crt"},
+		{name: "nested", root: "/etc/kamaji/datastores", path: "/etc/kamaji/datastores/default/ca.crt"},
+		{name: "outside the root", root: "/etc/kamaji/datastores", path: "/var/run/secrets/kubernetes.io/serviceaccount/token", wantErr: true},
+		{name: "sibling prefix", root: "/etc/kamaji/datastores", path: "/etc/kamaji/datastores-other/ca.crt", wantErr: true},
+		{name: "non absolute path", root: "/etc/kamaji/datastores", path: "ca.crt", wantErr: true},
+		{name: "non clean path", root: "/etc/kamaji/datastores", path: "/etc/kamaji/datastores/../ca.crt", wantErr: true},
+		{name: "disabled", root: "", path: "/etc/kamaji/datastores/ca.crt", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := DataStoreValidation{FileRoot: tc.root}.validate(context.Background(), dataStore(tc.path))
+			if tc.wantErr && err == nil {
+				t.Fatal("expected the DataStore to be rejected")
+			}
+
+			if !tc.wantErr && err != nil {
+				t.Fatalf("expected the DataStore to be accepted, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDataStoreValidationDriver(t *testing.T) {
+	dataStore := func(driver kamajiv1alpha1.Driver) *kamajiv1alpha1.DataStore {
+		key := kamajiv1alpha1.ContentRef{Content: []byte("ca-key")}
+
+		return &kamajiv1alpha1.DataStore{
+			Spec: kamajiv1alpha1.DataStoreSpec{
+				Driver: driver,
+				TLSConfig: kamajiv1alpha1.TLSConfig{
+					CertificateAuthority: kamajiv1alpha1.CertKeyPair{
+						Certificate: kamajiv1alpha1.ContentRef{Content: []byte("ca-crt")},
+						PrivateKey:  &key,
+					},
+					ClientCertificate: kamajiv1alpha1.ClientCertificate{
+						Certificate: kamajiv1alpha1.ContentRef{Content: []byte("client-crt")},
+						PrivateKey:  kamajiv1alpha1.ContentRef{Content: []byte("client-key")},
+					},
+				},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		driver  kamajiv1alpha1.Driver
+		wantErr bool
+	}{
+		{name: "etcd", driver: kamajiv1alpha1.EtcdDriver},
+		{name: "MySQL", driver: kamajiv1alpha1.KineMySQLDriver},
+		{name: "PostgreSQL", driver: kamajiv1alpha1.KinePostgreSQLDriver},
+		{name: "unsupported", driver: "NATS", wantErr: true},
+		{name: "empty", driver: "", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := DataStoreValidation{}.OnCreate(dataStore(tc.driver))(context.Background(), admission.Request{})
+			if tc.wantErr && err == nil {
+				t.Fatal("expected the DataStore to be rejected")
+			}
+
+			if !tc.wantErr && err != nil {
+				t.Fatalf("expected the DataStore to be accepted, got %v", err)
+			}
+		})
+	}
+
+	t.Run("driver change", func(t *testing.T) {
+		if _, err := (DataStoreValidation{}).OnUpdate(dataStore(kamajiv1alpha1.KineMySQLDriver), dataStore(kamajiv1alpha1.EtcdDriver))(context.Background(), admission.Request{}); err == nil {
+			t.Fatal("expected the driver change to be rejected")
+		}
+
+		if _, err := (DataStoreValidation{}).OnUpdate(dataStore(kamajiv1alpha1.EtcdDriver), dataStore(kamajiv1alpha1.EtcdDriver))(context.Background(), admission.Request{}); err != nil {
+			t.Fatalf("expected the DataStore update to be accepted, got %v", err)
+		}
+	})
+}
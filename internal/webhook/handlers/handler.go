@@ -5,6 +5,7 @@ package handlers
 
 import (
 	"context"
+	"strings"
 
 	"gomodules.xyz/jsonpatch/v2"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -13,6 +14,27 @@ import (
 
 type AdmissionResponse func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error)
 
+// AdmissionWarning is returned by the handlers to allow the request, returning the message as an admission warning.
+type AdmissionWarning struct {
+	Message string
+}
+
+func (w AdmissionWarning) Error() string {
+	return w.Message
+}
+
+// AdmissionWarnings is returned by the handlers to allow the request, returning each message as an admission warning.
+type AdmissionWarnings []AdmissionWarning
+
+func (w AdmissionWarnings) Error() string {
+	messages := make([]string, 0, len(w))
+	for _, warning := range w {
+		messages = append(messages, warning.Message)
+	}
+
+	return strings.Join(messages, ", ")
+}
+
 type Handler interface {
 	OnCreate(runtime.Object) AdmissionResponse
 	OnDelete(runtime.Object) AdmissionResponse
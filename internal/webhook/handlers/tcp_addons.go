@@ -0,0 +1,99 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/resources/addons"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+type TenantControlPlaneAddons struct{}
+
+func (t TenantControlPlaneAddons) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validateAddons(tcp.Spec.Addons)
+	}
+}
+
+func (t TenantControlPlaneAddons) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneAddons) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validateAddons(tcp.Spec.Addons)
+	}
+}
+
+func (t TenantControlPlaneAddons) validateAddons(spec kamajiv1alpha1.AddonsSpec) error {
+	if cni := spec.CNI; cni != nil {
+		if (len(cni.Manifests) == 0) == (cni.ConfigMapRef == nil) {
+			return fmt.Errorf("the CNI addon requires either the inline manifests or a ConfigMap reference")
+		}
+
+		if cni.ConfigMapRef != nil && len(cni.ConfigMapRef.Name) == 0 {
+			return fmt.Errorf("the CNI addon ConfigMap reference requires a name")
+		}
+
+		if cni.ReplacesKubeProxy && spec.KubeProxy != nil {
+			return fmt.Errorf("the kube-proxy addon must be disabled when the CNI replaces it")
+		}
+	}
+
+	if defaults := spec.Defaults; defaults != nil {
+		if (len(defaults.Manifests) == 0) == (defaults.ConfigMapRef == nil) {
+			return fmt.Errorf("the defaults addon requires either the inline manifests or a ConfigMap reference")
+		}
+
+		if defaults.ConfigMapRef != nil && len(defaults.ConfigMapRef.Name) == 0 {
+			return fmt.Errorf("the defaults addon ConfigMap reference requires a name")
+		}
+		// The manifests from the ConfigMap are validated upon reconciliation.
+		if err := addons.ValidateDefaultsManifests(defaults.Manifests); err != nil {
+			return fmt.Errorf("the defaults addon manifests are not valid: %w", err)
+		}
+	}
+
+	if networkPolicies := spec.NetworkPolicies; networkPolicies != nil {
+		if (len(networkPolicies.Manifests) == 0) == (networkPolicies.ConfigMapRef == nil) {
+			return fmt.Errorf("the NetworkPolicies addon requires either the inline manifests or a ConfigMap reference")
+		}
+
+		if networkPolicies.ConfigMapRef != nil && len(networkPolicies.ConfigMapRef.Name) == 0 {
+			return fmt.Errorf("the NetworkPolicies addon ConfigMap reference requires a name")
+		}
+		// The manifests from the ConfigMap are validated upon reconciliation.
+		if err := addons.ValidateNetworkPoliciesManifests(networkPolicies.Manifests); err != nil {
+			return fmt.Errorf("the NetworkPolicies addon manifests are not valid: %w", err)
+		}
+	}
+
+	if webhooks := spec.Webhooks; webhooks != nil {
+		if (len(webhooks.Manifests) == 0) == (webhooks.ConfigMapRef == nil) {
+			return fmt.Errorf("the webhooks addon requires either the inline manifests or a ConfigMap reference")
+		}
+
+		if webhooks.ConfigMapRef != nil && len(webhooks.ConfigMapRef.Name) == 0 {
+			return fmt.Errorf("the webhooks addon ConfigMap reference requires a name")
+		}
+		// The manifests from the ConfigMap are validated upon reconciliation.
+		if err := addons.ValidateWebhooksManifests(webhooks.Manifests); err != nil {
+			return fmt.Errorf("the webhooks addon manifests are not valid: %w", err)
+		}
+	}
+
+	return nil
+}
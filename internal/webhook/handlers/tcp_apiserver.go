@@ -0,0 +1,340 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/resources/addons"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// mandatoryAdmissionControllers are the Admission Controllers required by Kamaji:
+// the ServiceAccount one is required by the addons workloads, the NamespaceLifecycle one protects their Namespaces.
+var mandatoryAdmissionControllers = kamajiv1alpha1.AdmissionControllers{"NamespaceLifecycle", "ServiceAccount"}
+
+// allowedOIDCSigningAlgs are the asymmetric signing algorithms supported by the kube-apiserver for the ID Tokens.
+var allowedOIDCSigningAlgs = sets.New[string]("RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512")
+
+// watchCacheResourceRegexp matches the resources of the --watch-cache-sizes flag, such as pods, or deployments.apps.
+var watchCacheResourceRegexp = regexp.MustCompile(`^[a-z0-9]+(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+type TenantControlPlaneAPIServer struct{}
+
+func (t TenantControlPlaneAPIServer) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp)
+	}
+}
+
+func (t TenantControlPlaneAPIServer) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneAPIServer) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp)
+	}
+}
+
+func (t TenantControlPlaneAPIServer) validate(tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if err := t.validateAPIServer(tcp.Spec.Kubernetes.Version, tcp.Spec.ControlPlane.APIServer); err != nil {
+		return err
+	}
+
+	if _, err := apiServerFeatureGates(tcp); err != nil {
+		return err
+	}
+
+	// The warnings are collected rather than returning the first one, since they're not denying the request.
+	var warnings AdmissionWarnings
+
+	for _, check := range []func(*kamajiv1alpha1.TenantControlPlane) error{t.checkEndpointReconcilerType, t.checkAllowPrivileged} {
+		err := check(tcp)
+
+		var warning AdmissionWarning
+
+		switch {
+		case err == nil:
+		case errors.As(err, &warning):
+			warnings = append(warnings, warning)
+		default:
+			return err
+		}
+	}
+
+	if len(warnings) > 0 {
+		return warnings
+	}
+
+	return nil
+}
+
+// checkEndpointReconcilerType warns when the kubernetes Service endpoints of the Tenant Cluster are not managed
+// by the API Server, or they could be inaccurate given the number of replicas.
+func (t TenantControlPlaneAPIServer) checkEndpointReconcilerType(tcp *kamajiv1alpha1.TenantControlPlane) error {
+	apiServer := tcp.Spec.ControlPlane.APIServer
+	if apiServer == nil {
+		return nil
+	}
+
+	switch apiServer.EndpointReconcilerType {
+	case kamajiv1alpha1.EndpointReconcilerTypeNone:
+		return AdmissionWarning{Message: "the endpoint reconciler is disabled, the kubernetes Service endpoints of the Tenant Cluster must be managed externally"}
+	case kamajiv1alpha1.EndpointReconcilerTypeMasterCount:
+		if replicas := tcp.Spec.ControlPlane.Deployment.Replicas; replicas != nil && *replicas == 0 {
+			return AdmissionWarning{Message: "the master-count endpoint reconciler is used with no replicas, the kubernetes Service endpoints will be stale"}
+		}
+	}
+
+	return nil
+}
+
+// checkAllowPrivileged warns when the privileged containers are disallowed along with addons requiring them:
+// the outcome depends on the deployed manifests, thus the request is not rejected.
+func (t TenantControlPlaneAPIServer) checkAllowPrivileged(tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if apiServer := tcp.Spec.ControlPlane.APIServer; apiServer == nil || apiServer.AllowPrivileged == nil || *apiServer.AllowPrivileged {
+		return nil
+	}
+
+	switch {
+	case tcp.Spec.Addons.KubeProxy != nil:
+		return AdmissionWarning{Message: "the privileged containers are disallowed, the kube-proxy addon requires them and will not be able to run"}
+	case tcp.Spec.Addons.CNI != nil:
+		return AdmissionWarning{Message: "the privileged containers are disallowed, the CNI addon workloads could require them and not be able to run"}
+	default:
+		return nil
+	}
+}
+
+// apiServerFeatureGates returns the kube-apiserver feature gates provided with the extra arguments, sorted by name:
+// the malformed ones are rejected, since the API Server would fail to start. The gates known by the Kubernetes version
+// are not checked, since their list is part of the Kubernetes sources, rather than of the libraries vendored by Kamaji.
+func apiServerFeatureGates(tcp *kamajiv1alpha1.TenantControlPlane) ([]string, error) {
+	if tcp.Spec.ControlPlane.Deployment.ExtraArgs == nil {
+		return nil, nil
+	}
+
+	gates := sets.New[string]()
+
+	for _, arg := range tcp.Spec.ControlPlane.Deployment.ExtraArgs.APIServer {
+		value, found := strings.CutPrefix(arg, "--feature-gates=")
+		if !found {
+			continue
+		}
+
+		for _, gate := range strings.Split(value, ",") {
+			name, enabled, _ := strings.Cut(strings.TrimSpace(gate), "=")
+			if len(name) == 0 {
+				return nil, fmt.Errorf("the kube-apiserver feature gate %q must be in the <name>=<bool> form", gate)
+			}
+
+			if _, err := strconv.ParseBool(enabled); err != nil {
+				return nil, fmt.Errorf("the kube-apiserver feature gate %q must be enabled, or disabled, with a boolean value", name)
+			}
+
+			gates.Insert(name)
+		}
+	}
+
+	return sets.List(gates), nil
+}
+
+func (t TenantControlPlaneAPIServer) validateAPIServer(version string, apiServer *kamajiv1alpha1.APIServerSpec) error {
+	if apiServer == nil {
+		return nil
+	}
+
+	if apiServer.RequestTimeout != nil && apiServer.RequestTimeout.Duration <= 0 {
+		return fmt.Errorf("the kube-apiserver request timeout must be a positive duration, got %s", apiServer.RequestTimeout.Duration)
+	}
+
+	if err := t.validateAdmissionControllersOrder(apiServer.AdmissionControllersOrder); err != nil {
+		return err
+	}
+
+	if err := t.validateAPFBootstrap(apiServer.APF); err != nil {
+		return err
+	}
+
+	if err := t.validateOIDC(apiServer.OIDC); err != nil {
+		return err
+	}
+
+	if err := t.validateGracefulShutdown(version, apiServer.GracefulShutdown); err != nil {
+		return err
+	}
+
+	if err := t.validateTuning(apiServer.Tuning); err != nil {
+		return err
+	}
+
+	for _, origin := range apiServer.CORSAllowedOrigins {
+		// The origins are comma-separated in the kube-apiserver flag.
+		if len(origin) == 0 || strings.Contains(origin, ",") {
+			return fmt.Errorf("the kube-apiserver CORS allowed origin %q must be a non-empty regular expression with no commas", origin)
+		}
+
+		if _, err := regexp.Compile(origin); err != nil {
+			return fmt.Errorf("the kube-apiserver CORS allowed origin %q is not a valid regular expression: %w", origin, err)
+		}
+	}
+
+	for index, sniCert := range apiServer.SNICerts {
+		if len(sniCert.Certificate.FilePath) > 0 || len(sniCert.PrivateKey.FilePath) > 0 {
+			return fmt.Errorf("the kube-apiserver SNI certificate at index %d cannot be read from the Kamaji Pod filesystem, the file path is supported by DataStores only", index)
+		}
+
+		for _, hostname := range sniCert.Hostnames {
+			if len(hostname) == 0 {
+				return fmt.Errorf("the kube-apiserver SNI certificate at index %d has an empty hostname", index)
+			}
+		}
+		// Certificates referenced from Secrets are verified upon reconciliation.
+		if len(sniCert.Certificate.Content) == 0 || len(sniCert.PrivateKey.Content) == 0 {
+			continue
+		}
+
+		if err := crypto.VerifyCertificateHostnames(sniCert.Certificate.Content, sniCert.PrivateKey.Content, sniCert.Hostnames...); err != nil {
+			return fmt.Errorf("the kube-apiserver SNI certificate at index %d is not valid: %w", index, err)
+		}
+	}
+
+	return nil
+}
+
+func (t TenantControlPlaneAPIServer) validateGracefulShutdown(version string, gracefulShutdown *kamajiv1alpha1.APIServerGracefulShutdownSpec) error {
+	if gracefulShutdown == nil || gracefulShutdown.WatchTerminationGracePeriod == nil {
+		return nil
+	}
+
+	if period := gracefulShutdown.WatchTerminationGracePeriod.Duration; period < 0 {
+		return fmt.Errorf("the kube-apiserver watch termination grace period must be a non-negative duration, got %s", period)
+	}
+
+	if !controlplane.SupportsWatchTerminationGracePeriod(version) {
+		return fmt.Errorf("the kube-apiserver watch termination grace period is not supported by the Kubernetes version %s", version)
+	}
+
+	return nil
+}
+
+func (t TenantControlPlaneAPIServer) validateTuning(tuning *kamajiv1alpha1.APIServerTuningSpec) error {
+	if tuning == nil {
+		return nil
+	}
+
+	if tuning.DefaultWatchCacheSize != nil && *tuning.DefaultWatchCacheSize < 0 {
+		return fmt.Errorf("the kube-apiserver default watch cache size cannot be negative, got %d", *tuning.DefaultWatchCacheSize)
+	}
+
+	if tuning.WatchCache != nil && !*tuning.WatchCache && (tuning.DefaultWatchCacheSize != nil || len(tuning.WatchCacheSizes) > 0) {
+		return fmt.Errorf("the kube-apiserver watch cache sizes cannot be specified when the watch cache is disabled")
+	}
+
+	for resource, size := range tuning.WatchCacheSizes {
+		if !watchCacheResourceRegexp.MatchString(resource) {
+			return fmt.Errorf("the kube-apiserver watch cache resource %q is not valid, it must be a lowercase plural resource name, qualified by the API group for the non-core ones", resource)
+		}
+
+		if size < 0 {
+			return fmt.Errorf("the kube-apiserver watch cache size of the %s resource cannot be negative, got %d", resource, size)
+		}
+	}
+
+	return nil
+}
+
+func (t TenantControlPlaneAPIServer) validateOIDC(oidc *kamajiv1alpha1.OIDCSpec) error {
+	if oidc == nil {
+		return nil
+	}
+
+	issuer, err := url.Parse(oidc.IssuerURL)
+	if err != nil || issuer.Scheme != "https" || len(issuer.Host) == 0 {
+		return fmt.Errorf("the OIDC issuer URL %q is not valid, it must be an absolute HTTPS URL", oidc.IssuerURL)
+	}
+
+	found := sets.New[kamajiv1alpha1.OIDCSigningAlgorithm]()
+
+	for _, alg := range oidc.SigningAlgs {
+		if !allowedOIDCSigningAlgs.Has(string(alg)) {
+			return fmt.Errorf("the OIDC signing algorithm %s is not supported, must be one of %s", alg, strings.Join(sets.List(allowedOIDCSigningAlgs), ", "))
+		}
+
+		if found.Has(alg) {
+			return fmt.Errorf("the OIDC signing algorithm %s is repeated", alg)
+		}
+
+		found.Insert(alg)
+	}
+
+	return nil
+}
+
+func (t TenantControlPlaneAPIServer) validateAPFBootstrap(apf *kamajiv1alpha1.APFSpec) error {
+	if apf == nil || apf.Bootstrap == nil {
+		return nil
+	}
+
+	if apf.Enabled != nil && !*apf.Enabled {
+		return fmt.Errorf("the API Priority and Fairness bootstrap requires the API Priority and Fairness to be enabled")
+	}
+
+	if (len(apf.Bootstrap.Manifests) == 0) == (apf.Bootstrap.ConfigMapRef == nil) {
+		return fmt.Errorf("the API Priority and Fairness bootstrap requires either the inline manifests or a ConfigMap reference")
+	}
+
+	if apf.Bootstrap.ConfigMapRef != nil && len(apf.Bootstrap.ConfigMapRef.Name) == 0 {
+		return fmt.Errorf("the API Priority and Fairness bootstrap ConfigMap reference requires a name")
+	}
+	// The manifests from the ConfigMap are validated upon reconciliation.
+	if err := addons.ValidateAPFBootstrapManifests(apf.Bootstrap.Manifests); err != nil {
+		return fmt.Errorf("the API Priority and Fairness bootstrap manifests are not valid: %w", err)
+	}
+
+	return nil
+}
+
+func (t TenantControlPlaneAPIServer) validateAdmissionControllersOrder(order kamajiv1alpha1.AdmissionControllers) error {
+	if len(order) == 0 {
+		return nil
+	}
+
+	found := make(map[kamajiv1alpha1.AdmissionController]struct{}, len(order))
+
+	for _, admissionController := range order {
+		if _, ok := found[admissionController]; ok {
+			return fmt.Errorf("the Admission Controller %s is repeated in the ordered list", admissionController)
+		}
+
+		found[admissionController] = struct{}{}
+	}
+
+	for _, admissionController := range mandatoryAdmissionControllers {
+		if _, ok := found[admissionController]; !ok {
+			return fmt.Errorf("the Admission Controller %s is required by Kamaji and cannot be omitted from the ordered list", admissionController)
+		}
+	}
+
+	return nil
+}
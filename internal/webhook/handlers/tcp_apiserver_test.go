@@ -0,0 +1,56 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	pointer "k8s.io/utils/ptr"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestTenantControlPlaneAPIServerWarnings(t *testing.T) {
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	tcp.Spec.ControlPlane.APIServer = &kamajiv1alpha1.APIServerSpec{
+		EndpointReconcilerType: kamajiv1alpha1.EndpointReconcilerTypeNone,
+		AllowPrivileged:        pointer.To(false),
+	}
+	tcp.Spec.Addons.KubeProxy = &kamajiv1alpha1.AddonSpec{}
+
+	err := TenantControlPlaneAPIServer{}.validate(tcp)
+
+	var warnings AdmissionWarnings
+	if !errors.As(err, &warnings) {
+		t.Fatalf("expected the admission warnings, got %v", err)
+	}
+
+	got := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		got = append(got, warning.Message)
+	}
+
+	want := []string{
+		"the endpoint reconciler is disabled, the kubernetes Service endpoints of the Tenant Cluster must be managed externally",
+		"the privileged containers are disallowed, the kube-proxy addon requires them and will not be able to run",
+	}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected the warnings %q, got %q", want, got)
+	}
+
+	tcp.Spec.ControlPlane.APIServer.AllowPrivileged = nil
+
+	if err = (TenantControlPlaneAPIServer{}).validate(tcp); !errors.As(err, &warnings) || len(warnings) != 1 {
+		t.Fatalf("expected a single admission warning, got %v", err)
+	}
+
+	tcp.Spec.ControlPlane.APIServer.EndpointReconcilerType = ""
+
+	if err = (TenantControlPlaneAPIServer{}).validate(tcp); err != nil {
+		t.Fatalf("expected no admission warnings, got %v", err)
+	}
+}
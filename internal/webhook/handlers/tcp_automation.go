@@ -0,0 +1,80 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/api/validation/path"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// minimumTokenTTL is the lowest expiration accepted by the TokenRequest API.
+const minimumTokenTTL = 10 * time.Minute
+
+type TenantControlPlaneAutomation struct{}
+
+func (t TenantControlPlaneAutomation) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validateAutomation(tcp.Spec.ControlPlane.Automation)
+	}
+}
+
+func (t TenantControlPlaneAutomation) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneAutomation) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validateAutomation(tcp.Spec.ControlPlane.Automation)
+	}
+}
+
+func (t TenantControlPlaneAutomation) validateAutomation(automation *kamajiv1alpha1.AutomationSpec) error {
+	if automation == nil {
+		return nil
+	}
+
+	if errs := validation.IsDNS1123Subdomain(automation.ServiceAccountName); len(errs) > 0 {
+		return fmt.Errorf("automation ServiceAccount name %q is not valid: %s", automation.ServiceAccountName, strings.Join(errs, ", "))
+	}
+
+	if automation.TokenTTL.Duration < minimumTokenTTL {
+		return fmt.Errorf("automation token TTL cannot be lower than %s", minimumTokenTTL)
+	}
+
+	roles := sets.New[string]()
+
+	for _, role := range automation.ClusterRoles {
+		if len(role) == 0 {
+			return fmt.Errorf("automation ClusterRole reference cannot be empty")
+		}
+
+		if errs := path.IsValidPathSegmentName(role); len(errs) > 0 {
+			return fmt.Errorf("automation ClusterRole reference %q is not valid: %s", role, strings.Join(errs, ", "))
+		}
+
+		if roles.Has(role) {
+			return fmt.Errorf("automation ClusterRole reference is stated multiple times: %s", role)
+		}
+
+		roles.Insert(role)
+	}
+
+	return nil
+}
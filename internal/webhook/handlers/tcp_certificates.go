@@ -0,0 +1,60 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+type TenantControlPlaneCertificates struct{}
+
+func (t TenantControlPlaneCertificates) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validateCertificates(tcp.Spec.ControlPlane.Certificates)
+	}
+}
+
+func (t TenantControlPlaneCertificates) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneCertificates) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validateCertificates(tcp.Spec.ControlPlane.Certificates)
+	}
+}
+
+func (t TenantControlPlaneCertificates) validateCertificates(certificates *kamajiv1alpha1.CertificatesSpec) error {
+	if certificates == nil || certificates.ClusterCABundleRef == nil {
+		return nil
+	}
+
+	bundle := certificates.ClusterCABundleRef
+
+	switch {
+	case len(bundle.FilePath) > 0:
+		return fmt.Errorf("the cluster CA bundle cannot be read from the Kamaji Pod filesystem, the file path is supported by DataStores only")
+	case len(bundle.Content) > 0:
+		if err := crypto.VerifyCABundle(bundle.Content); err != nil {
+			return fmt.Errorf("the cluster CA bundle is not valid: %w", err)
+		}
+	case bundle.SecretRef == nil:
+		return fmt.Errorf("the cluster CA bundle requires either the bare content or a Secret reference")
+	}
+	// The bundle referenced from a Secret is verified upon reconciliation.
+	return nil
+}
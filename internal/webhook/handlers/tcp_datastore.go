@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/distribution/reference"
 	"gomodules.xyz/jsonpatch/v2"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -15,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/webhook/utils"
 )
 
@@ -26,7 +28,7 @@ func (t TenantControlPlaneDataStore) OnCreate(object runtime.Object) AdmissionRe
 	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
 		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
 
-		return nil, t.check(ctx, tcp.Spec.DataStore)
+		return nil, t.check(ctx, tcp)
 	}
 }
 
@@ -34,22 +36,145 @@ func (t TenantControlPlaneDataStore) OnDelete(runtime.Object) AdmissionResponse
 	return utils.NilOp()
 }
 
-func (t TenantControlPlaneDataStore) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+func (t TenantControlPlaneDataStore) OnUpdate(object runtime.Object, oldObject runtime.Object) AdmissionResponse {
 	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
-		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+		newTCP, oldTCP := object.(*kamajiv1alpha1.TenantControlPlane), oldObject.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		if err := t.check(ctx, newTCP); err != nil {
+			return nil, err
+		}
+
+		if err := t.checkMigrationAllowed(newTCP, oldTCP); err != nil {
+			return nil, err
+		}
 
-		return nil, t.check(ctx, tcp.Spec.DataStore)
+		return nil, t.checkCrossDriverMigration(ctx, newTCP, oldTCP)
 	}
 }
 
-func (t TenantControlPlaneDataStore) check(ctx context.Context, dataStoreName string) error {
-	if err := t.Client.Get(ctx, types.NamespacedName{Name: dataStoreName}, &kamajiv1alpha1.DataStore{}); err != nil {
-		if k8serrors.IsNotFound(err) {
-			return fmt.Errorf("%s DataStore does not exist", dataStoreName)
+func (t TenantControlPlaneDataStore) check(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	ds, err := t.getDataStore(ctx, tcp.Spec.DataStore)
+	if err != nil {
+		return err
+	}
+
+	return t.checkOptions(tcp.Spec.DataStoreOptions, ds)
+}
+
+func (t TenantControlPlaneDataStore) checkOptions(opts *kamajiv1alpha1.DataStoreOptions, ds *kamajiv1alpha1.DataStore) error {
+	if opts == nil {
+		return nil
+	}
+
+	if err := t.checkKineOptions(opts.Kine, ds); err != nil {
+		return err
+	}
+
+	if opts.Etcd == nil {
+		return nil
+	}
+
+	if ds.Spec.Driver != kamajiv1alpha1.EtcdDriver {
+		return fmt.Errorf("etcd options cannot be used with the %s DataStore, backed by the %s driver", ds.GetName(), ds.Spec.Driver)
+	}
+
+	if interval := opts.Etcd.APIServerCompactionInterval; interval != nil && interval.Duration < 0 {
+		return fmt.Errorf("the kube-apiserver compaction interval cannot be a negative duration, got %s", interval.Duration)
+	}
+
+	return nil
+}
+
+func (t TenantControlPlaneDataStore) checkKineOptions(opts *kamajiv1alpha1.KineDataStoreOptions, ds *kamajiv1alpha1.DataStore) error {
+	if opts == nil {
+		return nil
+	}
+
+	if ds.Spec.Driver == kamajiv1alpha1.EtcdDriver {
+		return fmt.Errorf("kine options cannot be used with the %s DataStore, backed by the %s driver", ds.GetName(), ds.Spec.Driver)
+	}
+
+	if len(opts.Image) > 0 {
+		if _, err := reference.ParseNormalizedNamed(opts.Image); err != nil {
+			return fmt.Errorf("the kine image %s is not a valid image reference: %w", opts.Image, err)
 		}
+	}
+
+	pool := opts.ConnectionPool
+	if pool == nil {
+		return nil
+	}
+
+	if pool.MaxIdleConnections != nil && *pool.MaxIdleConnections <= 0 {
+		return fmt.Errorf("the kine max idle connections must be a positive value, got %d", *pool.MaxIdleConnections)
+	}
+
+	if pool.MaxOpenConnections != nil && *pool.MaxOpenConnections <= 0 {
+		return fmt.Errorf("the kine max open connections must be a positive value, got %d", *pool.MaxOpenConnections)
+	}
+
+	if pool.MaxIdleConnections != nil && pool.MaxOpenConnections != nil && *pool.MaxIdleConnections > *pool.MaxOpenConnections {
+		return fmt.Errorf("the kine max idle connections cannot be greater than the max open connections")
+	}
 
-		return fmt.Errorf("an unexpected error occurred upon Tenant Control Plane DataStore check, %w", err)
+	if lifetime := pool.ConnectionMaxLifetime; lifetime != nil && lifetime.Duration <= 0 {
+		return fmt.Errorf("the kine connection max lifetime must be a positive duration, got %s", lifetime.Duration)
 	}
 
 	return nil
 }
+
+// checkMigrationAllowed ensures the change of the DataStore has been explicitly allowed,
+// since it triggers the migration of the data of a live Tenant Control Plane.
+func (t TenantControlPlaneDataStore) checkMigrationAllowed(newTCP, oldTCP *kamajiv1alpha1.TenantControlPlane) error {
+	if oldTCP.Spec.DataStore == "" || newTCP.Spec.DataStore == oldTCP.Spec.DataStore {
+		return nil
+	}
+
+	if newTCP.GetAnnotations()[constants.AllowDataStoreMigration] == "true" {
+		return nil
+	}
+
+	return fmt.Errorf("changing the DataStore from %s to %s requires the %s annotation set to true", oldTCP.Spec.DataStore, newTCP.Spec.DataStore, constants.AllowDataStoreMigration)
+}
+
+// checkCrossDriverMigration ensures a migration to a DataStore with a different driver has been explicitly confirmed,
+// since it requires the translation of the key-space.
+func (t TenantControlPlaneDataStore) checkCrossDriverMigration(ctx context.Context, newTCP, oldTCP *kamajiv1alpha1.TenantControlPlane) error {
+	if oldTCP.Spec.DataStore == "" || newTCP.Spec.DataStore == oldTCP.Spec.DataStore {
+		return nil
+	}
+
+	if newTCP.GetAnnotations()[constants.CrossDriverMigrationConfirmation] == "true" {
+		return nil
+	}
+
+	oldDs, err := t.getDataStore(ctx, oldTCP.Spec.DataStore)
+	if err != nil {
+		return err
+	}
+
+	newDs, err := t.getDataStore(ctx, newTCP.Spec.DataStore)
+	if err != nil {
+		return err
+	}
+
+	if oldDs.Spec.Driver != newDs.Spec.Driver {
+		return fmt.Errorf("migrating from %s DataStore (%s) to %s DataStore (%s) requires the %s annotation set to true", oldDs.GetName(), oldDs.Spec.Driver, newDs.GetName(), newDs.Spec.Driver, constants.CrossDriverMigrationConfirmation)
+	}
+
+	return nil
+}
+
+func (t TenantControlPlaneDataStore) getDataStore(ctx context.Context, dataStoreName string) (*kamajiv1alpha1.DataStore, error) {
+	ds := &kamajiv1alpha1.DataStore{}
+	if err := t.Client.Get(ctx, types.NamespacedName{Name: dataStoreName}, ds); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%s DataStore does not exist", dataStoreName)
+		}
+
+		return nil, fmt.Errorf("an unexpected error occurred upon Tenant Control Plane DataStore check, %w", err)
+	}
+
+	return ds, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+)
+
+func TestTenantControlPlaneDataStoreCrossDriverMigration(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	dataStore := func(name string, driver kamajiv1alpha1.Driver) *kamajiv1alpha1.DataStore {
+		return &kamajiv1alpha1.DataStore{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: kamajiv1alpha1.DataStoreSpec{Driver: driver}}
+	}
+
+	handler := TenantControlPlaneDataStore{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(dataStore("etcd-a", kamajiv1alpha1.EtcdDriver), dataStore("etcd-b", kamajiv1alpha1.EtcdDriver), dataStore("mysql", kamajiv1alpha1.KineMySQLDriver)).
+			Build(),
+	}
+
+	tcp := func(dataStore string, confirmed bool) *kamajiv1alpha1.TenantControlPlane {
+		tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tcp"}}
+		tcp.Spec.DataStore = dataStore
+
+		if confirmed {
+			tcp.SetAnnotations(map[string]string{constants.CrossDriverMigrationConfirmation: "true"})
+		}
+
+		return tcp
+	}
+
+	for _, tc := range []struct {
+		name      string
+		old       string
+		new       string
+		confirmed bool
+		wantErr   bool
+	}{
+		{name: "unchanged", old: "mysql", new: "mysql"},
+		{name: "same driver", old: "etcd-a", new: "etcd-b"},
+		{name: "default DataStore", old: "", new: "mysql"},
+		{name: "cross driver", old: "etcd-a", new: "mysql", wantErr: true},
+		{name: "confirmed cross driver", old: "etcd-a", new: "mysql", confirmed: true},
+		{name: "missing DataStore", old: "etcd-a", new: "missing", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := handler.checkCrossDriverMigration(context.Background(), tcp(tc.new, tc.confirmed), tcp(tc.old, false))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected the error %t, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTenantControlPlaneDataStoreMigrationAllowed(t *testing.T) {
+	tcp := func(dataStore string, annotations map[string]string) *kamajiv1alpha1.TenantControlPlane {
+		tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tcp", Annotations: annotations}}
+		tcp.Spec.DataStore = dataStore
+
+		return tcp
+	}
+
+	allowed := map[string]string{constants.AllowDataStoreMigration: "true"}
+
+	for _, tc := range []struct {
+		name        string
+		old         string
+		new         string
+		annotations map[string]string
+		wantErr     bool
+	}{
+		{name: "unchanged", old: "etcd-a", new: "etcd-a"},
+		{name: "first assignment", old: "", new: "etcd-a"},
+		{name: "changed", old: "etcd-a", new: "etcd-b", wantErr: true},
+		{name: "allowed change", old: "etcd-a", new: "etcd-b", annotations: allowed},
+		{name: "annotation not true", old: "etcd-a", new: "etcd-b", annotations: map[string]string{constants.AllowDataStoreMigration: "yes"}, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := TenantControlPlaneDataStore{}.checkMigrationAllowed(tcp(tc.new, tc.annotations), tcp(tc.old, nil))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected the error %t, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTenantControlPlaneDataStoreKineImage(t *testing.T) {
+	mysql := &kamajiv1alpha1.DataStore{ObjectMeta: metav1.ObjectMeta{Name: "mysql"}, Spec: kamajiv1alpha1.DataStoreSpec{Driver: kamajiv1alpha1.KineMySQLDriver}}
+	etcd := &kamajiv1alpha1.DataStore{ObjectMeta: metav1.ObjectMeta{Name: "etcd"}, Spec: kamajiv1alpha1.DataStoreSpec{Driver: kamajiv1alpha1.EtcdDriver}}
+
+	for _, tc := range []struct {
+		name      string
+		image     string
+		dataStore *kamajiv1alpha1.DataStore
+		wantErr   bool
+	}{
+		{name: "no override", dataStore: mysql},
+		{name: "tagged image", image: "rancher/kine:v0.11.4-amd64", dataStore: mysql},
+		{name: "registry with port", image: "registry.local:5000/kine:v0.11.4", dataStore: mysql},
+		{name: "digest", image: "rancher/kine@sha256:" + strings.Repeat("a", 64), dataStore: mysql},
+		{name: "invalid reference", image: "rancher/Kine:v0.11.4", dataStore: mysql, wantErr: true},
+		{name: "invalid tag", image: "rancher/kine:v0.11.4:amd64", dataStore: mysql, wantErr: true},
+		{name: "etcd DataStore", image: "rancher/kine:v0.11.4-amd64", dataStore: etcd, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := TenantControlPlaneDataStore{}.checkKineOptions(&kamajiv1alpha1.KineDataStoreOptions{Image: tc.image}, tc.dataStore)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected the error %t, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
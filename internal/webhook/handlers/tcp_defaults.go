@@ -6,28 +6,90 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	"gomodules.xyz/jsonpatch/v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	pointer "k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/webhook/utils"
 )
 
+// InflightLimits are the kube-apiserver inflight limits applied by default to the Tenant Control Planes of a DataStore tier.
+type InflightLimits struct {
+	MaxRequestsInflight         int32
+	MaxMutatingRequestsInflight int32
+}
+
+// ParseInflightLimits parses the DataStore tier mapping to the inflight limits,
+// each value must be in the <max-requests-inflight>/<max-mutating-requests-inflight> form.
+func ParseInflightLimits(mapping map[string]string) (map[string]InflightLimits, error) {
+	limits := make(map[string]InflightLimits, len(mapping))
+
+	for tier, value := range mapping {
+		parts := strings.Split(value, "/")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("inflight limits of tier %s must be in the <max-requests-inflight>/<max-mutating-requests-inflight> form, got %s", tier, value)
+		}
+
+		var values [2]int32
+
+		for i, part := range parts {
+			v, err := strconv.ParseInt(part, 10, 32)
+			if err != nil || v <= 0 {
+				return nil, fmt.Errorf("inflight limits of tier %s must be positive integers, got %s", tier, value)
+			}
+
+			values[i] = int32(v)
+		}
+
+		limits[tier] = InflightLimits{MaxRequestsInflight: values[0], MaxMutatingRequestsInflight: values[1]}
+	}
+
+	return limits, nil
+}
+
 type TenantControlPlaneDefaults struct {
+	Client           client.Client
 	DefaultDatastore string
+	// InflightDefaults maps the DataStore tier to the kube-apiserver inflight limits,
+	// the tier is defined by the DataStore label kamaji.clastix.io/datastore-tier, or its driver if missing.
+	InflightDefaults map[string]InflightLimits
 }
 
 func (t TenantControlPlaneDefaults) OnCreate(object runtime.Object) AdmissionResponse {
 	return func(ctx context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
 		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
 
-		if len(tcp.Spec.DataStore) == 0 {
+		dataStoreName := tcp.Spec.DataStore
+		if len(dataStoreName) == 0 {
+			dataStoreName = t.DefaultDatastore
+		}
+
+		if len(dataStoreName) == 0 {
+			return nil, fmt.Errorf("the DataStore is not specified, and no default DataStore is configured")
+		}
+
+		limits, err := t.inflightLimits(ctx, dataStoreName)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(tcp.Spec.DataStore) == 0 || limits != nil {
 			operations, err := utils.JSONPatch(tcp, func() {
-				tcp.Spec.DataStore = t.DefaultDatastore
+				tcp.Spec.DataStore = dataStoreName
+
+				if limits != nil {
+					t.setInflightLimits(tcp, *limits)
+				}
 			})
 			if err != nil {
 				return nil, errors.Wrap(err, "cannot create patch responses upon Tenant Control Plane creation")
@@ -67,3 +129,47 @@ func (t TenantControlPlaneDefaults) OnUpdate(object runtime.Object, oldObject ru
 		return nil, nil
 	}
 }
+
+// inflightLimits returns the inflight limits of the given DataStore tier, if any.
+func (t TenantControlPlaneDefaults) inflightLimits(ctx context.Context, dataStoreName string) (*InflightLimits, error) {
+	if len(t.InflightDefaults) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	ds := &kamajiv1alpha1.DataStore{}
+	if err := t.Client.Get(ctx, types.NamespacedName{Name: dataStoreName}, ds); err != nil {
+		// The missing DataStore is reported by the validation webhook.
+		if k8serrors.IsNotFound(err) {
+			return nil, nil //nolint:nilnil
+		}
+
+		return nil, errors.Wrap(err, "cannot retrieve the DataStore for the inflight limits defaults")
+	}
+
+	tier, ok := ds.GetLabels()[constants.DataStoreTierLabel]
+	if !ok {
+		tier = string(ds.Spec.Driver)
+	}
+
+	limits, ok := t.InflightDefaults[tier]
+	if !ok {
+		return nil, nil //nolint:nilnil
+	}
+
+	return &limits, nil
+}
+
+// setInflightLimits applies the given defaults, unless overridden by the Tenant Control Plane.
+func (t TenantControlPlaneDefaults) setInflightLimits(tcp *kamajiv1alpha1.TenantControlPlane, limits InflightLimits) {
+	if tcp.Spec.ControlPlane.APIServer == nil {
+		tcp.Spec.ControlPlane.APIServer = &kamajiv1alpha1.APIServerSpec{}
+	}
+
+	if tcp.Spec.ControlPlane.APIServer.MaxRequestsInflight == nil {
+		tcp.Spec.ControlPlane.APIServer.MaxRequestsInflight = pointer.To(limits.MaxRequestsInflight)
+	}
+
+	if tcp.Spec.ControlPlane.APIServer.MaxMutatingRequestsInflight == nil {
+		tcp.Spec.ControlPlane.APIServer.MaxMutatingRequestsInflight = pointer.To(limits.MaxMutatingRequestsInflight)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneKonnectivity prevents disabling the Konnectivity addon when the network profile requires it,
+// since the API Server would not be able to reach the worker nodes, breaking logs, exec, and port-forward.
+type TenantControlPlaneKonnectivity struct {
+	// Strict rejects the request, otherwise it's allowed with a warning.
+	Strict bool
+}
+
+func (t TenantControlPlaneKonnectivity) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validateKonnectivity(tcp)
+	}
+}
+
+func (t TenantControlPlaneKonnectivity) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneKonnectivity) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validateKonnectivity(tcp)
+	}
+}
+
+func (t TenantControlPlaneKonnectivity) validateKonnectivity(tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if !tcp.Spec.NetworkProfile.KonnectivityRequired || tcp.Spec.Addons.Konnectivity != nil {
+		return nil
+	}
+
+	message := "the network profile requires the Konnectivity addon, without it the API Server cannot reach the worker nodes, breaking logs, exec, and port-forward"
+
+	if !t.Strict {
+		return AdmissionWarning{Message: message}
+	}
+
+	return errors.New(message)
+}
@@ -0,0 +1,167 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// CIDROverlapPolicy defines how the overlapping CIDRs of the Tenant Control Planes sharing a network are handled.
+type CIDROverlapPolicy string
+
+const (
+	CIDROverlapPolicyIgnore CIDROverlapPolicy = "Ignore"
+	CIDROverlapPolicyWarn   CIDROverlapPolicy = "Warn"
+	CIDROverlapPolicyReject CIDROverlapPolicy = "Reject"
+)
+
+func (c CIDROverlapPolicy) Validate() error {
+	switch c {
+	case CIDROverlapPolicyIgnore, CIDROverlapPolicyWarn, CIDROverlapPolicyReject:
+		return nil
+	default:
+		return fmt.Errorf("the CIDR overlap policy %q is not valid, must be one of %s, %s, or %s", c, CIDROverlapPolicyIgnore, CIDROverlapPolicyWarn, CIDROverlapPolicyReject)
+	}
+}
+
+type TenantControlPlaneNetworkProfile struct {
+	Client client.Client
+	// CIDROverlapPolicy handles the Pod and Service CIDRs overlapping with the ones of the other
+	// Tenant Control Planes sharing the same network, as defined by the kamaji.clastix.io/network label.
+	CIDROverlapPolicy CIDROverlapPolicy
+}
+
+func (t TenantControlPlaneNetworkProfile) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		if err := t.validateNetworkProfile(tcp.Spec.NetworkProfile); err != nil {
+			return nil, err
+		}
+
+		return nil, t.checkCIDROverlap(ctx, tcp)
+	}
+}
+
+func (t TenantControlPlaneNetworkProfile) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneNetworkProfile) OnUpdate(object runtime.Object, prev runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+		old := prev.(*kamajiv1alpha1.TenantControlPlane)   //nolint:forcetypeassert
+
+		if err := t.validateNetworkProfile(tcp.Spec.NetworkProfile); err != nil {
+			return nil, err
+		}
+		// Checking the overlaps only upon changes, an already overlapping tenant must not be prevented from being updated.
+		if tcp.GetLabels()[constants.NetworkLabel] == old.GetLabels()[constants.NetworkLabel] &&
+			tcp.Spec.NetworkProfile.PodCIDR == old.Spec.NetworkProfile.PodCIDR &&
+			tcp.Spec.NetworkProfile.ServiceCIDR == old.Spec.NetworkProfile.ServiceCIDR {
+			return nil, nil
+		}
+
+		return nil, t.checkCIDROverlap(ctx, tcp)
+	}
+}
+
+func (t TenantControlPlaneNetworkProfile) validateNetworkProfile(networkProfile kamajiv1alpha1.NetworkProfileSpec) error {
+	if len(networkProfile.ServiceNodePortRange) > 0 {
+		portRange, err := utilnet.ParsePortRange(networkProfile.ServiceNodePortRange)
+		if err != nil {
+			return fmt.Errorf("the Service NodePort range %q is not valid: %w", networkProfile.ServiceNodePortRange, err)
+		}
+
+		if portRange.Base == 0 {
+			return fmt.Errorf("the Service NodePort range %q cannot start from port 0", networkProfile.ServiceNodePortRange)
+		}
+	}
+
+	return nil
+}
+
+// checkCIDROverlap ensures the Pod and Service CIDRs don't overlap with the ones of the
+// other Tenant Control Planes sharing the same network, according to the configured policy.
+func (t TenantControlPlaneNetworkProfile) checkCIDROverlap(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if len(t.CIDROverlapPolicy) == 0 || t.CIDROverlapPolicy == CIDROverlapPolicyIgnore {
+		return nil
+	}
+
+	network := tcp.GetLabels()[constants.NetworkLabel]
+	if len(network) == 0 {
+		return nil
+	}
+
+	tcpList := &kamajiv1alpha1.TenantControlPlaneList{}
+	if err := t.Client.List(ctx, tcpList, client.MatchingFields{kamajiv1alpha1.TenantControlPlaneNetworkKey: network}); err != nil {
+		return errors.Wrap(err, "cannot list the Tenant Control Planes sharing the same network")
+	}
+
+	for i := range tcpList.Items {
+		item := &tcpList.Items[i]
+
+		if item.GetNamespace() == tcp.GetNamespace() && item.GetName() == tcp.GetName() {
+			continue
+		}
+
+		for _, pair := range [][2]string{
+			{"Pod", "Pod"},
+			{"Pod", "Service"},
+			{"Service", "Pod"},
+			{"Service", "Service"},
+		} {
+			cidr, otherCIDR := networkProfileCIDR(tcp, pair[0]), networkProfileCIDR(item, pair[1])
+			if !overlappingCIDRs(cidr, otherCIDR) {
+				continue
+			}
+
+			message := fmt.Sprintf("the %s CIDR %s overlaps with the %s CIDR %s of the Tenant Control Plane %s/%s sharing the network %s", pair[0], cidr, pair[1], otherCIDR, item.GetNamespace(), item.GetName(), network)
+
+			if t.CIDROverlapPolicy == CIDROverlapPolicyWarn {
+				return AdmissionWarning{Message: message}
+			}
+
+			return errors.New(message)
+		}
+	}
+
+	return nil
+}
+
+func networkProfileCIDR(tcp *kamajiv1alpha1.TenantControlPlane, kind string) string {
+	if kind == "Pod" {
+		return tcp.Spec.NetworkProfile.PodCIDR
+	}
+
+	return tcp.Spec.NetworkProfile.ServiceCIDR
+}
+
+// overlappingCIDRs returns true if the given CIDRs overlap, invalid or empty CIDRs are ignored.
+func overlappingCIDRs(a, b string) bool {
+	_, first, err := net.ParseCIDR(a)
+	if err != nil {
+		return false
+	}
+
+	_, second, err := net.ParseCIDR(b)
+	if err != nil {
+		return false
+	}
+
+	return first.Contains(second.IP) || second.Contains(first.IP)
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneService validates the additional metadata of the generated Service,
+// such as the cloud providers annotations tuning the LoadBalancer.
+type TenantControlPlaneService struct{}
+
+func (t TenantControlPlaneService) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validateService(tcp.Spec.ControlPlane.Service)
+	}
+}
+
+func (t TenantControlPlaneService) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneService) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validateService(tcp.Spec.ControlPlane.Service)
+	}
+}
+
+func (t TenantControlPlaneService) validateService(service kamajiv1alpha1.ServiceSpec) error {
+	fldPath := field.NewPath("spec", "controlPlane", "service", "additionalMetadata", "annotations")
+
+	if errs := apimachineryvalidation.ValidateAnnotations(service.AdditionalMetadata.Annotations, fldPath); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+
+	if _, ok := service.AdditionalMetadata.Annotations[constants.ManagedAnnotations]; ok {
+		return fmt.Errorf("the annotation %s is managed by Kamaji and cannot be specified", constants.ManagedAnnotations)
+	}
+
+	return nil
+}
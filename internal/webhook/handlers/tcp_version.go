@@ -39,6 +39,10 @@ func (t TenantControlPlaneVersion) OnCreate(object runtime.Object) AdmissionResp
 			return nil, fmt.Errorf("unable to create a TenantControlPlane with a Kubernetes version greater than the supported one, actually %s", supportedVer.String())
 		}
 
+		if err = upgrade.CheckVersionSkew(*tcp); err != nil {
+			return nil, errors.Wrap(err, "unable to create a TenantControlPlane with out-of-skew component versions")
+		}
+
 		return nil, nil
 	}
 }
@@ -83,6 +87,22 @@ func (t TenantControlPlaneVersion) OnUpdate(object runtime.Object, oldObject run
 			return nil, fmt.Errorf("unable to upgrade to a minor version in a non-sequential mode")
 		}
 
+		if err := upgrade.CheckVersionSkew(*newTCP); err != nil {
+			return nil, errors.Wrap(err, "unable to update a TenantControlPlane with out-of-skew component versions")
+		}
+		// The feature gates are passed verbatim to the API Server, which fails to start upon the ones
+		// not recognized by the target version, such as the graduated and removed ones.
+		if !newVer.EQ(oldVer) {
+			gates, err := apiServerFeatureGates(newTCP)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(gates) > 0 {
+				return nil, AdmissionWarning{Message: fmt.Sprintf("the kube-apiserver feature gates %s are passed verbatim, make sure they're recognized by the Kubernetes version %s, or the API Server will fail to start", strings.Join(gates, ", "), newTCP.Spec.Kubernetes.Version)}
+			}
+		}
+
 		return nil, nil
 	}
 }
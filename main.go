@@ -11,14 +11,16 @@ import (
 	"github.com/clastix/kamaji/cmd"
 	"github.com/clastix/kamaji/cmd/manager"
 	"github.com/clastix/kamaji/cmd/migrate"
+	"github.com/clastix/kamaji/cmd/validate"
 )
 
 func main() {
 	scheme := runtime.NewScheme()
 
-	root, mgr, migrator := cmd.NewCmd(scheme), manager.NewCmd(scheme), migrate.NewCmd(scheme)
+	root, mgr, migrator, validator := cmd.NewCmd(scheme), manager.NewCmd(scheme), migrate.NewCmd(scheme), validate.NewCmd(scheme)
 	root.AddCommand(mgr)
 	root.AddCommand(migrator)
+	root.AddCommand(validator)
 
 	if err := root.Execute(); err != nil {
 		os.Exit(1)